@@ -1,62 +1,132 @@
 package main
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 
+	"building-report-backend/internal/domain/constants"
+	"building-report-backend/internal/interfaces/http/middleware"
 	"building-report-backend/internal/interfaces/http/router"
+	"building-report-backend/internal/interfaces/job"
 	"building-report-backend/pkg/cache"
 	"building-report-backend/pkg/config"
 	"building-report-backend/pkg/container"
 	"building-report-backend/pkg/database"
+	"building-report-backend/pkg/lifecycle"
+	"building-report-backend/pkg/logger"
 	"building-report-backend/pkg/storage"
+	"building-report-backend/pkg/tracing"
+	"building-report-backend/pkg/version"
 )
 
 func main() {
 	cfg := config.Load()
 
-	db, err := database.NewPostgresDB(cfg.Database)
+	log := logger.New(logger.Config{Level: cfg.Log.Level, Format: cfg.Log.Format}, os.Stdout)
+	slog.SetDefault(log)
+
+	// Shutdown hooks run in reverse order: the server stops first, then the
+	// workers, and the connections they use are closed last.
+	lc := lifecycle.New(time.Duration(cfg.App.ShutdownTimeoutSeconds)*time.Second, log)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Enabled:     cfg.Tracing.Enabled,
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		ServiceName: cfg.Tracing.ServiceName,
+		Version:     version.Get().Version,
+		Environment: cfg.App.Env,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	}, os.Stdout)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		fatal(log, "Failed to set up tracing", err)
 	}
+	lc.OnShutdown("flush traces", shutdownTracing)
+
+	db, err := database.NewPostgresDB(cfg.Database, log, time.Duration(cfg.Log.SlowQueryMs)*time.Millisecond)
+	if err != nil {
+		fatal(log, "Failed to connect to database", err)
+	}
+	lc.OnShutdown("close postgres", func(context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.Close()
+	})
 
 	redisClient := cache.NewRedisClient(cfg.Redis)
+	lc.OnShutdown("close redis", func(context.Context) error {
+		return redisClient.Close()
+	})
 	minioClient, err := storage.NewMinioClient(cfg.Minio)
 	if err != nil {
-		log.Fatal("Failed to connect to MinIO:", err)
+		fatal(log, "Failed to connect to MinIO", err)
+	}
+
+	cont := container.NewContainer(cfg, db, redisClient, minioClient, log)
+
+	// Workers stop when the lifecycle context is cancelled at shutdown
+	trashPurgeJob := job.NewTrashPurgeJob(cont.TrashUseCase, cfg.Trash.RetentionDays, cfg.Trash.PurgeIntervalHours, log)
+	trashPurgeJob.Start(lc.Context())
+	lc.OnShutdown("stop trash purge job", trashPurgeJob.Wait)
+	uploadSweepJob := job.NewUploadSweepJob(cont.UploadUseCase, cfg.Upload.SweepIntervalMinutes, log)
+	uploadSweepJob.Start(lc.Context())
+	lc.OnShutdown("stop upload sweep job", uploadSweepJob.Wait)
+	if cfg.Metrics.Enabled {
+		reportMetricsJob := job.NewReportMetricsJob(cont.ReportMetricsRepo, cfg.Metrics.RefreshSeconds, log)
+		reportMetricsJob.Start(lc.Context())
+		lc.OnShutdown("stop report metrics job", reportMetricsJob.Wait)
 	}
 
-	cont := container.NewContainer(cfg, db, redisClient, minioClient)
+	// The proxy header is only believed from the configured proxies, so a
+	// client reaching the app directly cannot pick its own address.
+	var trustedProxies []string
+	for _, proxy := range strings.Split(cfg.App.TrustedProxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+	if cfg.App.ProxyHeader != "" && len(trustedProxies) == 0 {
+		log.Warn("APP_PROXY_HEADER is set but APP_TRUSTED_PROXIES is empty; the header will be ignored")
+	}
 
 	app := fiber.New(fiber.Config{
-		ErrorHandler: customErrorHandler,
-		BodyLimit:    10 * 1024 * 1024, // 10 MB
+		ErrorHandler: newErrorHandler(log),
+		// The whole body is buffered before any middleware runs, including on
+		// unauthenticated routes, so this stays at one photo at the per-file
+		// limit plus fields. Reports with more photos upload them directly to
+		// storage through an upload session.
+		BodyLimit:    constants.MaxFileSize + 1024*1024,
+		ProxyHeader:  cfg.App.ProxyHeader,
+		ReadTimeout:  time.Duration(cfg.App.ReadTimeoutSeconds) * time.Second,
+		WriteTimeout: time.Duration(cfg.App.WriteTimeoutSeconds) * time.Second,
+		IdleTimeout:  time.Duration(cfg.App.IdleTimeoutSeconds) * time.Second,
+
+		// With the check enabled Fiber also ignores the header when no proxy is trusted.
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          trustedProxies,
 	})
 
-	// 1. Logger middleware
-	app.Use(logger.New(logger.Config{
-		Format: "[${time}] ${status} - ${method} ${path} | ${latency}\n",
-	}))
+	// 1. Request ID, tracing, access log and metrics middleware
+	app.Use(middleware.RequestID(log))
+	app.Use(middleware.Tracing())
+	app.Use(middleware.AccessLog(log))
+	if cfg.Metrics.Enabled {
+		app.Use(middleware.Metrics())
+	}
 
 	// 2. Recover middleware
 	app.Use(recover.New())
 
-	// 3. Debug middleware (optional - bisa dihapus di production)
-	app.Use(func(c *fiber.Ctx) error {
-		origin := c.Get("Origin")
-		if origin != "" {
-			log.Printf("📍 Request from: %s | Method: %s | Path: %s", origin, c.Method(), c.Path())
-		}
-		return c.Next()
-	})
-
-	// 4. CORS middleware - HARUS SEBELUM ROUTES
+	// 3. CORS middleware - HARUS SEBELUM ROUTES
 	origins := os.Getenv("APP_ALLOWED_ORIGINS")
 	if origins == "" {
 		origins = "http://localhost:3000"
@@ -69,7 +139,7 @@ func main() {
 	}
 	cleanedOrigins := strings.Join(originList, ",")
 
-	log.Printf("🌐 CORS Allowed Origins: %s", cleanedOrigins)
+	log.Info("CORS configured", slog.String("allowed_origins", cleanedOrigins))
 
 	// Check if we're using wildcard origins to determine if credentials should be allowed
 	var allowCredentials bool
@@ -82,21 +152,21 @@ func main() {
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     cleanedOrigins, // Use actual allowed origins instead of "*"
 		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS,PATCH",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,Cache-Control,Pragma,Expires,X-Requested-With",
-		ExposeHeaders:    "Content-Length,Content-Type,Authorization", 
+		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-API-Key,Cache-Control,Pragma,Expires,X-Requested-With,X-Request-ID,traceparent,tracestate",
+		ExposeHeaders:    "Content-Length,Content-Type,Authorization,X-Request-ID",
 		AllowCredentials: allowCredentials,
 		MaxAge:           86400,
 	}))
 
-	// 5. Handle preflight requests
+	// 4. Handle preflight requests
 	app.Options("/*", func(c *fiber.Ctx) error {
 		return c.SendStatus(fiber.StatusNoContent)
 	})
 
-	// 6. Setup routes - SETELAH CORS
+	// 5. Setup routes - SETELAH CORS
 	router.SetupRoutes(app, cont)
 
-	// 7. 404 handler
+	// 6. 404 handler
 	app.Use(func(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"success": false,
@@ -110,29 +180,43 @@ func main() {
 		port = "8080"
 	}
 
-	log.Printf("🚀 Server starting on port %s", port)
-	log.Printf("📝 Environment: %s", os.Getenv("APP_ENV"))
-	
-	if err := app.Listen("0.0.0.0:" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	// Stop accepting connections and wait for in-flight requests, such as
+	// photo uploads, until the shutdown deadline
+	lc.OnShutdown("drain http server", app.ShutdownWithContext)
+
+	log.Info("Server starting", slog.String("port", port), slog.String("environment", os.Getenv("APP_ENV")))
+
+	if err := lc.Run(func() error {
+		return app.Listen("0.0.0.0:" + port)
+	}); err != nil {
+		fatal(log, "Server stopped with errors", err)
 	}
 }
 
-func customErrorHandler(c *fiber.Ctx, err error) error {
-	code := fiber.StatusInternalServerError
-	message := "Internal Server Error"
+func newErrorHandler(log *slog.Logger) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		code := fiber.StatusInternalServerError
+		message := "Internal Server Error"
 
-	if e, ok := err.(*fiber.Error); ok {
-		code = e.Code
-		message = e.Message
-	}
+		if e, ok := err.(*fiber.Error); ok {
+			code = e.Code
+			message = e.Message
+		}
 
-	// Log error untuk debugging
-	log.Printf("❌ Error: %v | Path: %s | Method: %s", err, c.Path(), c.Method())
+		// Client errors are already visible in the access log
+		if code >= fiber.StatusInternalServerError {
+			log.ErrorContext(c.Context(), "Unhandled error", logger.Err(err), slog.String("path", c.Path()), slog.String("method", c.Method()))
+		}
 
-	return c.Status(code).JSON(fiber.Map{
-		"success": false,
-		"message": message,
-		"error":   err.Error(),
-	})
+		return c.Status(code).JSON(fiber.Map{
+			"success": false,
+			"message": message,
+			"error":   err.Error(),
+		})
+	}
+}
+
+func fatal(log *slog.Logger, msg string, err error) {
+	log.Error(msg, logger.Err(err))
+	os.Exit(1)
 }
\ No newline at end of file
@@ -0,0 +1,73 @@
+// Command reconcile compares the report photos in the database with the
+// objects in MinIO. It lists objects no row refers to and rows whose object is
+// gone; with -delete it also removes the unreferenced objects. It exits with
+// status 1 when something is left to fix, so it can run from cron.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"building-report-backend/internal/application/usecase"
+	"building-report-backend/internal/infrastructure/persistence/postgres"
+	infraStorage "building-report-backend/internal/infrastructure/storage"
+	"building-report-backend/pkg/config"
+	"building-report-backend/pkg/database"
+	"building-report-backend/pkg/logger"
+	"building-report-backend/pkg/storage"
+)
+
+func main() {
+	deleteOrphans := flag.Bool("delete", false, "delete objects no photo row refers to")
+	minAge := flag.Duration("min-age", 24*time.Hour, "ignore unreferenced objects younger than this")
+	flag.Parse()
+
+	cfg := config.Load()
+	logs := logger.New(logger.Config{Level: cfg.Log.Level, Format: "text"}, os.Stderr)
+
+	db, err := database.NewPostgresDB(cfg.Database, logs, time.Duration(cfg.Log.SlowQueryMs)*time.Millisecond)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	minioClient, err := storage.NewMinioClient(cfg.Minio)
+	if err != nil {
+		log.Fatal("Failed to connect to MinIO:", err)
+	}
+
+	// Nothing is signed here, so the internal client does for both.
+	reconcile := usecase.NewReconcileUseCase(
+		postgres.NewPhotoReferenceRepository(db),
+		infraStorage.NewMinioStorage(minioClient, minioClient, cfg.Minio.BucketName),
+		logs,
+	)
+
+	result, err := reconcile.Reconcile(context.Background(), usecase.ReconcileOptions{
+		MinAge:        *minAge,
+		DeleteOrphans: *deleteOrphans,
+	})
+	if err != nil {
+		log.Fatal("Reconciliation failed:", err)
+	}
+
+	for _, name := range result.OrphanObjects {
+		fmt.Println("orphaned object:", name)
+	}
+	for _, missing := range result.MissingObjects {
+		fmt.Printf("missing object: %s (%s %s)\n", missing.Key, missing.Table, missing.ID)
+	}
+
+	fmt.Printf("Checked %d objects against %d photo rows: %d orphaned objects, %d missing objects\n",
+		result.Objects, result.References, len(result.OrphanObjects), len(result.MissingObjects))
+	if *deleteOrphans {
+		fmt.Printf("Deleted %d orphaned objects\n", result.DeletedObjects)
+	}
+
+	if len(result.MissingObjects) > 0 || (len(result.OrphanObjects) > result.DeletedObjects) {
+		os.Exit(1)
+	}
+}
@@ -3,10 +3,12 @@ package main
 import (
 	"building-report-backend/pkg/config"
 	"building-report-backend/pkg/database"
+	"building-report-backend/pkg/logger"
 	"building-report-backend/seeds"
 	"fmt"
 	"log"
 	"os"
+	"time"
 )
 
 func main() {
@@ -14,7 +16,7 @@ func main() {
 	cfg := config.Load()
 
 	// Connect to database
-	db, err := database.NewPostgresDB(cfg.Database)
+	db, err := database.NewPostgresDB(cfg.Database, logger.New(logger.Config{Level: cfg.Log.Level, Format: "text"}, os.Stderr), time.Duration(cfg.Log.SlowQueryMs)*time.Millisecond)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
@@ -65,6 +65,10 @@ type CreateAgricultureRequest struct {
 	UrgentNeeds    string `json:"urgent_needs"`
 	WaterAccess    string `json:"water_access"`
 	Suggestions    string `json:"suggestions,omitempty"`
+
+	// UploadSessionID refers to photos uploaded beforehand through an upload
+	// session, in place of photo files in the request.
+	UploadSessionID string `json:"upload_session_id,omitempty"`
 }
 
 func (r *CreateAgricultureRequest) Validate() error {
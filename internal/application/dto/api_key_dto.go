@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"building-report-backend/internal/domain/entity"
+)
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+	// OwnerID defaults to the admin creating the key. Only a superadmin may
+	// set it to another user.
+	OwnerID   string     `json:"owner_id"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (r *CreateAPIKeyRequest) Validate() error {
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	for _, scope := range r.Scopes {
+		if err := entity.ValidateAPIKeyScope(scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreatedAPIKeyResponse is the only response that carries the plaintext key.
+type CreatedAPIKeyResponse struct {
+	Key    string         `json:"key"`
+	APIKey *entity.APIKey `json:"api_key"`
+}
+
+type PaginatedAPIKeyResponse struct {
+	APIKeys    []*entity.APIKey `json:"api_keys"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page"`
+	PerPage    int              `json:"per_page"`
+	TotalPages int64            `json:"total_pages"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "building-report-backend/internal/domain/entity"
+
+type PaginatedAuditLogResponse struct {
+	Logs       []*entity.AuditLog `json:"logs"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	PerPage    int                `json:"per_page"`
+	TotalPages int64              `json:"total_pages"`
+}
+
+type PaginatedTrashResponse struct {
+	Sector     entity.Sector `json:"sector"`
+	Reports    interface{}   `json:"reports"`
+	Total      int64         `json:"total"`
+	Page       int           `json:"page"`
+	PerPage    int           `json:"per_page"`
+	TotalPages int64         `json:"total_pages"`
+}
@@ -1,6 +1,8 @@
 package dto
 
 import (
+    "errors"
+
     "building-report-backend/internal/domain/entity"
     "github.com/go-playground/validator/v10"
 )
@@ -27,41 +29,239 @@ func (l *LoginRequest) Validate() error {
 }
 
 type AuthResponse struct {
-    Token     string      `json:"token"`
-    User      interface{} `json:"user"`
-    ExpiresIn int         `json:"expires_in"`
+    Token            string      `json:"token"`
+    RefreshToken     string      `json:"refresh_token"`
+    User             interface{} `json:"user"`
+    ExpiresIn        int         `json:"expires_in"`
+    RefreshExpiresIn int         `json:"refresh_expires_in"`
+}
+
+type RefreshTokenRequest struct {
+    RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+func (r *RefreshTokenRequest) Validate() error {
+    return validate.Struct(r)
+}
+
+// LogoutRequest optionally carries the refresh token so it is revoked together
+// with the access token used to call logout.
+type LogoutRequest struct {
+    RefreshToken string `json:"refresh_token"`
+}
+
+// TwoFactorChallengeResponse is returned by login instead of tokens when the user
+// has two-factor authentication enabled.
+type TwoFactorChallengeResponse struct {
+    TwoFactorRequired bool   `json:"two_factor_required"`
+    ChallengeToken    string `json:"challenge_token"`
+    ExpiresIn         int    `json:"expires_in"`
+}
+
+// VerifyTwoFactorRequest completes a login with either a TOTP code or a recovery code.
+type VerifyTwoFactorRequest struct {
+    ChallengeToken string `json:"challenge_token" validate:"required"`
+    Code           string `json:"code" validate:"required_without=RecoveryCode"`
+    RecoveryCode   string `json:"recovery_code" validate:"required_without=Code"`
+}
+
+func (r *VerifyTwoFactorRequest) Validate() error {
+    return validate.Struct(r)
+}
+
+type TwoFactorSetupResponse struct {
+    Secret     string `json:"secret"`
+    OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type TwoFactorCodeRequest struct {
+    Code string `json:"code" validate:"required"`
+}
+
+func (r *TwoFactorCodeRequest) Validate() error {
+    return validate.Struct(r)
+}
+
+// TwoFactorEnabledResponse carries the recovery codes, shown only once, and a new
+// token pair replacing the sessions ended by enabling two-factor.
+type TwoFactorEnabledResponse struct {
+    RecoveryCodes []string      `json:"recovery_codes"`
+    Auth          *AuthResponse `json:"auth"`
+}
+
+type DisableTwoFactorRequest struct {
+    Password     string `json:"password" validate:"required"`
+    Code         string `json:"code" validate:"required_without=RecoveryCode"`
+    RecoveryCode string `json:"recovery_code" validate:"required_without=Code"`
+}
+
+func (r *DisableTwoFactorRequest) Validate() error {
+    return validate.Struct(r)
+}
+
+type RecoveryCodesResponse struct {
+    RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorStatusResponse struct {
+    Enabled                bool    `json:"enabled"`
+    Required               bool    `json:"required"`
+    EnabledAt              *string `json:"enabled_at,omitempty"`
+    RecoveryCodesRemaining int64   `json:"recovery_codes_remaining"`
+}
+
+// OIDCLoginResponse is returned instead of a redirect when the client starts
+// the SSO login itself.
+type OIDCLoginResponse struct {
+    AuthorizationURL string `json:"authorization_url"`
+    State            string `json:"state"`
+}
+
+type OIDCCallbackRequest struct {
+    Code  string `json:"code" query:"code" validate:"required"`
+    State string `json:"state" query:"state" validate:"required"`
+}
+
+func (r *OIDCCallbackRequest) Validate() error {
+    return validate.Struct(r)
 }
 
 type CreateUserRequest struct {
-    Username string           `json:"username" validate:"required,min=3,max=50"`
-    Email    string           `json:"email" validate:"required,email"`
-    Password string           `json:"password" validate:"required,min=6"`
-    Role     entity.UserRole  `json:"role" validate:"required,oneof=SUPERADMIN USER"`
+    Username           string          `json:"username" validate:"required,min=3,max=50"`
+    Email              string          `json:"email" validate:"required,email"`
+    Password           string          `json:"password" validate:"required,min=6"`
+    Role               entity.UserRole `json:"role" validate:"required,oneof=SUPERADMIN ADMIN SUPERVISOR OPERATOR VIEWER EXECUTIVE USER"`
+    MustChangePassword bool            `json:"must_change_password"`
+    // District and Unit restrict the user to one district's or unit's reports.
+    District           string          `json:"district" validate:"max=100"`
+    Unit               string          `json:"unit" validate:"max=100"`
 }
 
 func (r *CreateUserRequest) Validate() error {
-    return validate.Struct(r)
+    if err := validate.Struct(r); err != nil {
+        return err
+    }
+    if err := entity.ValidateScopeValue(r.District); err != nil {
+        return err
+    }
+    return entity.ValidateScopeValue(r.Unit)
 }
 
 type UpdateUserRequest struct {
-    Role entity.UserRole `json:"role" validate:"required,oneof=SUPERADMIN USER"`
+    Role               entity.UserRole `json:"role" validate:"omitempty,oneof=SUPERADMIN ADMIN SUPERVISOR OPERATOR VIEWER EXECUTIVE USER"`
+    IsActive           *bool           `json:"is_active"`
+    MustChangePassword *bool           `json:"must_change_password"`
+    // District and Unit replace the user's data scope; an empty string clears it.
+    District           *string         `json:"district" validate:"omitempty,max=100"`
+    Unit               *string         `json:"unit" validate:"omitempty,max=100"`
 }
 
 func (r *UpdateUserRequest) Validate() error {
+    if err := validate.Struct(r); err != nil {
+        return err
+    }
+    if r.Role == "" && r.IsActive == nil && r.MustChangePassword == nil && r.District == nil && r.Unit == nil {
+        return errors.New("role, is_active, must_change_password, district or unit is required")
+    }
+    for _, value := range []*string{r.District, r.Unit} {
+        if value == nil {
+            continue
+        }
+        if err := entity.ValidateScopeValue(*value); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+type ChangePasswordRequest struct {
+    CurrentPassword string `json:"current_password" validate:"required"`
+    NewPassword     string `json:"new_password" validate:"required"`
+}
+
+func (r *ChangePasswordRequest) Validate() error {
+    return validate.Struct(r)
+}
+
+type ResetPasswordRequest struct {
+    Token       string `json:"token" validate:"required"`
+    NewPassword string `json:"new_password" validate:"required"`
+}
+
+func (r *ResetPasswordRequest) Validate() error {
     return validate.Struct(r)
 }
 
+type PasswordResetIssuedResponse struct {
+    UserID    string `json:"user_id"`
+    ExpiresAt string `json:"expires_at"`
+}
+
 type UserResponse struct {
-    ID        string          `json:"id"`
-    Username  string          `json:"username"`
-    Email     string          `json:"email"`
-    Role      entity.UserRole `json:"role"`
-    IsActive  bool            `json:"is_active"`
-    CreatedAt string          `json:"created_at"`
-    UpdatedAt string          `json:"updated_at"`
+    ID                 string          `json:"id"`
+    Username           string          `json:"username"`
+    Email              string          `json:"email"`
+    Role               entity.UserRole `json:"role"`
+    IsActive           bool            `json:"is_active"`
+    MustChangePassword bool            `json:"must_change_password"`
+    AuthProvider       string          `json:"auth_provider"`
+    TwoFactorEnabled   bool            `json:"two_factor_enabled"`
+    District           string          `json:"district,omitempty"`
+    Unit               string          `json:"unit,omitempty"`
+    CreatedAt          string          `json:"created_at"`
+    UpdatedAt          string          `json:"updated_at"`
 }
 
 type UserListResponse struct {
-    Users []*UserResponse `json:"users"`
-    Total int64           `json:"total"`
-}
\ No newline at end of file
+    Users      []*UserResponse `json:"users"`
+    Total      int64           `json:"total"`
+    Page       int             `json:"page"`
+    PerPage    int             `json:"per_page"`
+    TotalPages int64           `json:"total_pages"`
+}
+
+type BulkUserStatusRequest struct {
+    UserIDs  []string `json:"user_ids" validate:"required,min=1,max=500,dive,required"`
+    IsActive *bool    `json:"is_active" validate:"required"`
+}
+
+func (r *BulkUserStatusRequest) Validate() error {
+    return validate.Struct(r)
+}
+
+type BulkUserStatusResponse struct {
+    Updated int64 `json:"updated"`
+    // Unchanged already had the requested status.
+    Unchanged []string `json:"unchanged"`
+    NotFound  []string `json:"not_found"`
+    // Skipped holds the requester's own id, which cannot be deactivated in bulk.
+    Skipped []string `json:"skipped"`
+}
+
+// UserImportRowResult reports the outcome of one CSV row; Row counts the header as row 1.
+type UserImportRowResult struct {
+    Row      int      `json:"row"`
+    Username string   `json:"username"`
+    UserID   string   `json:"user_id,omitempty"`
+    Status   string   `json:"status"`
+    Errors   []string `json:"errors,omitempty"`
+    // PasswordResetSent is set when the row had no password and a reset link
+    // was sent instead.
+    PasswordResetSent bool `json:"password_reset_sent,omitempty"`
+}
+
+type UserImportResponse struct {
+    DryRun  bool                   `json:"dry_run"`
+    Total   int                    `json:"total"`
+    Created int                    `json:"created"`
+    Failed  int                    `json:"failed"`
+    Rows    []*UserImportRowResult `json:"rows"`
+}
+
+type PaginatedLoginLockoutResponse struct {
+    Lockouts   []*entity.LoginLockout `json:"lockouts"`
+    Total      int64                  `json:"total"`
+    Page       int                    `json:"page"`
+    PerPage    int                    `json:"per_page"`
+    TotalPages int64                  `json:"total_pages"`
+}
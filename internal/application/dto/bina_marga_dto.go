@@ -44,6 +44,10 @@ type CreateBinaMargaRequest struct {
     
     CauseOfDamage       string    `json:"cause_of_damage,omitempty"` 
     Notes               string    `json:"notes,omitempty"` 
+
+    // UploadSessionID refers to photos uploaded beforehand through an upload
+    // session, in place of photo files in the request.
+    UploadSessionID string `json:"upload_session_id,omitempty"`
 }
 
 func (r *CreateBinaMargaRequest) Validate() error {
@@ -93,7 +97,9 @@ func (r *UpdateBinaMargaRequest) Validate() error {
 
 type UpdateBinaMargaStatusRequest struct {
     Status string `json:"status" validate:"required,oneof=PENDING VERIFIED PLANNED IN_PROGRESS COMPLETED POSTPONED REJECTED"`
-    Notes  string `json:"notes,omitempty"`
+    Notes  string `json:"notes" validate:"required"`
+
+    RepairPhotos
 }
 
 func (r *UpdateBinaMargaStatusRequest) Validate() error {
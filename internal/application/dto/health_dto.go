@@ -0,0 +1,29 @@
+package dto
+
+import "building-report-backend/pkg/version"
+
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+	HealthStatusDown     = "down"
+)
+
+type DependencyStatus struct {
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type ReadinessResponse struct {
+	// Status is ok, degraded when only non-critical dependencies fail, or down.
+	Status       string                       `json:"status"`
+	Dependencies map[string]*DependencyStatus `json:"dependencies"`
+}
+
+type BuildInfoResponse struct {
+	version.Info
+	// MigrationVersion is the last applied goose migration, null when the
+	// database could not be read.
+	MigrationVersion *int64 `json:"migration_version"`
+}
@@ -149,7 +149,6 @@ func (r *UpdateSpatialPlanningRequest) Normalize() {
 	r.ViolationLevel = utils.NormalizeEnum(r.ViolationLevel)
 	r.EnvironmentalImpact = utils.NormalizeEnum(r.EnvironmentalImpact)
 	r.UrgencyLevel = utils.NormalizeEnum(r.UrgencyLevel)
-	r.Status = utils.NormalizeEnum(r.Status)
 }
 
 
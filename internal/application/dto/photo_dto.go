@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"time"
+
+	"building-report-backend/internal/domain/entity"
+)
+
+// AddPhotosRequest comes with the multipart files, or an upload session, of
+// photos added to an existing report. Caption and Angle apply to every photo
+// added; they can be edited one by one afterwards.
+type AddPhotosRequest struct {
+	UploadSessionID string `form:"upload_session_id"`
+	Caption         string `form:"caption" validate:"max=255"`
+	Angle           string `form:"angle" validate:"max=50"`
+	RepairPhase     string `form:"repair_phase" validate:"omitempty,oneof=BEFORE AFTER"`
+}
+
+func (r *AddPhotosRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+// RepairPhotos is embedded in the status change requests of the infrastructure
+// sectors. UploadSessionID attaches photos of the repair with the change: the
+// before set when work starts, the after set when it is completed.
+type RepairPhotos struct {
+	UploadSessionID string `json:"upload_session_id,omitempty"`
+}
+
+// UpdatePhotoRequest edits a photo; fields left out are kept.
+type UpdatePhotoRequest struct {
+	Caption *string `json:"caption" validate:"omitempty,max=255"`
+	Angle   *string `json:"angle" validate:"omitempty,max=50"`
+}
+
+func (r *UpdatePhotoRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+// ReorderPhotosRequest lists every photo of the report in its new order.
+type ReorderPhotosRequest struct {
+	PhotoIDs []string `json:"photo_ids" validate:"required,min=1,dive,required"`
+}
+
+func (r *ReorderPhotosRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+// PhotoResponse is a report photo as the photo endpoints return it, the same
+// for every sector. Angle is the photo angle, or the photo type of the sectors
+// that record one instead.
+type PhotoResponse struct {
+	ID        string    `json:"id"`
+	ReportID  string    `json:"report_id"`
+	PhotoURL  string    `json:"photo_url"`
+	Caption   string    `json:"caption"`
+	Angle     string    `json:"angle"`
+	CreatedAt time.Time `json:"created_at"`
+	entity.PhotoPlacement
+	entity.PhotoMeta
+}
@@ -23,6 +23,10 @@ type CreateReportRequest struct {
     FloorCount           int     `json:"floor_count" form:"floor_count" validate:"required,min=1"`
     WorkType             string  `json:"work_type,omitempty" form:"work_type"`
     ConditionAfterRehab  string  `json:"condition_after_rehab,omitempty" form:"condition_after_rehab"`
+
+    // UploadSessionID refers to photos uploaded beforehand through an upload
+    // session, in place of photo files in the request.
+    UploadSessionID string `json:"upload_session_id,omitempty" form:"upload_session_id"`
 }
 
 
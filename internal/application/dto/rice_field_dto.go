@@ -11,13 +11,23 @@ type CreateRiceFieldRequest struct {
 	IrrigatedRiceFields float64   `json:"irrigated_rice_fields"`
 }
 
+func (r *CreateRiceFieldRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+// UpdateRiceFieldRequest changes only the fields that are present, so an
+// area can be set back to zero.
 type UpdateRiceFieldRequest struct {
-	District            string    `json:"district"`
-	Longitude           float64   `json:"longitude"`
-	Latitude            float64   `json:"latitude"`
-	Date                time.Time `json:"date"`
-	RainfedRiceFields   float64   `json:"rainfed_rice_fields"`
-	IrrigatedRiceFields float64   `json:"irrigated_rice_fields"`
+	District            *string    `json:"district" validate:"omitempty,min=1"`
+	Longitude           *float64   `json:"longitude"`
+	Latitude            *float64   `json:"latitude"`
+	Date                *time.Time `json:"date"`
+	RainfedRiceFields   *float64   `json:"rainfed_rice_fields" validate:"omitempty,min=0"`
+	IrrigatedRiceFields *float64   `json:"irrigated_rice_fields" validate:"omitempty,min=0"`
+}
+
+func (r *UpdateRiceFieldRequest) Validate() error {
+	return validate.Struct(r)
 }
 
 type RiceFieldResponse struct {
@@ -70,4 +80,23 @@ type RiceFieldAnalysisResponse struct {
 	DistributionMap         []RiceFieldMapPoint      `json:"distribution_map"`
 	AreaTrendByMonth        []map[string]interface{} `json:"area_trend_by_month"`
 	IrrigationRatio         float64               `json:"irrigation_ratio"`
-}
\ No newline at end of file
+}
+type RiceFieldStatisticsResponse struct {
+	StartDate          string  `json:"start_date"`
+	EndDate            string  `json:"end_date"`
+	TotalRainfedArea   float64 `json:"total_rainfed_area"`
+	TotalIrrigatedArea float64 `json:"total_irrigated_area"`
+	TotalRiceFieldArea float64 `json:"total_rice_field_area"`
+	TotalRecords       int64   `json:"total_records"`
+	RainfedGrowth      float64 `json:"rainfed_growth"`
+	IrrigatedGrowth    float64 `json:"irrigated_growth"`
+	TotalGrowth        float64 `json:"total_growth"`
+}
+
+type RiceFieldTrendResponse struct {
+	Year               int     `json:"year"`
+	TotalRainfedArea   float64 `json:"total_rainfed_area"`
+	TotalIrrigatedArea float64 `json:"total_irrigated_area"`
+	TotalArea          float64 `json:"total_area"`
+	Count              int64   `json:"count"`
+}
@@ -20,7 +20,12 @@ type CreateSpatialPlanningRequest struct {
     Latitude            float64   `json:"latitude" validate:"required,min=-90,max=90"`
     Longitude           float64   `json:"longitude" validate:"required,min=-180,max=180"`
     Address             string    `json:"address" validate:"required"`
+    District            string    `json:"district,omitempty" validate:"max=100"`
     Notes               string    `json:"notes,omitempty"` 
+
+    // UploadSessionID refers to photos uploaded beforehand through an upload
+    // session, in place of photo files in the request.
+    UploadSessionID string `json:"upload_session_id,omitempty"`
 }
 
 func (r *CreateSpatialPlanningRequest) Validate() error {
@@ -38,7 +43,6 @@ type UpdateSpatialPlanningRequest struct {
     Longitude           float64   `json:"longitude,omitempty"`
     Address             string    `json:"address,omitempty"`
     Notes               string    `json:"notes,omitempty"`
-    Status              string    `json:"status,omitempty"`
 }
 
 func (r *UpdateSpatialPlanningRequest) Validate() error {
@@ -47,7 +51,9 @@ func (r *UpdateSpatialPlanningRequest) Validate() error {
 
 type UpdateSpatialStatusRequest struct {
     Status string `json:"status" validate:"required,oneof=PENDING REVIEWING PROCESSING RESOLVED REJECTED"`
-    Notes  string `json:"notes,omitempty"`
+    Notes  string `json:"notes" validate:"required"`
+
+    RepairPhotos
 }
 
 func (r *UpdateSpatialStatusRequest) Validate() error {
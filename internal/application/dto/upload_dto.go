@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"building-report-backend/internal/domain/constants"
+	"building-report-backend/internal/domain/entity"
+)
+
+type UploadFileRequest struct {
+	FileName    string `json:"file_name" validate:"required,max=255"`
+	ContentType string `json:"content_type" validate:"required,max=100"`
+	Size        int64  `json:"size" validate:"required,min=1"`
+}
+
+type CreateUploadSessionRequest struct {
+	Files []UploadFileRequest `json:"files" validate:"required,min=1,dive"`
+}
+
+// Validate checks the files the client announces. The photo pipeline checks
+// the uploaded bytes again when the session is confirmed.
+func (r *CreateUploadSessionRequest) Validate() error {
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	if len(r.Files) > constants.MaxPhotosPerReport {
+		return fmt.Errorf("at most %d photos can be uploaded per report", constants.MaxPhotosPerReport)
+	}
+
+	allowed := strings.Split(constants.AllowedImageFormats, ",")
+	for _, file := range r.Files {
+		if file.Size > constants.MaxFileSize {
+			return fmt.Errorf("%s is larger than %d MB", file.FileName, constants.MaxFileSize/(1024*1024))
+		}
+		ext := strings.TrimPrefix(strings.ToLower(path.Ext(file.FileName)), ".")
+		if !slices.Contains(allowed, ext) {
+			return fmt.Errorf("%s must be one of %s", file.FileName, constants.AllowedImageFormats)
+		}
+	}
+	return nil
+}
+
+// PresignedUpload tells the client where to send one file: a multipart POST
+// to URL with Fields as form fields and the file last, as "file". Storage
+// rejects a file whose size or content type differs from what was declared.
+type PresignedUpload struct {
+	FileID   string            `json:"file_id"`
+	FileName string            `json:"file_name"`
+	Method   string            `json:"method"`
+	URL      string            `json:"url"`
+	Fields   map[string]string `json:"fields"`
+}
+
+type UploadSessionResponse struct {
+	ID        string                     `json:"id"`
+	Sector    entity.Sector              `json:"sector"`
+	Status    entity.UploadSessionStatus `json:"status"`
+	ExpiresAt time.Time                  `json:"expires_at"`
+	// Uploads is only returned when the session is created.
+	Uploads []PresignedUpload          `json:"uploads,omitempty"`
+	Files   []entity.UploadSessionFile `json:"files"`
+}
@@ -14,6 +14,7 @@ type CreateWaterResourcesRequest struct {
 	IrrigationType     string    `json:"irrigation_type" validate:"required"`
 	Latitude           float64   `json:"latitude" validate:"required,min=-90,max=90"`
 	Longitude          float64   `json:"longitude" validate:"required,min=-180,max=180"`
+	District           string    `json:"district,omitempty" validate:"max=100"`
 	DamageType string `json:"damage_type" validate:"required,oneof=RETAK_BOCOR LONGSOR_AMBROL SEDIMENTASI_TINGGI TERSUMBAT_SAMPAH STRUKTUR_RUSAK STRUKTUR_BETON_RUSAK PINTU_AIR_MACET TANGGUL_JEBOL LAINNYA"`
 	DamageLevel           string  `json:"damage_level" validate:"required,oneof=RINGAN SEDANG BERAT"`
 	EstimatedLength       float64 `json:"estimated_length" validate:"min=0"`
@@ -25,6 +26,10 @@ type CreateWaterResourcesRequest struct {
 	AffectedFarmersCount  int     `json:"affected_farmers_count" validate:"min=0"`
 	UrgencyCategory       string  `json:"urgency_category" validate:"required,oneof=MENDESAK RUTIN"`
 	Notes                 string  `json:"notes,omitempty"`
+
+	// UploadSessionID refers to photos uploaded beforehand through an upload
+	// session, in place of photo files in the request.
+	UploadSessionID string `json:"upload_session_id,omitempty"`
 }
 
 func (r *CreateWaterResourcesRequest) Validate() error {
@@ -55,7 +60,9 @@ func (r *UpdateWaterResourcesRequest) Validate() error {
 
 type UpdateWaterStatusRequest struct {
 	Status string `json:"status" validate:"required,oneof=PENDING VERIFIED IN_PROGRESS COMPLETED POSTPONED REJECTED"`
-	Notes  string `json:"notes,omitempty"`
+	Notes  string `json:"notes" validate:"required"`
+
+	RepairPhotos
 }
 
 func (r *UpdateWaterStatusRequest) Validate() error {
@@ -4,35 +4,48 @@ import (
 	"context"
 	"fmt"
 	"mime/multipart"
+	"strconv"
 	"strings"
 	"time"
 
 	"building-report-backend/internal/application/dto"
 	"building-report-backend/internal/domain/entity"
 	"building-report-backend/internal/domain/repository"
-	"building-report-backend/internal/infrastructure/storage"
+	"building-report-backend/pkg/tracing"
 	"building-report-backend/pkg/utils"
 )
 
 type AgricultureUseCase struct {
 	agricultureRepo repository.AgricultureRepository
-	storage         storage.StorageService
+	uploads         *UploadUseCase
+	work            *UnitOfWork
+	links           *PhotoLinks
 	cache           repository.CacheRepository
+	auditRepo       repository.AuditLogRepository
 }
 
 func NewAgricultureUseCase(
 	agricultureRepo repository.AgricultureRepository,
-	storage storage.StorageService,
+	uploads *UploadUseCase,
+	work *UnitOfWork,
+	links *PhotoLinks,
 	cache repository.CacheRepository,
+	auditRepo repository.AuditLogRepository,
 ) *AgricultureUseCase {
 	return &AgricultureUseCase{
 		agricultureRepo: agricultureRepo,
-		storage:         storage,
+		uploads:         uploads,
+		work:            work,
+		links:           links,
 		cache:           cache,
+		auditRepo:       auditRepo,
 	}
 }
 
-func (uc *AgricultureUseCase) CreateReport(ctx context.Context, req *dto.CreateAgricultureRequest, photos []*multipart.FileHeader) (*entity.AgricultureReport, error) {
+func (uc *AgricultureUseCase) CreateReport(ctx context.Context, req *dto.CreateAgricultureRequest, photos []*multipart.FileHeader, userID string) (*entity.AgricultureReport, error) {
+	ctx, span := tracing.Start(ctx, "AgricultureUseCase.CreateReport")
+	defer span.End()
+
 	report := &entity.AgricultureReport{
 		ID:               utils.GenerateULID(),
 		ExtensionOfficer: req.ExtensionOfficer,
@@ -132,37 +145,60 @@ func (uc *AgricultureUseCase) CreateReport(ctx context.Context, req *dto.CreateA
 	}
 
 	photoTypes := []string{"field", "crop", "general", "pest_disease"}
-	for i, photo := range photos {
+	work := uc.work.Begin()
+	batch, err := uc.uploads.collectPhotos(ctx, work, photoSource{
+		Sector:    entity.SectorAgriculture,
+		SessionID: req.UploadSessionID,
+		Files:     photos,
+		UserID:    userID,
+		ReportID:  report.ID,
+		Min:       1,
+	})
+	if err != nil {
+		work.Rollback(ctx)
+		return nil, err
+	}
+
+	for i, uploaded := range batch.Photos {
 		photoType := "general"
 		if i < len(photoTypes) {
 			photoType = photoTypes[i]
 		}
 
-		photoURL, err := uc.storage.UploadFile(ctx, photo, "agriculture")
-		if err != nil {
-			return nil, fmt.Errorf("failed to upload photo: %w", err)
-		}
-
 		caption := fmt.Sprintf("%s - %s (%s)", photoType, report.FarmerName, report.Village)
 		report.Photos = append(report.Photos, entity.AgriculturePhoto{
-			ID:        utils.GenerateULID(),
-			PhotoURL:  photoURL,
-			PhotoType: photoType,
-			Caption:   caption,
+			ID:             utils.GenerateULID(),
+			PhotoURL:       uploaded.Key,
+			PhotoType:      photoType,
+			Caption:        caption,
+			PhotoPlacement: entity.PhotoPlacement{Position: i},
+			PhotoMeta:      uploaded.PhotoMeta,
 		})
 	}
 
-	if err := uc.agricultureRepo.Create(ctx, report); err != nil {
+	report.CreatedBy = userID
+
+	work.Do(func(ctx context.Context) error {
+		return uc.agricultureRepo.Create(ctx, report)
+	})
+	if err := work.Commit(ctx); err != nil {
 		return nil, err
 	}
 
+	recordAudit(ctx, uc.auditRepo, entity.SectorAgriculture, report.ID, entity.AuditActionCreate, userID, nil, report, "")
+	countReportCreated(entity.SectorAgriculture)
+
 	uc.cache.Delete(ctx, "agriculture:list")
 	uc.cache.Delete(ctx, "agriculture:stats")
 
+	uc.links.linkAgricultureReports(ctx, report)
 	return report, nil
 }
 
 func (uc *AgricultureUseCase) GetReport(ctx context.Context, id string) (*entity.AgricultureReport, error) {
+	ctx, span := tracing.Start(ctx, "AgricultureUseCase.GetReport")
+	defer span.End()
+
 	cacheKey := "agriculture:" + id
 
 	report, err := uc.agricultureRepo.FindByID(ctx, id)
@@ -172,16 +208,21 @@ func (uc *AgricultureUseCase) GetReport(ctx context.Context, id string) (*entity
 
 	uc.cache.Set(ctx, cacheKey, report, 3600)
 
+	uc.links.linkAgricultureReports(ctx, report)
 	return report, nil
 }
 
 func (uc *AgricultureUseCase) ListReports(ctx context.Context, page, limit int, filters map[string]interface{}) (*dto.PaginatedAgricultureResponse, error) {
+	ctx, span := tracing.Start(ctx, "AgricultureUseCase.ListReports")
+	defer span.End()
+
 	offset := (page - 1) * limit
 
 	reports, total, err := uc.agricultureRepo.FindAll(ctx, limit, offset, filters)
 	if err != nil {
 		return nil, err
 	}
+	uc.links.linkAgricultureReports(ctx, reports...)
 
 	return &dto.PaginatedAgricultureResponse{
 		Reports:    reports,
@@ -192,12 +233,43 @@ func (uc *AgricultureUseCase) ListReports(ctx context.Context, page, limit int,
 	}, nil
 }
 
-func (uc *AgricultureUseCase) UpdateReport(ctx context.Context, id string, req *dto.UpdateAgricultureRequest, userID string) (*entity.AgricultureReport, error) {
+// ListMyReports returns the reports created by userID, newest first.
+func (uc *AgricultureUseCase) ListMyReports(ctx context.Context, userID string, page, limit int) (*dto.PaginatedAgricultureResponse, error) {
+	ctx, span := tracing.Start(ctx, "AgricultureUseCase.ListMyReports")
+	defer span.End()
+
+	offset := (page - 1) * limit
+
+	reports, total, err := uc.agricultureRepo.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	uc.links.linkAgricultureReports(ctx, reports...)
+
+	return &dto.PaginatedAgricultureResponse{
+		Reports:    reports,
+		Total:      total,
+		Page:       page,
+		PerPage:    limit,
+		TotalPages: (total + int64(limit) - 1) / int64(limit),
+	}, nil
+}
+
+func (uc *AgricultureUseCase) UpdateReport(ctx context.Context, id string, req *dto.UpdateAgricultureRequest, userID string, role entity.UserRole) (*entity.AgricultureReport, error) {
+	ctx, span := tracing.Start(ctx, "AgricultureUseCase.UpdateReport")
+	defer span.End()
+
 	report, err := uc.agricultureRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if !entity.CanManageReport(report.CreatedBy, userID, role) {
+		return nil, ErrUnauthorized
+	}
+
+	before := *report
+
 	if req.ExtensionOfficer != "" {
 		report.ExtensionOfficer = req.ExtensionOfficer
 	}
@@ -258,31 +330,41 @@ func (uc *AgricultureUseCase) UpdateReport(ctx context.Context, id string, req *
 		report.Suggestions = req.Suggestions
 	}
 
+	report.UpdatedBy = userID
+
 	if err := uc.agricultureRepo.Update(ctx, report); err != nil {
 		return nil, err
 	}
 
+	recordAudit(ctx, uc.auditRepo, entity.SectorAgriculture, report.ID, entity.AuditActionUpdate, userID, &before, report, "")
+
 	uc.cache.Delete(ctx, "agriculture:"+id)
 	uc.cache.Delete(ctx, "agriculture:list")
 	uc.cache.Delete(ctx, "agriculture:stats")
 
+	uc.links.linkAgricultureReports(ctx, report)
 	return report, nil
 }
 
-func (uc *AgricultureUseCase) DeleteReport(ctx context.Context, id string, userID string) error {
+func (uc *AgricultureUseCase) DeleteReport(ctx context.Context, id string, userID string, role entity.UserRole) error {
+	ctx, span := tracing.Start(ctx, "AgricultureUseCase.DeleteReport")
+	defer span.End()
+
 	report, err := uc.agricultureRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	for _, photo := range report.Photos {
-		uc.storage.DeleteFile(ctx, photo.PhotoURL)
+	if !entity.CanManageReport(report.CreatedBy, userID, role) {
+		return ErrUnauthorized
 	}
 
 	if err := uc.agricultureRepo.Delete(ctx, id); err != nil {
 		return err
 	}
 
+	recordAudit(ctx, uc.auditRepo, entity.SectorAgriculture, report.ID, entity.AuditActionDelete, userID, report, nil, "")
+
 	uc.cache.Delete(ctx, "agriculture:"+id)
 	uc.cache.Delete(ctx, "agriculture:list")
 	uc.cache.Delete(ctx, "agriculture:stats")
@@ -291,10 +373,12 @@ func (uc *AgricultureUseCase) DeleteReport(ctx context.Context, id string, userI
 }
 
 func (uc *AgricultureUseCase) GetExecutiveSummary(ctx context.Context, commodityType string) (*dto.AgricultureExecutiveResponse, error) {
+	ctx, span := tracing.Start(ctx, "AgricultureUseCase.GetExecutiveSummary")
+	defer span.End()
 
 	commodityType = strings.ToUpper(strings.TrimSpace(commodityType))
 
-	cacheKey := fmt.Sprintf("agriculture:executive_summary:%s", commodityType)
+	cacheKey := scopedCacheKey(ctx, fmt.Sprintf("agriculture:executive_summary:%s", commodityType))
 	var response dto.AgricultureExecutiveResponse
 
 	err := uc.cache.Get(ctx, cacheKey, &response)
@@ -422,7 +506,10 @@ func (uc *AgricultureUseCase) GetExecutiveSummary(ctx context.Context, commodity
 }
 
 func (uc *AgricultureUseCase) GetFoodCropStats(ctx context.Context, commodityName string) (*dto.FoodCropResponse, error) {
-	cacheKey := fmt.Sprintf("agriculture:food_crop_stats:%s", commodityName)
+	ctx, span := tracing.Start(ctx, "AgricultureUseCase.GetFoodCropStats")
+	defer span.End()
+
+	cacheKey := scopedCacheKey(ctx, fmt.Sprintf("agriculture:food_crop_stats:%s", commodityName))
 
 	var response dto.FoodCropResponse
 	err := uc.cache.Get(ctx, cacheKey, &response)
@@ -530,7 +617,10 @@ func (uc *AgricultureUseCase) GetFoodCropStats(ctx context.Context, commodityNam
 }
 
 func (uc *AgricultureUseCase) GetHorticultureStats(ctx context.Context, commodityName string) (*dto.HorticultureResponse, error) {
-	cacheKey := fmt.Sprintf("agriculture:horticulture_stats:%s", commodityName)
+	ctx, span := tracing.Start(ctx, "AgricultureUseCase.GetHorticultureStats")
+	defer span.End()
+
+	cacheKey := scopedCacheKey(ctx, fmt.Sprintf("agriculture:horticulture_stats:%s", commodityName))
 
 	var response dto.HorticultureResponse
 	err := uc.cache.Get(ctx, cacheKey, &response)
@@ -639,7 +729,10 @@ func (uc *AgricultureUseCase) GetHorticultureStats(ctx context.Context, commodit
 }
 
 func (uc *AgricultureUseCase) GetPlantationStats(ctx context.Context, commodityName string) (*dto.PlantationResponse, error) {
-	cacheKey := fmt.Sprintf("agriculture:plantation_stats:%s", commodityName)
+	ctx, span := tracing.Start(ctx, "AgricultureUseCase.GetPlantationStats")
+	defer span.End()
+
+	cacheKey := scopedCacheKey(ctx, fmt.Sprintf("agriculture:plantation_stats:%s", commodityName))
 
 	var response dto.PlantationResponse
 	err := uc.cache.Get(ctx, cacheKey, &response)
@@ -751,8 +844,11 @@ func (uc *AgricultureUseCase) GetPlantationStats(ctx context.Context, commodityN
 }
 
 func (uc *AgricultureUseCase) GetAgriculturalEquipmentStats(ctx context.Context, startDate, endDate time.Time) (*dto.AgriculturalEquipmentResponse, error) {
-	cacheKey := fmt.Sprintf("agriculture:equipment_stats:%s:%s",
-		startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	ctx, span := tracing.Start(ctx, "AgricultureUseCase.GetAgriculturalEquipmentStats")
+	defer span.End()
+
+	cacheKey := scopedCacheKey(ctx, fmt.Sprintf("agriculture:equipment_stats:%s:%s",
+		startDate.Format("2006-01-02"), endDate.Format("2006-01-02")))
 
 	var response dto.AgriculturalEquipmentResponse
 	err := uc.cache.Get(ctx, cacheKey, &response)
@@ -824,8 +920,11 @@ func (uc *AgricultureUseCase) GetAgriculturalEquipmentStats(ctx context.Context,
 }
 
 func (uc *AgricultureUseCase) GetLandAndIrrigationStats(ctx context.Context, startDate, endDate time.Time) (*dto.LandIrrigationResponse, error) {
-	cacheKey := fmt.Sprintf("agriculture:land_irrigation:%s:%s",
-		startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	ctx, span := tracing.Start(ctx, "AgricultureUseCase.GetLandAndIrrigationStats")
+	defer span.End()
+
+	cacheKey := scopedCacheKey(ctx, fmt.Sprintf("agriculture:land_irrigation:%s:%s",
+		startDate.Format("2006-01-02"), endDate.Format("2006-01-02")))
 
 	var response dto.LandIrrigationResponse
 	err := uc.cache.Get(ctx, cacheKey, &response)
@@ -916,8 +1015,11 @@ func (uc *AgricultureUseCase) GetLandAndIrrigationStats(ctx context.Context, sta
 }
 
 func (uc *AgricultureUseCase) GetCommodityAnalysis(ctx context.Context, startDate, endDate time.Time, commodityName string) (*dto.CommodityAnalysisResponse, error) {
-	cacheKey := fmt.Sprintf("agriculture:commodity_analysis:%s:%s:%s",
-		commodityName, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	ctx, span := tracing.Start(ctx, "AgricultureUseCase.GetCommodityAnalysis")
+	defer span.End()
+
+	cacheKey := scopedCacheKey(ctx, fmt.Sprintf("agriculture:commodity_analysis:%s:%s:%s",
+		commodityName, startDate.Format("2006-01-02"), endDate.Format("2006-01-02")))
 
 	var response dto.CommodityAnalysisResponse
 	err := uc.cache.Get(ctx, cacheKey, &response)
@@ -1023,6 +1125,12 @@ func convertToFloat64(value interface{}) float64 {
 		return float64(v)
 	case int64:
 		return float64(v)
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	case []byte:
+		f, _ := strconv.ParseFloat(string(v), 64)
+		return f
 	case nil:
 		return 0.0
 	default:
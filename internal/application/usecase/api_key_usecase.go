@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"building-report-backend/pkg/logger"
+	"building-report-backend/pkg/tracing"
+	"building-report-backend/pkg/utils"
+)
+
+var (
+	ErrInvalidAPIKey  = errors.New("invalid, expired or revoked API key")
+	ErrAPIKeyNotFound = errors.New("API key not found")
+	ErrAPIKeyExpiry   = errors.New("expires_at must be in the future")
+)
+
+const (
+	apiKeyPrefix = "brk_"
+	// apiKeyTouchInterval limits how often last_used_at is written for a busy key.
+	apiKeyTouchInterval = time.Minute
+)
+
+type APIKeyUseCase struct {
+	apiKeyRepo repository.APIKeyRepository
+	userRepo   repository.UserRepository
+	log        *slog.Logger
+}
+
+func NewAPIKeyUseCase(apiKeyRepo repository.APIKeyRepository, userRepo repository.UserRepository, log *slog.Logger) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+		log:        log,
+	}
+}
+
+// CreateAPIKey issues a new key. The plaintext key is returned once and never stored.
+// Only a superadmin may create keys owned by another user, since the key acts
+// with the owner's role and data scope.
+func (uc *APIKeyUseCase) CreateAPIKey(ctx context.Context, requesterID string, requesterRole entity.UserRole, req *dto.CreateAPIKeyRequest) (*dto.CreatedAPIKeyResponse, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUseCase.CreateAPIKey")
+	defer span.End()
+
+	ownerID := req.OwnerID
+	if ownerID == "" {
+		ownerID = requesterID
+	}
+
+	if ownerID != requesterID && requesterRole != entity.RoleSuperAdmin {
+		return nil, ErrForbidden
+	}
+
+	if !utils.IsValidULID(ownerID) {
+		return nil, ErrUserNotFound
+	}
+	owner, err := uc.userRepo.FindByID(ctx, ownerID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrAPIKeyExpiry
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	rawKey := apiKeyPrefix + hex.EncodeToString(buf)
+
+	key := &entity.APIKey{
+		ID:        utils.GenerateULID(),
+		Name:      req.Name,
+		OwnerID:   owner.ID,
+		Prefix:    rawKey[:len(apiKeyPrefix)+8],
+		KeyHash:   hashAPIKey(rawKey),
+		Scopes:    entity.APIKeyScopes(req.Scopes),
+		ExpiresAt: req.ExpiresAt,
+		CreatedBy: requesterID,
+	}
+	key.BeforeCreate()
+
+	if err := uc.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &dto.CreatedAPIKeyResponse{
+		Key:    rawKey,
+		APIKey: key,
+	}, nil
+}
+
+func (uc *APIKeyUseCase) ListAPIKeys(ctx context.Context, filters map[string]interface{}, page, limit int) (*dto.PaginatedAPIKeyResponse, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUseCase.ListAPIKeys")
+	defer span.End()
+
+	offset := (page - 1) * limit
+
+	keys, total, err := uc.apiKeyRepo.FindAll(ctx, filters, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PaginatedAPIKeyResponse{
+		APIKeys:    keys,
+		Total:      total,
+		Page:       page,
+		PerPage:    limit,
+		TotalPages: (total + int64(limit) - 1) / int64(limit),
+	}, nil
+}
+
+func (uc *APIKeyUseCase) RevokeAPIKey(ctx context.Context, id, requesterID string) error {
+	ctx, span := tracing.Start(ctx, "APIKeyUseCase.RevokeAPIKey")
+	defer span.End()
+
+	if err := uc.apiKeyRepo.Revoke(ctx, id, requesterID, time.Now()); err != nil {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// AuthenticateAPIKey resolves a plaintext key to the key record and its owner.
+// Keys of deleted or inactive owners are rejected.
+func (uc *APIKeyUseCase) AuthenticateAPIKey(ctx context.Context, rawKey string) (*entity.APIKey, *entity.User, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUseCase.AuthenticateAPIKey")
+	defer span.End()
+
+	if !strings.HasPrefix(rawKey, apiKeyPrefix) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	key, err := uc.apiKeyRepo.FindByHash(ctx, hashAPIKey(rawKey))
+	if err != nil {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if !key.IsUsable(now) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	owner, err := uc.userRepo.FindByID(ctx, key.OwnerID)
+	if err != nil || !owner.IsActive {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval {
+		if err := uc.apiKeyRepo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			uc.log.WarnContext(ctx, "failed to record API key use", slog.String("api_key_id", key.ID), logger.Err(err))
+		}
+	}
+
+	return key, owner, nil
+}
+
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"building-report-backend/pkg/logger"
+	"building-report-backend/pkg/tracing"
+	"building-report-backend/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+type AuditUseCase struct {
+	auditRepo  repository.AuditLogRepository
+	reportRepo repository.ReportLookupRepository
+}
+
+func NewAuditUseCase(auditRepo repository.AuditLogRepository, reportRepo repository.ReportLookupRepository) *AuditUseCase {
+	return &AuditUseCase{
+		auditRepo:  auditRepo,
+		reportRepo: reportRepo,
+	}
+}
+
+// GetReportHistory returns the audit entries of a report. Audit logs are not
+// covered by the data scope, so the report itself is looked up first and
+// gorm.ErrRecordNotFound returned when the caller cannot see it. Roles that
+// can read the trash also see the history of trashed reports.
+func (uc *AuditUseCase) GetReportHistory(ctx context.Context, sector entity.Sector, reportID string, role entity.UserRole, page, limit int) (*dto.PaginatedAuditLogResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuditUseCase.GetReportHistory")
+	defer span.End()
+
+	includeDeleted := entity.HasPermission(role, entity.SectorTrash, entity.ActionRead)
+	visible, err := uc.reportRepo.Exists(ctx, sector, reportID, includeDeleted)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if !visible {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	offset := (page - 1) * limit
+
+	logs, total, err := uc.auditRepo.FindByReport(ctx, sector, reportID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PaginatedAuditLogResponse{
+		Logs:       logs,
+		Total:      total,
+		Page:       page,
+		PerPage:    limit,
+		TotalPages: (total + int64(limit) - 1) / int64(limit),
+	}, nil
+}
+
+// SearchAuditLogs searches all audit entries. Callers with a scoped role only
+// find the entries of reports in their data scope.
+func (uc *AuditUseCase) SearchAuditLogs(ctx context.Context, page, limit int, filters map[string]interface{}) (*dto.PaginatedAuditLogResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuditUseCase.SearchAuditLogs")
+	defer span.End()
+
+	offset := (page - 1) * limit
+
+	logs, total, err := uc.auditRepo.Search(ctx, filters, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PaginatedAuditLogResponse{
+		Logs:       logs,
+		Total:      total,
+		Page:       page,
+		PerPage:    limit,
+		TotalPages: (total + int64(limit) - 1) / int64(limit),
+	}, nil
+}
+
+// auditSkippedFields are bookkeeping or derived values that only add noise to a diff.
+var auditSkippedFields = map[string]bool{
+	"photos":         true,
+	"created_at":     true,
+	"updated_at":     true,
+	"updated_by":     true,
+	"priority_score": true,
+}
+
+// recordAudit writes one audit entry for a report mutation. before is nil on create and
+// after is nil on delete. A failed write is logged rather than returned because the
+// mutation it describes has already been committed.
+func recordAudit(ctx context.Context, auditRepo repository.AuditLogRepository, sector entity.Sector, reportID string, action entity.AuditAction, actorID string, before, after interface{}, notes string) {
+	entry := &entity.AuditLog{
+		ID:       utils.GenerateULID(),
+		Sector:   sector,
+		ReportID: reportID,
+		Action:   action,
+		ActorID:  actorID,
+		Changes:  diffAuditFields(before, after),
+		Notes:    notes,
+	}
+	entry.BeforeCreate()
+
+	if err := auditRepo.Create(ctx, entry); err != nil {
+		logger.FromContext(ctx).ErrorContext(ctx, "failed to write audit log",
+			slog.String("sector", string(sector)),
+			slog.String("report_id", reportID),
+			logger.Err(err),
+		)
+	}
+}
+
+// diffAuditFields compares the JSON representation of two reports and returns the fields
+// whose values differ.
+func diffAuditFields(before, after interface{}) entity.AuditChanges {
+	oldFields := toAuditFields(before)
+	newFields := toAuditFields(after)
+
+	changes := entity.AuditChanges{}
+	for key, newValue := range newFields {
+		if oldValue, ok := oldFields[key]; !ok || !reflect.DeepEqual(oldValue, newValue) {
+			changes[key] = entity.FieldChange{Old: oldFields[key], New: newValue}
+		}
+	}
+	for key, oldValue := range oldFields {
+		if _, ok := newFields[key]; !ok {
+			changes[key] = entity.FieldChange{Old: oldValue, New: nil}
+		}
+	}
+
+	return changes
+}
+
+func toAuditFields(v interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if v == nil {
+		return fields
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fields
+	}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return fields
+	}
+
+	for key, value := range fields {
+		if auditSkippedFields[key] || value == nil || value == "" {
+			delete(fields, key)
+		}
+	}
+
+	return fields
+}
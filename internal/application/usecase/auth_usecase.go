@@ -3,12 +3,16 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"building-report-backend/internal/application/dto"
 	"building-report-backend/internal/domain/constants"
 	"building-report-backend/internal/domain/entity"
 	"building-report-backend/internal/domain/repository"
 	"building-report-backend/internal/infrastructure/auth"
+	"building-report-backend/internal/infrastructure/notification"
+	"building-report-backend/pkg/tracing"
 	"building-report-backend/pkg/utils"
 	"building-report-backend/pkg/validation"
 
@@ -23,27 +27,51 @@ var (
     ErrInactiveUser       = errors.New("user account is inactive")
     ErrForbidden          = errors.New("forbidden: insufficient permissions")
     ErrCannotDeleteSelf   = errors.New("cannot delete your own account")
+    ErrSamePassword       = errors.New("new password must differ from the current password")
 )
 
 type AuthUseCase struct {
     userRepo    repository.UserRepository
     authService auth.JWTService
     cache       repository.CacheRepository
+    tokenStore  auth.TokenStore
+    refreshTTL  time.Duration
+    resetSender notification.PasswordResetSender
+    resetTTL    time.Duration
+    loginGuard  *LoginGuard
+    lockoutRepo repository.LoginLockoutRepository
+    twoFactor   *TwoFactorManager
 }
 
 func NewAuthUseCase(
     userRepo repository.UserRepository,
     authService auth.JWTService,
     cache repository.CacheRepository,
+    tokenStore auth.TokenStore,
+    refreshTTL time.Duration,
+    resetSender notification.PasswordResetSender,
+    resetTTL time.Duration,
+    loginGuard *LoginGuard,
+    lockoutRepo repository.LoginLockoutRepository,
+    twoFactor *TwoFactorManager,
 ) *AuthUseCase {
     return &AuthUseCase{
         userRepo:    userRepo,
         authService: authService,
         cache:       cache,
+        tokenStore:  tokenStore,
+        refreshTTL:  refreshTTL,
+        resetSender: resetSender,
+        resetTTL:    resetTTL,
+        loginGuard:  loginGuard,
+        lockoutRepo: lockoutRepo,
+        twoFactor:   twoFactor,
     }
 }
 
 func (uc *AuthUseCase) Register(ctx context.Context, req *dto.RegisterRequest) (*dto.AuthResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.Register")
+    defer span.End()
     
     if err := uc.validateRegistrationInput(req); err != nil {
         return nil, err
@@ -60,12 +88,13 @@ func (uc *AuthUseCase) Register(ctx context.Context, req *dto.RegisterRequest) (
     }
 
     user := &entity.User{
-        ID:       utils.GenerateULID(),
-        Username: req.Username,
-        Email:    req.Email,
-        Password: string(password), 
-        Role:     entity.RoleUser, 
-        IsActive: true,
+        ID:           utils.GenerateULID(),
+        Username:     req.Username,
+        Email:        req.Email,
+        Password:     string(password), 
+        Role:         entity.RoleUser, 
+        AuthProvider: entity.AuthProviderLocal,
+        IsActive:     true,
     }
 
     if err := uc.userRepo.Create(ctx, user); err != nil {
@@ -116,7 +145,14 @@ func (uc *AuthUseCase) validateUserNotExists(ctx context.Context, username, emai
 }
 
 func (uc *AuthUseCase) generateAuthResponse(ctx context.Context, user *entity.User) (*dto.AuthResponse, error) {
-    token, err := uc.authService.GenerateToken(user.ID, user.Username, string(user.Role))
+    token, err := uc.authService.GenerateToken(user, auth.TokenOptions{
+        MustEnrollTwoFactor: uc.twoFactor.MustEnroll(user),
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    refreshToken, err := uc.tokenStore.IssueRefreshToken(ctx, user.ID, uc.refreshTTL)
     if err != nil {
         return nil, err
     }
@@ -126,30 +162,190 @@ func (uc *AuthUseCase) generateAuthResponse(ctx context.Context, user *entity.Us
     uc.cache.Set(ctx, cacheKey, user, constants.UserCacheDuration)
 
     return &dto.AuthResponse{
-        Token:     token,
-        User:      user,
-        ExpiresIn: constants.TokenExpirationSecs,
+        Token:            token,
+        RefreshToken:     refreshToken,
+        User:             user,
+        ExpiresIn:        int(uc.authService.TokenExpiry().Seconds()),
+        RefreshExpiresIn: int(uc.refreshTTL.Seconds()),
     }, nil
 }
 
-func (uc *AuthUseCase) Login(ctx context.Context, req *dto.LoginRequest) (*dto.AuthResponse, error) {
+// RefreshToken exchanges a refresh token for a new access and refresh token pair.
+// The presented refresh token is consumed, so it cannot be used again.
+func (uc *AuthUseCase) RefreshToken(ctx context.Context, req *dto.RefreshTokenRequest) (*dto.AuthResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.RefreshToken")
+    defer span.End()
+
+    session, err := uc.tokenStore.ConsumeRefreshToken(ctx, req.RefreshToken)
+    if err != nil {
+        // A used token coming back means it was copied; the copy and the
+        // tokens issued from the original are all signed out.
+        var reused *auth.RefreshTokenReusedError
+        if errors.As(err, &reused) {
+            tracing.RecordError(span, err)
+            if revokeErr := uc.revokeSessions(ctx, reused.UserID); revokeErr != nil {
+                tracing.RecordError(span, revokeErr)
+                return nil, revokeErr
+            }
+        }
+        return nil, err
+    }
+
+    
+    user, err := uc.userRepo.FindByID(ctx, session.UserID)
+    if err != nil {
+        return nil, auth.ErrInvalidRefreshToken
+    }
+
+    if !user.IsActive {
+        return nil, ErrInactiveUser
+    }
+
+    return uc.generateAuthResponse(ctx, user)
+}
+
+// Logout revokes the access token identified by tokenID until it would have expired,
+// and the refresh token if one is given.
+func (uc *AuthUseCase) Logout(ctx context.Context, tokenID string, tokenExpiresAt time.Time, req *dto.LogoutRequest) error {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.Logout")
+    defer span.End()
+
+    if err := uc.tokenStore.RevokeAccessToken(ctx, tokenID, time.Until(tokenExpiresAt)); err != nil {
+        return err
+    }
+
+    if req.RefreshToken != "" {
+        return uc.tokenStore.DeleteRefreshToken(ctx, req.RefreshToken)
+    }
+
+    return nil
+}
+
+// revokeSessions signs the user out everywhere by invalidating every token issued so far.
+func (uc *AuthUseCase) revokeSessions(ctx context.Context, userID string) error {
+    ttl := uc.refreshTTL
+    if expiry := uc.authService.TokenExpiry(); expiry > ttl {
+        ttl = expiry
+    }
+    return uc.tokenStore.RevokeUserSessions(ctx, userID, ttl)
+}
+
+// Login authenticates by username or email. Failed attempts are counted per account
+// and per clientIP, and a *LoginLockedError is returned while either is locked out.
+// Users with two-factor authentication get a challenge instead of tokens, to be
+// completed with VerifyTwoFactor.
+func (uc *AuthUseCase) Login(ctx context.Context, req *dto.LoginRequest, clientIP string) (*dto.AuthResponse, *dto.TwoFactorChallengeResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.Login")
+    defer span.End()
+
     user, err := uc.userRepo.FindByUsernameOrEmail(ctx, req.Identifier)
     if err != nil {
-        return nil, ErrInvalidCredentials
+        user = nil
     }
 
-    if !user.ComparePassword(req.Password) {
-        return nil, ErrInvalidCredentials
+    subject := newLoginSubject(user, req.Identifier, clientIP)
+    if err := uc.loginGuard.Check(ctx, subject); err != nil {
+        return nil, nil, err
+    }
+
+    if user == nil || !user.ComparePassword(req.Password) {
+        if err := uc.loginGuard.RecordFailure(ctx, subject); err != nil {
+            return nil, nil, err
+        }
+        return nil, nil, ErrInvalidCredentials
+    }
+
+    if !user.IsActive {
+        return nil, nil, ErrInactiveUser
+    }
+
+    // Failures are only forgotten once the second factor is passed, so wrong
+    // codes keep counting towards the account lockout.
+    if user.TwoFactorEnabled {
+        challenge, err := uc.twoFactorChallenge(ctx, user)
+        return nil, challenge, err
+    }
+
+    uc.loginGuard.RecordSuccess(ctx, subject)
+
+    authResponse, err := uc.generateAuthResponse(ctx, user)
+    return authResponse, nil, err
+}
+
+// twoFactorChallenge starts the second step of a login for a user with
+// two-factor authentication enabled, to be completed with VerifyTwoFactor.
+func (uc *AuthUseCase) twoFactorChallenge(ctx context.Context, user *entity.User) (*dto.TwoFactorChallengeResponse, error) {
+    challengeToken, err := uc.twoFactor.IssueChallenge(ctx, user.ID)
+    if err != nil {
+        return nil, err
+    }
+    return &dto.TwoFactorChallengeResponse{
+        TwoFactorRequired: true,
+        ChallengeToken:    challengeToken,
+        ExpiresIn:         int(uc.twoFactor.ChallengeTTL().Seconds()),
+    }, nil
+}
+
+// VerifyTwoFactor completes a login started by Login with a TOTP or recovery code.
+func (uc *AuthUseCase) VerifyTwoFactor(ctx context.Context, req *dto.VerifyTwoFactorRequest, clientIP string) (*dto.AuthResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.VerifyTwoFactor")
+    defer span.End()
+
+    userID, err := uc.twoFactor.Challenge(ctx, req.ChallengeToken)
+    if err != nil {
+        return nil, err
+    }
+
+    user, err := uc.userRepo.FindByID(ctx, userID)
+    if err != nil {
+        return nil, ErrInvalidChallenge
+    }
+
+    subject := newLoginSubject(user, user.Username, clientIP)
+    if err := uc.loginGuard.Check(ctx, subject); err != nil {
+        return nil, err
+    }
+
+    ok, err := uc.verifySecondFactor(ctx, user, req.Code, req.RecoveryCode)
+    if err != nil {
+        return nil, err
+    }
+    if !ok {
+        uc.twoFactor.FailChallenge(ctx, req.ChallengeToken)
+        if err := uc.loginGuard.RecordFailure(ctx, subject); err != nil {
+            return nil, err
+        }
+        return nil, ErrInvalidTwoFactorCode
+    }
+
+    consumedBy, err := uc.twoFactor.ConsumeChallenge(ctx, req.ChallengeToken)
+    if err != nil || consumedBy != user.ID {
+        return nil, ErrInvalidChallenge
     }
 
     if !user.IsActive {
         return nil, ErrInactiveUser
     }
 
+    uc.loginGuard.RecordSuccess(ctx, subject)
+
     return uc.generateAuthResponse(ctx, user)
 }
 
+// verifySecondFactor checks a TOTP code, or a recovery code when no TOTP code is given.
+func (uc *AuthUseCase) verifySecondFactor(ctx context.Context, user *entity.User, code, recoveryCode string) (bool, error) {
+    if code != "" {
+        return uc.twoFactor.VerifyCode(ctx, user, code)
+    }
+    if recoveryCode != "" {
+        return uc.twoFactor.UseRecoveryCode(ctx, user.ID, recoveryCode)
+    }
+    return false, nil
+}
+
 func (uc *AuthUseCase) GetUserByID(ctx context.Context, userID string) (*entity.User, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.GetUserByID")
+    defer span.End()
     
     if !utils.IsValidULID(userID) {
         return nil, ErrInvalidCredentials
@@ -176,8 +372,12 @@ func (uc *AuthUseCase) GetUserByID(ctx context.Context, userID string) (*entity.
     return dbUser, nil
 }
 
-func (uc *AuthUseCase) GetAllUsers(ctx context.Context, requesterID string) (*dto.UserListResponse, error) {
-    
+// GetAllUsers returns one page of users matching filters; see
+// repository.UserRepository.FindAll for the supported keys.
+func (uc *AuthUseCase) GetAllUsers(ctx context.Context, requesterID string, filters map[string]interface{}, page, limit int) (*dto.UserListResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.GetAllUsers")
+    defer span.End()
+
     requester, err := uc.GetUserByID(ctx, requesterID)
     if err != nil {
         return nil, err
@@ -187,34 +387,30 @@ func (uc *AuthUseCase) GetAllUsers(ctx context.Context, requesterID string) (*dt
         return nil, ErrForbidden
     }
 
-    
-    users, total, err := uc.userRepo.FindAll(ctx, 0, 0)
+    offset := (page - 1) * limit
+    users, total, err := uc.userRepo.FindAll(ctx, filters, limit, offset)
     if err != nil {
         return nil, err
     }
 
-    
     userResponses := make([]*dto.UserResponse, len(users))
     for i, user := range users {
-        userResponses[i] = &dto.UserResponse{
-            ID:        user.ID,
-            Username:  user.Username,
-            Email:     user.Email,
-            Role:      user.Role,
-            IsActive:  user.IsActive,
-            CreatedAt: user.CreatedAt.Format("2006-01-02 15:04:05"),
-            UpdatedAt: user.UpdatedAt.Format("2006-01-02 15:04:05"),
-        }
+        userResponses[i] = newUserResponse(user)
     }
 
     return &dto.UserListResponse{
-        Users: userResponses,
-        Total: total,
+        Users:      userResponses,
+        Total:      total,
+        Page:       page,
+        PerPage:    limit,
+        TotalPages: (total + int64(limit) - 1) / int64(limit),
     }, nil
 }
 
 
 func (uc *AuthUseCase) GetUserDetailByID(ctx context.Context, requesterID, targetUserID string) (*dto.UserResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.GetUserDetailByID")
+    defer span.End()
     
     requester, err := uc.GetUserByID(ctx, requesterID)
     if err != nil {
@@ -236,19 +432,13 @@ func (uc *AuthUseCase) GetUserDetailByID(ctx context.Context, requesterID, targe
         return nil, ErrUserNotFound
     }
 
-    return &dto.UserResponse{
-        ID:        targetUser.ID,
-        Username:  targetUser.Username,
-        Email:     targetUser.Email,
-        Role:      targetUser.Role,
-        IsActive:  targetUser.IsActive,
-        CreatedAt: targetUser.CreatedAt.Format("2006-01-02 15:04:05"),
-        UpdatedAt: targetUser.UpdatedAt.Format("2006-01-02 15:04:05"),
-    }, nil
+    return newUserResponse(targetUser), nil
 }
 
 
 func (uc *AuthUseCase) CreateUser(ctx context.Context, requesterID string, req *dto.CreateUserRequest) (*dto.UserResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.CreateUser")
+    defer span.End()
     
     requester, err := uc.GetUserByID(ctx, requesterID)
     if err != nil {
@@ -297,12 +487,16 @@ func (uc *AuthUseCase) CreateUser(ctx context.Context, requesterID string, req *
 
     
     newUser := &entity.User{
-        ID:       utils.GenerateULID(),
-        Username: req.Username,
-        Email:    req.Email,
-        Password: string(hashedPassword),
-        Role:     req.Role,
-        IsActive: true,
+        ID:                 utils.GenerateULID(),
+        Username:           req.Username,
+        Email:              req.Email,
+        Password:           string(hashedPassword),
+        Role:               req.Role,
+        IsActive:           true,
+        MustChangePassword: req.MustChangePassword,
+        AuthProvider:       entity.AuthProviderLocal,
+        District:           strings.TrimSpace(req.District),
+        Unit:               strings.TrimSpace(req.Unit),
     }
 
     if err := uc.userRepo.Create(ctx, newUser); err != nil {
@@ -313,19 +507,13 @@ func (uc *AuthUseCase) CreateUser(ctx context.Context, requesterID string, req *
     cacheKey := constants.UserCachePrefix + newUser.ID
     uc.cache.Set(ctx, cacheKey, newUser, constants.UserCacheDuration)
 
-    return &dto.UserResponse{
-        ID:        newUser.ID,
-        Username:  newUser.Username,
-        Email:     newUser.Email,
-        Role:      newUser.Role,
-        IsActive:  newUser.IsActive,
-        CreatedAt: newUser.CreatedAt.Format("2006-01-02 15:04:05"),
-        UpdatedAt: newUser.UpdatedAt.Format("2006-01-02 15:04:05"),
-    }, nil
+    return newUserResponse(newUser), nil
 }
 
 
-func (uc *AuthUseCase) UpdateUserRole(ctx context.Context, requesterID, targetUserID string, req *dto.UpdateUserRequest) (*dto.UserResponse, error) {
+func (uc *AuthUseCase) UpdateUser(ctx context.Context, requesterID, targetUserID string, req *dto.UpdateUserRequest) (*dto.UserResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.UpdateUser")
+    defer span.End()
     
     requester, err := uc.GetUserByID(ctx, requesterID)
     if err != nil {
@@ -342,36 +530,58 @@ func (uc *AuthUseCase) UpdateUserRole(ctx context.Context, requesterID, targetUs
     }
 
     
-    targetUser, err := uc.GetUserByID(ctx, targetUserID)
+    // Load from the database: cached users carry no password hash and Save would blank it.
+    targetUser, err := uc.userRepo.FindByID(ctx, targetUserID)
     if err != nil {
         return nil, ErrUserNotFound
     }
 
-    
-    targetUser.Role = req.Role
+    // Tokens carry the role and data scope, so existing sessions must end when
+    // either changes or when the account is deactivated.
+    revoke := false
+    if req.Role != "" && req.Role != targetUser.Role {
+        targetUser.Role = req.Role
+        revoke = true
+    }
+    if req.IsActive != nil && *req.IsActive != targetUser.IsActive {
+        targetUser.IsActive = *req.IsActive
+        revoke = revoke || !targetUser.IsActive
+    }
+    if req.District != nil && strings.TrimSpace(*req.District) != targetUser.District {
+        targetUser.District = strings.TrimSpace(*req.District)
+        revoke = true
+    }
+    if req.Unit != nil && strings.TrimSpace(*req.Unit) != targetUser.Unit {
+        targetUser.Unit = strings.TrimSpace(*req.Unit)
+        revoke = true
+    }
+    // Takes effect on the next login; current sessions are left alone.
+    if req.MustChangePassword != nil {
+        targetUser.MustChangePassword = *req.MustChangePassword
+    }
 
     
     if err := uc.userRepo.Update(ctx, targetUser); err != nil {
         return nil, err
     }
 
+    if revoke {
+        if err := uc.revokeSessions(ctx, targetUser.ID); err != nil {
+            return nil, err
+        }
+    }
+
     
     cacheKey := constants.UserCachePrefix + targetUser.ID
     uc.cache.Set(ctx, cacheKey, targetUser, constants.UserCacheDuration)
 
-    return &dto.UserResponse{
-        ID:        targetUser.ID,
-        Username:  targetUser.Username,
-        Email:     targetUser.Email,
-        Role:      targetUser.Role,
-        IsActive:  targetUser.IsActive,
-        CreatedAt: targetUser.CreatedAt.Format("2006-01-02 15:04:05"),
-        UpdatedAt: targetUser.UpdatedAt.Format("2006-01-02 15:04:05"),
-    }, nil
+    return newUserResponse(targetUser), nil
 }
 
 
 func (uc *AuthUseCase) DeleteUser(ctx context.Context, requesterID, targetUserID string) error {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.DeleteUser")
+    defer span.End()
     
     requester, err := uc.GetUserByID(ctx, requesterID)
     if err != nil {
@@ -403,9 +613,389 @@ func (uc *AuthUseCase) DeleteUser(ctx context.Context, requesterID, targetUserID
         return err
     }
 
+    if err := uc.revokeSessions(ctx, targetUser.ID); err != nil {
+        return err
+    }
+
     
     cacheKey := constants.UserCachePrefix + targetUser.ID
     uc.cache.Delete(ctx, cacheKey)
 
     return nil
-}
\ No newline at end of file
+}
+
+// ChangePassword sets a new password for the logged-in user, ends every other
+// session and returns a fresh token pair for the caller.
+func (uc *AuthUseCase) ChangePassword(ctx context.Context, userID string, req *dto.ChangePasswordRequest) (*dto.AuthResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.ChangePassword")
+    defer span.End()
+
+    user, err := uc.userRepo.FindByID(ctx, userID)
+    if err != nil {
+        return nil, ErrUserNotFound
+    }
+
+    if !user.ComparePassword(req.CurrentPassword) {
+        return nil, ErrInvalidCredentials
+    }
+
+    if req.CurrentPassword == req.NewPassword {
+        return nil, ErrSamePassword
+    }
+
+    if err := uc.setPassword(ctx, user, req.NewPassword); err != nil {
+        return nil, err
+    }
+
+    return uc.generateAuthResponse(ctx, user)
+}
+
+// IssuePasswordReset creates a one-time reset token for the target user and hands
+// it to the configured sender. The token is never returned to the admin.
+func (uc *AuthUseCase) IssuePasswordReset(ctx context.Context, requesterID, targetUserID string) (*dto.PasswordResetIssuedResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.IssuePasswordReset")
+    defer span.End()
+
+    requester, err := uc.GetUserByID(ctx, requesterID)
+    if err != nil {
+        return nil, err
+    }
+
+    if !requester.IsSuperAdmin() {
+        return nil, ErrForbidden
+    }
+
+    if !utils.IsValidULID(targetUserID) {
+        return nil, ErrUserNotFound
+    }
+
+    targetUser, err := uc.userRepo.FindByID(ctx, targetUserID)
+    if err != nil {
+        return nil, ErrUserNotFound
+    }
+
+    token, err := uc.tokenStore.IssuePasswordResetToken(ctx, targetUser.ID, uc.resetTTL)
+    if err != nil {
+        return nil, err
+    }
+
+    expiresAt := time.Now().Add(uc.resetTTL)
+    if err := uc.resetSender.SendPasswordReset(ctx, targetUser, token, expiresAt); err != nil {
+        return nil, err
+    }
+
+    return &dto.PasswordResetIssuedResponse{
+        UserID:    targetUser.ID,
+        ExpiresAt: expiresAt.Format("2006-01-02 15:04:05"),
+    }, nil
+}
+
+// ResetPassword redeems a reset token and signs the user out everywhere.
+func (uc *AuthUseCase) ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) error {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.ResetPassword")
+    defer span.End()
+
+    userID, err := uc.tokenStore.ConsumePasswordResetToken(ctx, req.Token)
+    if err != nil {
+        return err
+    }
+
+    user, err := uc.userRepo.FindByID(ctx, userID)
+    if err != nil {
+        return auth.ErrInvalidResetToken
+    }
+
+    if !user.IsActive {
+        return ErrInactiveUser
+    }
+
+    return uc.setPassword(ctx, user, req.NewPassword)
+}
+
+// setPassword validates, hashes and stores a new password, clears the forced-change
+// flag and revokes all existing sessions.
+func (uc *AuthUseCase) setPassword(ctx context.Context, user *entity.User, password string) error {
+    if err := validation.ValidatePassword(password); err != nil {
+        return err
+    }
+
+    hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+    if err != nil {
+        return err
+    }
+
+    now := time.Now()
+    user.Password = string(hashedPassword)
+    user.MustChangePassword = false
+    user.PasswordChangedAt = &now
+
+    if err := uc.userRepo.Update(ctx, user); err != nil {
+        return err
+    }
+
+    uc.cache.Delete(ctx, constants.UserCachePrefix+user.ID)
+
+    return uc.revokeSessions(ctx, user.ID)
+}
+
+func (uc *AuthUseCase) ListLoginLockouts(ctx context.Context, requesterID string, filters map[string]interface{}, page, limit int) (*dto.PaginatedLoginLockoutResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.ListLoginLockouts")
+    defer span.End()
+
+    requester, err := uc.GetUserByID(ctx, requesterID)
+    if err != nil {
+        return nil, err
+    }
+
+    if !requester.IsSuperAdmin() {
+        return nil, ErrForbidden
+    }
+
+    offset := (page - 1) * limit
+    lockouts, total, err := uc.lockoutRepo.FindAll(ctx, filters, limit, offset)
+    if err != nil {
+        return nil, err
+    }
+
+    return &dto.PaginatedLoginLockoutResponse{
+        Lockouts:   lockouts,
+        Total:      total,
+        Page:       page,
+        PerPage:    limit,
+        TotalPages: (total + int64(limit) - 1) / int64(limit),
+    }, nil
+}
+
+// UnlockUser lifts a login lockout on the target account before it expires.
+func (uc *AuthUseCase) UnlockUser(ctx context.Context, requesterID, targetUserID string) error {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.UnlockUser")
+    defer span.End()
+
+    requester, err := uc.GetUserByID(ctx, requesterID)
+    if err != nil {
+        return err
+    }
+
+    if !requester.IsSuperAdmin() {
+        return ErrForbidden
+    }
+
+    if !utils.IsValidULID(targetUserID) {
+        return ErrUserNotFound
+    }
+
+    if _, err := uc.userRepo.FindByID(ctx, targetUserID); err != nil {
+        return ErrUserNotFound
+    }
+
+    return uc.loginGuard.Unlock(ctx, targetUserID, requesterID)
+}
+
+func (uc *AuthUseCase) GetTwoFactorStatus(ctx context.Context, userID string) (*dto.TwoFactorStatusResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.GetTwoFactorStatus")
+    defer span.End()
+
+    user, err := uc.userRepo.FindByID(ctx, userID)
+    if err != nil {
+        return nil, ErrUserNotFound
+    }
+
+    status := &dto.TwoFactorStatusResponse{
+        Enabled:  user.TwoFactorEnabled,
+        Required: uc.twoFactor.Required(user.Role),
+    }
+    if user.TwoFactorEnabledAt != nil {
+        enabledAt := user.TwoFactorEnabledAt.Format("2006-01-02 15:04:05")
+        status.EnabledAt = &enabledAt
+    }
+    if user.TwoFactorEnabled {
+        if status.RecoveryCodesRemaining, err = uc.twoFactor.RemainingRecoveryCodes(ctx, user.ID); err != nil {
+            return nil, err
+        }
+    }
+
+    return status, nil
+}
+
+// SetupTwoFactor starts enrollment by generating a secret for the user's
+// authenticator app. Nothing changes until EnableTwoFactor confirms a code.
+func (uc *AuthUseCase) SetupTwoFactor(ctx context.Context, userID string) (*dto.TwoFactorSetupResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.SetupTwoFactor")
+    defer span.End()
+
+    user, err := uc.userRepo.FindByID(ctx, userID)
+    if err != nil {
+        return nil, ErrUserNotFound
+    }
+
+    if user.TwoFactorEnabled {
+        return nil, ErrTwoFactorAlreadyEnabled
+    }
+
+    secret, uri, err := uc.twoFactor.BeginSetup(ctx, user)
+    if err != nil {
+        return nil, err
+    }
+
+    return &dto.TwoFactorSetupResponse{
+        Secret:     secret,
+        OTPAuthURI: uri,
+    }, nil
+}
+
+// EnableTwoFactor confirms enrollment with a code from the authenticator app,
+// issues recovery codes and replaces every session with a fresh token pair.
+func (uc *AuthUseCase) EnableTwoFactor(ctx context.Context, userID string, req *dto.TwoFactorCodeRequest) (*dto.TwoFactorEnabledResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.EnableTwoFactor")
+    defer span.End()
+
+    user, err := uc.userRepo.FindByID(ctx, userID)
+    if err != nil {
+        return nil, ErrUserNotFound
+    }
+
+    if user.TwoFactorEnabled {
+        return nil, ErrTwoFactorAlreadyEnabled
+    }
+
+    sealedSecret, err := uc.twoFactor.ConfirmSetup(ctx, user.ID, req.Code)
+    if err != nil {
+        return nil, err
+    }
+
+    recoveryCodes, err := uc.twoFactor.GenerateRecoveryCodes(ctx, user.ID)
+    if err != nil {
+        return nil, err
+    }
+
+    now := time.Now()
+    user.TwoFactorEnabled = true
+    user.TwoFactorSecret = sealedSecret
+    user.TwoFactorEnabledAt = &now
+    user.BeforeUpdate()
+
+    if err := uc.userRepo.Update(ctx, user); err != nil {
+        return nil, err
+    }
+
+    if err := uc.revokeSessions(ctx, user.ID); err != nil {
+        return nil, err
+    }
+
+    authResponse, err := uc.generateAuthResponse(ctx, user)
+    if err != nil {
+        return nil, err
+    }
+
+    return &dto.TwoFactorEnabledResponse{
+        RecoveryCodes: recoveryCodes,
+        Auth:          authResponse,
+    }, nil
+}
+
+// DisableTwoFactor turns two-factor off for the user after checking both their
+// password and a second factor. Roles that require two-factor cannot disable it.
+func (uc *AuthUseCase) DisableTwoFactor(ctx context.Context, userID string, req *dto.DisableTwoFactorRequest) error {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.DisableTwoFactor")
+    defer span.End()
+
+    user, err := uc.userRepo.FindByID(ctx, userID)
+    if err != nil {
+        return ErrUserNotFound
+    }
+
+    if !user.TwoFactorEnabled {
+        return ErrTwoFactorNotEnabled
+    }
+
+    if uc.twoFactor.Required(user.Role) {
+        return ErrTwoFactorRequired
+    }
+
+    if !user.ComparePassword(req.Password) {
+        return ErrInvalidCredentials
+    }
+
+    ok, err := uc.verifySecondFactor(ctx, user, req.Code, req.RecoveryCode)
+    if err != nil {
+        return err
+    }
+    if !ok {
+        return ErrInvalidTwoFactorCode
+    }
+
+    return uc.clearTwoFactor(ctx, user)
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after checking a TOTP code.
+func (uc *AuthUseCase) RegenerateRecoveryCodes(ctx context.Context, userID string, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.RegenerateRecoveryCodes")
+    defer span.End()
+
+    user, err := uc.userRepo.FindByID(ctx, userID)
+    if err != nil {
+        return nil, ErrUserNotFound
+    }
+
+    ok, err := uc.twoFactor.VerifyCode(ctx, user, req.Code)
+    if err != nil {
+        return nil, err
+    }
+    if !ok {
+        return nil, ErrInvalidTwoFactorCode
+    }
+
+    recoveryCodes, err := uc.twoFactor.GenerateRecoveryCodes(ctx, user.ID)
+    if err != nil {
+        return nil, err
+    }
+
+    return &dto.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// ResetTwoFactor removes two-factor from a user who lost their authenticator
+// and recovery codes, and signs them out. If their role requires two-factor
+// they have to enroll again at the next login.
+func (uc *AuthUseCase) ResetTwoFactor(ctx context.Context, requesterID, targetUserID string) error {
+    ctx, span := tracing.Start(ctx, "AuthUseCase.ResetTwoFactor")
+    defer span.End()
+
+    requester, err := uc.GetUserByID(ctx, requesterID)
+    if err != nil {
+        return err
+    }
+
+    if !requester.IsSuperAdmin() {
+        return ErrForbidden
+    }
+
+    if !utils.IsValidULID(targetUserID) {
+        return ErrUserNotFound
+    }
+
+    targetUser, err := uc.userRepo.FindByID(ctx, targetUserID)
+    if err != nil {
+        return ErrUserNotFound
+    }
+
+    if err := uc.clearTwoFactor(ctx, targetUser); err != nil {
+        return err
+    }
+
+    return uc.revokeSessions(ctx, targetUser.ID)
+}
+
+func (uc *AuthUseCase) clearTwoFactor(ctx context.Context, user *entity.User) error {
+    user.TwoFactorEnabled = false
+    user.TwoFactorSecret = ""
+    user.TwoFactorEnabledAt = nil
+    user.BeforeUpdate()
+
+    if err := uc.userRepo.Update(ctx, user); err != nil {
+        return err
+    }
+
+    uc.cache.Delete(ctx, constants.UserCachePrefix+user.ID)
+
+    return uc.twoFactor.Reset(ctx, user.ID)
+}
@@ -9,29 +9,44 @@ import (
 	"building-report-backend/internal/application/dto"
 	"building-report-backend/internal/domain/entity"
 	"building-report-backend/internal/domain/repository"
-	"building-report-backend/internal/infrastructure/storage"
+	"building-report-backend/pkg/tracing"
 	"building-report-backend/pkg/utils"
 )
 
 type BinaMargaUseCase struct {
 	binaMargaRepo repository.BinaMargaRepository
-	storage       storage.StorageService
+	uploads       *UploadUseCase
+	work          *UnitOfWork
+	links         *PhotoLinks
+	photos        *PhotoUseCase
 	cache         repository.CacheRepository
+	auditRepo     repository.AuditLogRepository
 }
 
 func NewBinaMargaUseCase(
 	binaMargaRepo repository.BinaMargaRepository,
-	storage storage.StorageService,
+	uploads *UploadUseCase,
+	work *UnitOfWork,
+	links *PhotoLinks,
+	photos *PhotoUseCase,
 	cache repository.CacheRepository,
+	auditRepo repository.AuditLogRepository,
 ) *BinaMargaUseCase {
 	return &BinaMargaUseCase{
 		binaMargaRepo: binaMargaRepo,
-		storage:       storage,
+		uploads:       uploads,
+		work:          work,
+		links:         links,
+		photos:        photos,
 		cache:         cache,
+		auditRepo:     auditRepo,
 	}
 }
 
-func (uc *BinaMargaUseCase) CreateReport(ctx context.Context, req *dto.CreateBinaMargaRequest, photos []*multipart.FileHeader) (*entity.BinaMargaReport, error) {    
+func (uc *BinaMargaUseCase) CreateReport(ctx context.Context, req *dto.CreateBinaMargaRequest, photos []*multipart.FileHeader, userID string) (*entity.BinaMargaReport, error) {    
+    ctx, span := tracing.Start(ctx, "BinaMargaUseCase.CreateReport")
+    defer span.End()
+
     damagedArea := req.DamagedLength * req.DamagedWidth
     
     
@@ -90,34 +105,53 @@ func (uc *BinaMargaUseCase) CreateReport(ctx context.Context, req *dto.CreateBin
 
     
     photoAngles := []string{"before", "damage_detail", "traffic_impact", "aerial", "surrounding"}
-    for i, photo := range photos {
+    work := uc.work.Begin()
+    batch, err := uc.uploads.collectPhotos(ctx, work, photoSource{
+        Sector:    entity.SectorBinaMarga,
+        SessionID: req.UploadSessionID,
+        Files:     photos,
+        UserID:    userID,
+        ReportID:  report.ID,
+        Min:       2,
+    })
+    if err != nil {
+        work.Rollback(ctx)
+        return nil, err
+    }
+
+    for i, uploaded := range batch.Photos {
         angle := "general"
         if i < len(photoAngles) {
             angle = photoAngles[i]
         }
 
-        photoURL, err := uc.storage.UploadFile(ctx, photo, "bina-marga")
-        if err != nil {
-            return nil, fmt.Errorf("failed to upload photo: %w", err)
-        }
-
         caption := fmt.Sprintf("%s view - %s (%s)", angle, report.RoadName, report.DamageType)
         if report.BridgeName != "" {
             caption = fmt.Sprintf("%s view - Bridge %s (%s)", angle, report.BridgeName, report.BridgeDamageType)
         }
         
         report.Photos = append(report.Photos, entity.BinaMargaPhoto{
-            ID:         utils.GenerateULID(),
-            PhotoURL:   photoURL,
-            PhotoAngle: angle,
-            Caption:    caption,
+            ID:             utils.GenerateULID(),
+            PhotoURL:       uploaded.Key,
+            PhotoAngle:     angle,
+            Caption:        caption,
+            PhotoPlacement: entity.PhotoPlacement{Position: i},
+            PhotoMeta:      uploaded.PhotoMeta,
         })
     }
 
-    if err := uc.binaMargaRepo.Create(ctx, report); err != nil {
+    report.CreatedBy = userID
+
+    work.Do(func(ctx context.Context) error {
+        return uc.binaMargaRepo.Create(ctx, report)
+    })
+    if err := work.Commit(ctx); err != nil {
         return nil, err
     }
 
+    recordAudit(ctx, uc.auditRepo, entity.SectorBinaMarga, report.ID, entity.AuditActionCreate, userID, nil, report, "")
+    countReportCreated(entity.SectorBinaMarga)
+
     
     uc.cache.Delete(ctx, "bina_marga:list")
     uc.cache.Delete(ctx, "bina_marga:stats")
@@ -130,10 +164,14 @@ func (uc *BinaMargaUseCase) CreateReport(ctx context.Context, req *dto.CreateBin
         uc.sendUrgentNotification(ctx, report)
     }
 
+    uc.links.linkBinaMargaReports(ctx, report)
     return report, nil
 }
 
 func (uc *BinaMargaUseCase) GetReport(ctx context.Context, id string) (*entity.BinaMargaReport, error) {
+	ctx, span := tracing.Start(ctx, "BinaMargaUseCase.GetReport")
+	defer span.End()
+
 	cacheKey := "bina_marga:" + id
 
 	report, err := uc.binaMargaRepo.FindByID(ctx, id)
@@ -143,16 +181,43 @@ func (uc *BinaMargaUseCase) GetReport(ctx context.Context, id string) (*entity.B
 
 	uc.cache.Set(ctx, cacheKey, report, 3600)
 
+	uc.links.linkBinaMargaReports(ctx, report)
 	return report, nil
 }
 
 func (uc *BinaMargaUseCase) ListReports(ctx context.Context, page, limit int, filters map[string]interface{}) (*dto.PaginatedBinaMargaResponse, error) {
+	ctx, span := tracing.Start(ctx, "BinaMargaUseCase.ListReports")
+	defer span.End()
+
 	offset := (page - 1) * limit
 
 	reports, total, err := uc.binaMargaRepo.FindAll(ctx, limit, offset, filters)
 	if err != nil {
 		return nil, err
 	}
+	uc.links.linkBinaMargaReports(ctx, reports...)
+
+	return &dto.PaginatedBinaMargaResponse{
+		Reports:    reports,
+		Total:      total,
+		Page:       page,
+		PerPage:    limit,
+		TotalPages: (total + int64(limit) - 1) / int64(limit),
+	}, nil
+}
+
+// ListMyReports returns the reports created by userID, newest first.
+func (uc *BinaMargaUseCase) ListMyReports(ctx context.Context, userID string, page, limit int) (*dto.PaginatedBinaMargaResponse, error) {
+	ctx, span := tracing.Start(ctx, "BinaMargaUseCase.ListMyReports")
+	defer span.End()
+
+	offset := (page - 1) * limit
+
+	reports, total, err := uc.binaMargaRepo.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	uc.links.linkBinaMargaReports(ctx, reports...)
 
 	return &dto.PaginatedBinaMargaResponse{
 		Reports:    reports,
@@ -164,12 +229,16 @@ func (uc *BinaMargaUseCase) ListReports(ctx context.Context, page, limit int, fi
 }
 
 func (uc *BinaMargaUseCase) ListByPriority(ctx context.Context, page, limit int) (*dto.PaginatedBinaMargaResponse, error) {
+	ctx, span := tracing.Start(ctx, "BinaMargaUseCase.ListByPriority")
+	defer span.End()
+
 	offset := (page - 1) * limit
 
 	reports, total, err := uc.binaMargaRepo.FindByPriority(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
+	uc.links.linkBinaMargaReports(ctx, reports...)
 
 	return &dto.PaginatedBinaMargaResponse{
 		Reports:    reports,
@@ -180,16 +249,21 @@ func (uc *BinaMargaUseCase) ListByPriority(ctx context.Context, page, limit int)
 	}, nil
 }
 
-func (uc *BinaMargaUseCase) UpdateReport(ctx context.Context, id string, req *dto.UpdateBinaMargaRequest, userID string) (*entity.BinaMargaReport, error) {
+func (uc *BinaMargaUseCase) UpdateReport(ctx context.Context, id string, req *dto.UpdateBinaMargaRequest, userID string, role entity.UserRole) (*entity.BinaMargaReport, error) {
+    ctx, span := tracing.Start(ctx, "BinaMargaUseCase.UpdateReport")
+    defer span.End()
+
     report, err := uc.binaMargaRepo.FindByID(ctx, id)
     if err != nil {
         return nil, err
     }
 
     
-    // if report.CreatedBy != userID {
-    //     return nil, ErrUnauthorized
-    // }
+    if !entity.CanManageReport(report.CreatedBy, userID, role) {
+        return nil, ErrUnauthorized
+    }
+
+    before := *report
 
     if req.District != "" {
         report.District = req.District
@@ -291,48 +365,89 @@ func (uc *BinaMargaUseCase) UpdateReport(ctx context.Context, id string, req *dt
         report.EstimatedRepairTime = uc.calculateEstimatedRepairTime(report)
     }
 
+    report.UpdatedBy = userID
+
     if err := uc.binaMargaRepo.Update(ctx, report); err != nil {
         return nil, err
     }
 
+    recordAudit(ctx, uc.auditRepo, entity.SectorBinaMarga, report.ID, entity.AuditActionUpdate, userID, &before, report, "")
+
     
     uc.cache.Delete(ctx, "bina_marga:"+id)
     uc.cache.Delete(ctx, "bina_marga:list")
     uc.cache.Delete(ctx, "bina_marga:stats")
 
+    uc.links.linkBinaMargaReports(ctx, report)
     return report, nil
 }
 
-func (uc *BinaMargaUseCase) UpdateStatus(ctx context.Context, id string, req *dto.UpdateBinaMargaStatusRequest) error {
-	err := uc.binaMargaRepo.UpdateStatus(ctx, id, entity.BinaMargaStatus(req.Status), req.Notes)
+func (uc *BinaMargaUseCase) UpdateStatus(ctx context.Context, id string, req *dto.UpdateBinaMargaStatusRequest, userID string) error {
+	ctx, span := tracing.Start(ctx, "BinaMargaUseCase.UpdateStatus")
+	defer span.End()
+
+	report, err := uc.binaMargaRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
+	next := entity.BinaMargaStatus(req.Status)
+	if !report.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, report.Status, next)
+	}
+
+	// Photos of the repair are saved with the change, so if it fails the
+	// upload session can be reused.
+	work := uc.work.Begin()
+	if req.UploadSessionID != "" {
+		phase, ok := next.RepairPhase()
+		if !ok {
+			return ErrRepairPhotosNotAllowed
+		}
+		if err := uc.photos.attachRepairPhotos(ctx, work, entity.SectorBinaMarga, id, req.UploadSessionID, phase, string(next), userID); err != nil {
+			work.Rollback(ctx)
+			return err
+		}
+	}
+
+	work.Do(func(ctx context.Context) error {
+		return uc.binaMargaRepo.UpdateStatus(ctx, id, report.Status, next, req.Notes, userID)
+	})
+	if err := work.Commit(ctx); err != nil {
+		return err
+	}
+
+	before := *report
+	report.Status = next
+	report.Notes = req.Notes
+	recordAudit(ctx, uc.auditRepo, entity.SectorBinaMarga, report.ID, entity.AuditActionStatusChange, userID, &before, report, req.Notes)
+
 	uc.cache.Delete(ctx, "bina_marga:"+id)
+	uc.cache.Delete(ctx, "bina_marga:list")
 	uc.cache.Delete(ctx, "bina_marga:stats")
 
 	return nil
 }
 
-func (uc *BinaMargaUseCase) DeleteReport(ctx context.Context, id string, userID string) error {
+func (uc *BinaMargaUseCase) DeleteReport(ctx context.Context, id string, userID string, role entity.UserRole) error {
+	ctx, span := tracing.Start(ctx, "BinaMargaUseCase.DeleteReport")
+	defer span.End()
+
 	report, err := uc.binaMargaRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
     
-    // if report.CreatedBy != userID {
-    //     return ErrUnauthorized
-    // }
-
-	for _, photo := range report.Photos {
-		uc.storage.DeleteFile(ctx, photo.PhotoURL)
-	}
+    if !entity.CanManageReport(report.CreatedBy, userID, role) {
+        return ErrUnauthorized
+    }
 
 	if err := uc.binaMargaRepo.Delete(ctx, id); err != nil {
 		return err
 	}
 
+	recordAudit(ctx, uc.auditRepo, entity.SectorBinaMarga, report.ID, entity.AuditActionDelete, userID, report, nil, "")
+
 	uc.cache.Delete(ctx, "bina_marga:"+id)
 	uc.cache.Delete(ctx, "bina_marga:list")
 	uc.cache.Delete(ctx, "bina_marga:stats")
@@ -520,6 +635,9 @@ func (uc *BinaMargaUseCase) sendUrgentNotification(ctx context.Context, report *
 }
 
 func (uc *BinaMargaUseCase) GetDashboard(ctx context.Context, roadType string, startDate, endDate time.Time) (*dto.BinaMargaDashboardResponse, error) {
+    ctx, span := tracing.Start(ctx, "BinaMargaUseCase.GetDashboard")
+    defer span.End()
+
     avgSeg, avgArea, avgTraffic, total, err := uc.binaMargaRepo.GetKPIs(ctx, roadType, startDate, endDate)
     if err != nil {
         return nil, err
@@ -615,8 +733,11 @@ func deref(s *string) string {
 }
 
 func (uc *BinaMargaUseCase) GetBinaMargaOverview(ctx context.Context, roadType string) (*dto.BinaMargaOverviewResponse, error) {
+	ctx, span := tracing.Start(ctx, "BinaMargaUseCase.GetBinaMargaOverview")
+	defer span.End()
+
 	// Cache key based on road type
-	cacheKey := fmt.Sprintf("bina_marga:overview:%s", roadType)
+	cacheKey := scopedCacheKey(ctx, fmt.Sprintf("bina_marga:overview:%s", roadType))
 	var response dto.BinaMargaOverviewResponse
 
 	err := uc.cache.Get(ctx, cacheKey, &response)
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+
+	"building-report-backend/internal/domain/entity"
+)
+
+// scopedCacheKey keeps cached aggregates of district or unit scoped users apart
+// from the global ones. Writes only invalidate the global keys; scoped entries
+// expire with their TTL.
+func scopedCacheKey(ctx context.Context, key string) string {
+	if scopeKey := entity.DataScopeFromContext(ctx).Key(); scopeKey != "" {
+		return key + ":scope:" + scopeKey
+	}
+	return key
+}
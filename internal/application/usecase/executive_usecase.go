@@ -7,6 +7,7 @@ import (
 	"building-report-backend/internal/application/dto"
 	"building-report-backend/internal/domain/entity"
 	"building-report-backend/internal/domain/repository"
+	"building-report-backend/pkg/tracing"
 
 	"gorm.io/gorm"
 )
@@ -26,6 +27,8 @@ func NewExecutiveUseCase(executiveRepo repository.ExecutiveRepository) *Executiv
 }
 
 func (uc *ExecutiveUseCase) GetEkonomiOverview(ctx context.Context, tahun int) (*dto.EkonomiOverviewResponse, error) {
+    ctx, span := tracing.Start(ctx, "ExecutiveUseCase.GetEkonomiOverview")
+    defer span.End()
     
     dataCurrentYear, err := uc.executiveRepo.FindByTahun(ctx, tahun)
     if err != nil {
@@ -128,6 +131,9 @@ func (uc *ExecutiveUseCase) convertToTrendData(data []*entity.IndikatorEkonomi)
 }
 
 func (uc *ExecutiveUseCase) GetPopulationOverview(ctx context.Context, tahun int) (*dto.PopulationOverviewResponse, error) {
+    ctx, span := tracing.Start(ctx, "ExecutiveUseCase.GetPopulationOverview")
+    defer span.End()
+
     dataCurrentYear, err := uc.executiveRepo.FindDemografiByTahun(ctx, tahun)
     if err != nil {
         if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -216,6 +222,9 @@ func (uc *ExecutiveUseCase) convertToTrendDataDemografi(data []*entity.Indikator
 }
 
 func (uc *ExecutiveUseCase) GetPovertyOverview(ctx context.Context, tahun int) (*dto.PovertyOverviewResponse, error) {
+    ctx, span := tracing.Start(ctx, "ExecutiveUseCase.GetPovertyOverview")
+    defer span.End()
+
     dataCurrentYear, err := uc.executiveRepo.FindSosialByTahun(ctx, tahun)
     if err != nil {
         if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -313,6 +322,9 @@ func (uc *ExecutiveUseCase) convertToTrendDataSosial(data []*entity.IndikatorSos
 }
 
 func (uc *ExecutiveUseCase) GetEmploymentOverview(ctx context.Context, tahun int) (*dto.EmploymentOverviewResponse, error) {
+    ctx, span := tracing.Start(ctx, "ExecutiveUseCase.GetEmploymentOverview")
+    defer span.End()
+
     dataCurrentYear, err := uc.executiveRepo.FindKetenagakerjaanByTahun(ctx, tahun)
     if err != nil {
         if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -390,6 +402,9 @@ func (uc *ExecutiveUseCase) convertToTrendDataKetenagakerjaan(data []*entity.Ind
 }
 
 func (uc *ExecutiveUseCase) GetEducationOverview(ctx context.Context, tahun int) (*dto.EducationOverviewResponse, error) {
+    ctx, span := tracing.Start(ctx, "ExecutiveUseCase.GetEducationOverview")
+    defer span.End()
+
     dataCurrentYear, err := uc.executiveRepo.FindPendidikanByTahun(ctx, tahun)
     if err != nil {
         if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/domain/repository"
+	"building-report-backend/internal/infrastructure/health"
+	"building-report-backend/pkg/version"
+)
+
+type HealthUseCase struct {
+	checkers      []health.Checker
+	migrationRepo repository.MigrationRepository
+	timeout       time.Duration
+	log           *slog.Logger
+}
+
+func NewHealthUseCase(checkers []health.Checker, migrationRepo repository.MigrationRepository, timeout time.Duration, log *slog.Logger) *HealthUseCase {
+	return &HealthUseCase{
+		checkers:      checkers,
+		migrationRepo: migrationRepo,
+		timeout:       timeout,
+		log:           log,
+	}
+}
+
+// Readiness runs every check concurrently, each bounded by the check timeout.
+// Errors are logged; the response only says whether a dependency timed out or
+// failed, since it may be served to anyone.
+func (uc *HealthUseCase) Readiness(ctx context.Context) *dto.ReadinessResponse {
+	result := &dto.ReadinessResponse{
+		Status:       dto.HealthStatusOK,
+		Dependencies: make(map[string]*dto.DependencyStatus, len(uc.checkers)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, checker := range uc.checkers {
+		wg.Add(1)
+		go func(checker health.Checker) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, uc.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := checker.Check(checkCtx)
+			status := &dto.DependencyStatus{
+				Status:    dto.HealthStatusOK,
+				Critical:  checker.Critical,
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				status.Status = dto.HealthStatusDown
+				status.Error = "unavailable"
+				if errors.Is(err, context.DeadlineExceeded) || errors.Is(checkCtx.Err(), context.DeadlineExceeded) {
+					status.Error = "timeout"
+				}
+				uc.log.WarnContext(ctx, "dependency check failed",
+					slog.String("dependency", checker.Name),
+					slog.Float64("latency_ms", status.LatencyMs),
+					slog.String("error", err.Error()))
+			}
+
+			mu.Lock()
+			result.Dependencies[checker.Name] = status
+			mu.Unlock()
+		}(checker)
+	}
+	wg.Wait()
+
+	for _, status := range result.Dependencies {
+		if status.Status == dto.HealthStatusOK {
+			continue
+		}
+		if status.Critical {
+			result.Status = dto.HealthStatusDown
+			break
+		}
+		result.Status = dto.HealthStatusDegraded
+	}
+	return result
+}
+
+func (uc *HealthUseCase) BuildInfo(ctx context.Context) *dto.BuildInfoResponse {
+	result := &dto.BuildInfoResponse{Info: version.Get()}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	migrationVersion, err := uc.migrationRepo.CurrentVersion(ctx)
+	if err != nil {
+		uc.log.WarnContext(ctx, "reading migration version failed", slog.String("error", err.Error()))
+		return result
+	}
+	result.MigrationVersion = &migrationVersion
+	return result
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"building-report-backend/pkg/logger"
+)
+
+var ErrLoginLocked = errors.New("too many failed login attempts")
+
+// LoginLockedError is returned while an account or client address is locked out.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrLoginLocked, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return ErrLoginLocked
+}
+
+type LoginGuardConfig struct {
+	// MaxAttempts is the number of failures per account before it is locked.
+	MaxAttempts int
+	// IPMaxAttempts is the number of failures per client address before it is locked.
+	IPMaxAttempts int
+	// Window is how long failures are counted after the first one.
+	Window time.Duration
+	// BaseLockout is the first lockout; every further failure doubles it up to MaxLockout.
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+}
+
+// LoginGuard throttles login attempts per account and per client address.
+// Counters and locks live in the cache; lockouts are also recorded in the
+// database so admins can review and lift them.
+type LoginGuard struct {
+	cache       repository.CacheRepository
+	lockoutRepo repository.LoginLockoutRepository
+	cfg         LoginGuardConfig
+	log         *slog.Logger
+}
+
+func NewLoginGuard(cache repository.CacheRepository, lockoutRepo repository.LoginLockoutRepository, cfg LoginGuardConfig, log *slog.Logger) *LoginGuard {
+	return &LoginGuard{
+		cache:       cache,
+		lockoutRepo: lockoutRepo,
+		cfg:         cfg,
+		log:         log,
+	}
+}
+
+// loginSubject identifies who is trying to log in. Known users are keyed by ID so
+// that username and email share one counter.
+type loginSubject struct {
+	key        string
+	userID     string
+	identifier string
+	ip         string
+}
+
+func newLoginSubject(user *entity.User, identifier, ip string) loginSubject {
+	subject := loginSubject{
+		key:        "id:" + strings.ToLower(strings.TrimSpace(identifier)),
+		identifier: identifier,
+		ip:         ip,
+	}
+	if user != nil {
+		subject.key = "user:" + user.ID
+		subject.userID = user.ID
+	}
+	return subject
+}
+
+// Check returns a *LoginLockedError when the account or the address is locked.
+func (g *LoginGuard) Check(ctx context.Context, subject loginSubject) error {
+	now := time.Now()
+	longest := time.Duration(0)
+
+	for _, key := range []string{"login:lock:" + subject.key, "login:lock:ip:" + subject.ip} {
+		var until time.Time
+		if err := g.cache.Get(ctx, key, &until); err != nil {
+			if !errors.Is(err, repository.ErrCacheMiss) {
+				g.log.ErrorContext(ctx, "login guard: failed to read lock", slog.String("key", key), logger.Err(err))
+			}
+			continue
+		}
+		if wait := until.Sub(now); wait > longest {
+			longest = wait
+		}
+	}
+
+	if longest > 0 {
+		return &LoginLockedError{RetryAfter: longest}
+	}
+	return nil
+}
+
+// RecordFailure counts a failed attempt. It returns a *LoginLockedError when this
+// failure locked the account or the address.
+func (g *LoginGuard) RecordFailure(ctx context.Context, subject loginSubject) error {
+	var locked *LoginLockedError
+
+	if d := g.fail(ctx, subject, entity.LockoutScopeAccount, subject.key, g.cfg.MaxAttempts); d > 0 {
+		locked = &LoginLockedError{RetryAfter: d}
+	}
+	if subject.ip != "" {
+		if d := g.fail(ctx, subject, entity.LockoutScopeIP, "ip:"+subject.ip, g.cfg.IPMaxAttempts); d > 0 {
+			if locked == nil || d > locked.RetryAfter {
+				locked = &LoginLockedError{RetryAfter: d}
+			}
+		}
+	}
+
+	if locked != nil {
+		return locked
+	}
+	return nil
+}
+
+// RecordSuccess forgets the account's failures. Address counters are kept so a
+// single valid login cannot reset an ongoing spray across many accounts.
+func (g *LoginGuard) RecordSuccess(ctx context.Context, subject loginSubject) {
+	g.cache.Delete(ctx, "login:fail:"+subject.key, "login:lock:"+subject.key)
+}
+
+// Unlock lifts the lockout of a user account.
+func (g *LoginGuard) Unlock(ctx context.Context, userID, unlockedBy string) error {
+	key := "user:" + userID
+	g.cache.Delete(ctx, "login:fail:"+key, "login:lock:"+key)
+	return g.lockoutRepo.MarkUserUnlocked(ctx, userID, unlockedBy, time.Now())
+}
+
+// fail increments one counter and, once it reaches threshold, locks the key and
+// returns the lockout duration.
+func (g *LoginGuard) fail(ctx context.Context, subject loginSubject, scope entity.LockoutScope, key string, threshold int) time.Duration {
+	if threshold <= 0 {
+		return 0
+	}
+
+	// The counter must outlive the longest lockout or the backoff would reset.
+	window := g.cfg.Window
+	if window < g.cfg.MaxLockout {
+		window = g.cfg.MaxLockout
+	}
+
+	count, err := g.cache.Increment(ctx, "login:fail:"+key, window)
+	if err != nil {
+		g.log.ErrorContext(ctx, "login guard: failed to count attempt", slog.String("key", key), logger.Err(err))
+		return 0
+	}
+	if int(count) < threshold {
+		return 0
+	}
+
+	duration := g.lockoutDuration(int(count) - threshold)
+	until := time.Now().Add(duration)
+	g.cache.Set(ctx, "login:lock:"+key, until, duration)
+
+	lockout := &entity.LoginLockout{
+		Scope:          scope,
+		Identifier:     subject.identifier,
+		IPAddress:      subject.ip,
+		FailedAttempts: int(count),
+		LockedUntil:    until,
+	}
+	if scope == entity.LockoutScopeAccount {
+		lockout.UserID = subject.userID
+	}
+	lockout.BeforeCreate()
+	if err := g.lockoutRepo.Create(ctx, lockout); err != nil {
+		g.log.ErrorContext(ctx, "login guard: failed to record lockout", slog.String("key", key), logger.Err(err))
+	}
+
+	return duration
+}
+
+func (g *LoginGuard) lockoutDuration(excess int) time.Duration {
+	duration := g.cfg.BaseLockout
+	for i := 0; i < excess && duration < g.cfg.MaxLockout; i++ {
+		duration *= 2
+	}
+	if duration > g.cfg.MaxLockout {
+		duration = g.cfg.MaxLockout
+	}
+	return duration
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var reportsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "reports_created_total",
+	Help: "Reports created since the process started, by sector.",
+}, []string{"sector"})
+
+func init() {
+	metrics.Default.MustRegister(reportsCreated)
+}
+
+func countReportCreated(sector entity.Sector) {
+	reportsCreated.WithLabelValues(string(sector)).Inc()
+}
@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/domain/constants"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"building-report-backend/internal/infrastructure/oidc"
+	"building-report-backend/pkg/tracing"
+	"building-report-backend/pkg/utils"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrOIDCState        = errors.New("invalid or expired sso state")
+	ErrOIDCNonce        = errors.New("sso id token nonce mismatch")
+	ErrOIDCMissingEmail = errors.New("sso id token has no email claim")
+)
+
+const oidcStatePrefix = "oidc:state:"
+
+// OIDCRoleRule maps a value of the role claim to a role.
+type OIDCRoleRule struct {
+	Value string
+	Role  entity.UserRole
+}
+
+// ParseOIDCRoleMapping parses "value=ROLE,value=ROLE". Rules keep their order and
+// the first one matching the user's claim wins.
+func ParseOIDCRoleMapping(spec string) ([]OIDCRoleRule, error) {
+	var rules []OIDCRoleRule
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		value, role, ok := strings.Cut(pair, "=")
+		rule := OIDCRoleRule{Value: strings.TrimSpace(value), Role: entity.UserRole(strings.ToUpper(strings.TrimSpace(role)))}
+		if !ok || rule.Value == "" || !rule.Role.IsValid() {
+			return nil, fmt.Errorf("invalid role mapping %q", pair)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+type OIDCConfig struct {
+	// RoleClaim is the claim holding groups or roles; dots address nested claims
+	// such as realm_access.roles.
+	RoleClaim string
+	RoleRules []OIDCRoleRule
+	// DefaultRole is given to new users whose claim matches no rule.
+	DefaultRole   entity.UserRole
+	UsernameClaim string
+	// LinkByEmail lets a verified email sign in to an existing local account
+	// with the same address instead of failing with ErrUserExists.
+	LinkByEmail bool
+	StateTTL    time.Duration
+}
+
+type oidcLoginState struct {
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// OIDCUseCase signs users in through an external OpenID Connect provider and
+// issues the same tokens as a local login.
+type OIDCUseCase struct {
+	provider    oidc.Provider
+	userRepo    repository.UserRepository
+	cache       repository.CacheRepository
+	authUseCase *AuthUseCase
+	cfg         OIDCConfig
+	log         *slog.Logger
+}
+
+func NewOIDCUseCase(
+	provider oidc.Provider,
+	userRepo repository.UserRepository,
+	cache repository.CacheRepository,
+	authUseCase *AuthUseCase,
+	cfg OIDCConfig,
+	log *slog.Logger,
+) *OIDCUseCase {
+	return &OIDCUseCase{
+		provider:    provider,
+		userRepo:    userRepo,
+		cache:       cache,
+		authUseCase: authUseCase,
+		cfg:         cfg,
+		log:         log,
+	}
+}
+
+// BeginLogin creates the state, nonce and PKCE verifier of a login attempt and
+// returns the provider URL to send the browser to.
+func (uc *OIDCUseCase) BeginLogin(ctx context.Context) (*dto.OIDCLoginResponse, error) {
+	ctx, span := tracing.Start(ctx, "OIDCUseCase.BeginLogin")
+	defer span.End()
+
+	state, err := oidc.RandomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := oidc.RandomString()
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := uc.provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallenge(verifier))
+	if err != nil {
+		return nil, err
+	}
+
+	loginState := oidcLoginState{Nonce: nonce, CodeVerifier: verifier}
+	if err := uc.cache.Set(ctx, oidcStatePrefix+state, loginState, uc.cfg.StateTTL); err != nil {
+		return nil, err
+	}
+
+	return &dto.OIDCLoginResponse{
+		AuthorizationURL: authURL,
+		State:            state,
+	}, nil
+}
+
+// Callback completes a login started by BeginLogin, provisioning the user on
+// their first sign-in. A user with two-factor authentication enabled gets a
+// challenge to pass with VerifyTwoFactor, as with a password login.
+func (uc *OIDCUseCase) Callback(ctx context.Context, req *dto.OIDCCallbackRequest) (*dto.AuthResponse, *dto.TwoFactorChallengeResponse, error) {
+	ctx, span := tracing.Start(ctx, "OIDCUseCase.Callback")
+	defer span.End()
+
+	key := oidcStatePrefix + req.State
+	var loginState oidcLoginState
+	if err := uc.cache.Get(ctx, key, &loginState); err != nil || loginState.CodeVerifier == "" {
+		return nil, nil, ErrOIDCState
+	}
+	uc.cache.Delete(ctx, key)
+
+	token, err := uc.provider.Exchange(ctx, req.Code, loginState.CodeVerifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	if token.Nonce != loginState.Nonce {
+		return nil, nil, ErrOIDCNonce
+	}
+
+	user, err := uc.resolveUser(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !user.IsActive {
+		return nil, nil, ErrInactiveUser
+	}
+
+	if user.TwoFactorEnabled {
+		challenge, err := uc.authUseCase.twoFactorChallenge(ctx, user)
+		return nil, challenge, err
+	}
+
+	authResponse, err := uc.authUseCase.generateAuthResponse(ctx, user)
+	return authResponse, nil, err
+}
+
+// resolveUser finds the user linked to the token's subject, links or creates one
+// otherwise, and keeps the role in sync with the provider.
+func (uc *OIDCUseCase) resolveUser(ctx context.Context, token *oidc.IDToken) (*entity.User, error) {
+	mappedRole, mapped := uc.mapRole(token)
+
+	user, err := uc.userRepo.FindByExternalSubject(ctx, entity.AuthProviderOIDC, token.Subject)
+	if err == nil {
+		// A user whose claim no longer matches any rule keeps the role an admin gave them.
+		if mapped && user.Role != mappedRole {
+			user.Role = mappedRole
+			user.BeforeUpdate()
+			if err := uc.userRepo.Update(ctx, user); err != nil {
+				return nil, err
+			}
+			if err := uc.authUseCase.revokeSessions(ctx, user.ID); err != nil {
+				return nil, err
+			}
+		}
+		return user, nil
+	}
+
+	if token.Email == "" {
+		return nil, ErrOIDCMissingEmail
+	}
+
+	if existing, _ := uc.userRepo.FindByEmail(ctx, token.Email); existing != nil {
+		// Linking would let the provider's login stand in for the account's
+		// own second factor, so accounts with two-factor are never linked.
+		if !uc.cfg.LinkByEmail || !token.EmailVerified || existing.ExternalSubject != nil || existing.TwoFactorEnabled {
+			return nil, ErrUserExists
+		}
+		return uc.linkUser(ctx, existing, token, mappedRole, mapped)
+	}
+
+	role := uc.cfg.DefaultRole
+	if mapped {
+		role = mappedRole
+	}
+	return uc.provisionUser(ctx, token, role)
+}
+
+func (uc *OIDCUseCase) linkUser(ctx context.Context, user *entity.User, token *oidc.IDToken, role entity.UserRole, mapped bool) (*entity.User, error) {
+	subject := token.Subject
+	user.AuthProvider = entity.AuthProviderOIDC
+	user.ExternalSubject = &subject
+	if mapped {
+		user.Role = role
+	}
+	user.BeforeUpdate()
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	uc.cache.Delete(ctx, constants.UserCachePrefix+user.ID)
+
+	uc.log.InfoContext(ctx, "oidc: linked user to subject", slog.String("linked_user_id", user.ID), slog.String("subject", subject))
+	return user, nil
+}
+
+func (uc *OIDCUseCase) provisionUser(ctx context.Context, token *oidc.IDToken, role entity.UserRole) (*entity.User, error) {
+	username, err := uc.availableUsername(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// SSO users never sign in with a password; store one nobody knows.
+	secret, err := oidc.RandomString()
+	if err != nil {
+		return nil, err
+	}
+	password, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := token.Subject
+	now := time.Now()
+	user := &entity.User{
+		ID:              utils.GenerateULID(),
+		Username:        username,
+		Email:           token.Email,
+		Password:        string(password),
+		Role:            role,
+		IsActive:        true,
+		AuthProvider:    entity.AuthProviderOIDC,
+		ExternalSubject: &subject,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	uc.log.InfoContext(ctx, "oidc: provisioned user",
+		slog.String("provisioned_user_id", user.ID),
+		slog.String("username", user.Username),
+		slog.String("subject", subject),
+	)
+	return user, nil
+}
+
+var usernameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// availableUsername derives a valid, unused username from the username claim,
+// falling back to the local part of the email.
+func (uc *OIDCUseCase) availableUsername(ctx context.Context, token *oidc.IDToken) (string, error) {
+	base, _ := token.Claims[uc.cfg.UsernameClaim].(string)
+	if base == "" {
+		base, _, _ = strings.Cut(token.Email, "@")
+	}
+
+	base = strings.Trim(usernameInvalidChars.ReplaceAllString(base, "_"), "_")
+	if len(base) > 40 {
+		base = base[:40]
+	}
+	for len(base) < 3 {
+		base += "_"
+	}
+
+	candidate := base
+	for i := 2; i < 100; i++ {
+		if existing, _ := uc.userRepo.FindByUsername(ctx, candidate); existing == nil {
+			return candidate, nil
+		}
+		candidate = base + "_" + strconv.Itoa(i)
+	}
+	return "", ErrUserExists
+}
+
+// mapRole returns the role of the first rule matching a value of the role claim.
+func (uc *OIDCUseCase) mapRole(token *oidc.IDToken) (entity.UserRole, bool) {
+	if uc.cfg.RoleClaim == "" {
+		return "", false
+	}
+
+	values := claimValues(lookupClaim(token.Claims, uc.cfg.RoleClaim))
+	for _, rule := range uc.cfg.RoleRules {
+		for _, value := range values {
+			if value == rule.Value {
+				return rule.Role, true
+			}
+		}
+	}
+	return "", false
+}
+
+func lookupClaim(claims map[string]interface{}, path string) interface{} {
+	var current interface{} = claims
+	for _, part := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[part]
+	}
+	return current
+}
+
+func claimValues(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,332 @@
+package usecase
+
+import (
+	"context"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+)
+
+// photoReport is a report as photo management sees it, whatever its sector.
+// Photo URLs are object keys until the photos are linked.
+type photoReport struct {
+	ID        string
+	CreatedBy string
+	Photos    []dto.PhotoResponse
+}
+
+// photo returns the report's photo with the given ID.
+func (r *photoReport) photo(id string) (*dto.PhotoResponse, bool) {
+	for i := range r.Photos {
+		if r.Photos[i].ID == id {
+			return &r.Photos[i], true
+		}
+	}
+	return nil, false
+}
+
+// sectorPhotos adapts a sector's repository to photo management, converting
+// between its photo entity and dto.PhotoResponse.
+type sectorPhotos interface {
+	find(ctx context.Context, reportID string) (*photoReport, error)
+	add(ctx context.Context, reportID string, photos []dto.PhotoResponse, max int) error
+	update(ctx context.Context, photo *dto.PhotoResponse) error
+	delete(ctx context.Context, reportID, photoID string) error
+	reorder(ctx context.Context, reportID string, photoIDs []string) error
+}
+
+type reportPhotos struct {
+	repo repository.ReportRepository
+}
+
+func (s reportPhotos) find(ctx context.Context, reportID string) (*photoReport, error) {
+	report, err := s.repo.FindByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	found := &photoReport{ID: report.ID, CreatedBy: report.CreatedBy}
+	for _, p := range report.Photos {
+		found.Photos = append(found.Photos, dto.PhotoResponse{
+			ID:             p.ID,
+			ReportID:       p.ReportID,
+			PhotoURL:       p.PhotoURL,
+			Caption:        p.Caption,
+			Angle:          p.PhotoType,
+			CreatedAt:      p.CreatedAt,
+			PhotoPlacement: p.PhotoPlacement,
+			PhotoMeta:      p.PhotoMeta,
+		})
+	}
+	return found, nil
+}
+
+func (s reportPhotos) add(ctx context.Context, reportID string, photos []dto.PhotoResponse, max int) error {
+	rows := make([]entity.ReportPhoto, len(photos))
+	for i := range photos {
+		rows[i] = toReportPhoto(&photos[i])
+	}
+	return s.repo.AddPhotos(ctx, reportID, rows, max)
+}
+
+func (s reportPhotos) update(ctx context.Context, photo *dto.PhotoResponse) error {
+	row := toReportPhoto(photo)
+	return s.repo.UpdatePhoto(ctx, &row)
+}
+
+func (s reportPhotos) delete(ctx context.Context, reportID, photoID string) error {
+	return s.repo.DeletePhoto(ctx, reportID, photoID)
+}
+
+func (s reportPhotos) reorder(ctx context.Context, reportID string, photoIDs []string) error {
+	return s.repo.ReorderPhotos(ctx, reportID, photoIDs)
+}
+
+func toReportPhoto(p *dto.PhotoResponse) entity.ReportPhoto {
+	return entity.ReportPhoto{
+		ID:             p.ID,
+		ReportID:       p.ReportID,
+		PhotoURL:       p.PhotoURL,
+		Caption:        p.Caption,
+		PhotoType:      p.Angle,
+		CreatedAt:      p.CreatedAt,
+		PhotoPlacement: p.PhotoPlacement,
+		PhotoMeta:      p.PhotoMeta,
+	}
+}
+
+type spatialPhotos struct {
+	repo repository.SpatialPlanningRepository
+}
+
+func (s spatialPhotos) find(ctx context.Context, reportID string) (*photoReport, error) {
+	report, err := s.repo.FindByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	found := &photoReport{ID: report.ID, CreatedBy: report.CreatedBy}
+	for _, p := range report.Photos {
+		found.Photos = append(found.Photos, dto.PhotoResponse{
+			ID:             p.ID,
+			ReportID:       p.ReportID,
+			PhotoURL:       p.PhotoURL,
+			Caption:        p.Caption,
+			Angle:          p.PhotoAngle,
+			CreatedAt:      p.CreatedAt,
+			PhotoPlacement: p.PhotoPlacement,
+			PhotoMeta:      p.PhotoMeta,
+		})
+	}
+	return found, nil
+}
+
+func (s spatialPhotos) add(ctx context.Context, reportID string, photos []dto.PhotoResponse, max int) error {
+	rows := make([]entity.SpatialPlanningPhoto, len(photos))
+	for i := range photos {
+		rows[i] = toSpatialPlanningPhoto(&photos[i])
+	}
+	return s.repo.AddPhotos(ctx, reportID, rows, max)
+}
+
+func (s spatialPhotos) update(ctx context.Context, photo *dto.PhotoResponse) error {
+	row := toSpatialPlanningPhoto(photo)
+	return s.repo.UpdatePhoto(ctx, &row)
+}
+
+func (s spatialPhotos) delete(ctx context.Context, reportID, photoID string) error {
+	return s.repo.DeletePhoto(ctx, reportID, photoID)
+}
+
+func (s spatialPhotos) reorder(ctx context.Context, reportID string, photoIDs []string) error {
+	return s.repo.ReorderPhotos(ctx, reportID, photoIDs)
+}
+
+func toSpatialPlanningPhoto(p *dto.PhotoResponse) entity.SpatialPlanningPhoto {
+	return entity.SpatialPlanningPhoto{
+		ID:             p.ID,
+		ReportID:       p.ReportID,
+		PhotoURL:       p.PhotoURL,
+		Caption:        p.Caption,
+		PhotoAngle:     p.Angle,
+		CreatedAt:      p.CreatedAt,
+		PhotoPlacement: p.PhotoPlacement,
+		PhotoMeta:      p.PhotoMeta,
+	}
+}
+
+type waterPhotos struct {
+	repo repository.WaterResourcesRepository
+}
+
+func (s waterPhotos) find(ctx context.Context, reportID string) (*photoReport, error) {
+	report, err := s.repo.FindByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	found := &photoReport{ID: report.ID, CreatedBy: report.CreatedBy}
+	for _, p := range report.Photos {
+		found.Photos = append(found.Photos, dto.PhotoResponse{
+			ID:             p.ID,
+			ReportID:       p.ReportID,
+			PhotoURL:       p.PhotoURL,
+			Caption:        p.Caption,
+			Angle:          p.PhotoAngle,
+			CreatedAt:      p.CreatedAt,
+			PhotoPlacement: p.PhotoPlacement,
+			PhotoMeta:      p.PhotoMeta,
+		})
+	}
+	return found, nil
+}
+
+func (s waterPhotos) add(ctx context.Context, reportID string, photos []dto.PhotoResponse, max int) error {
+	rows := make([]entity.WaterResourcesPhoto, len(photos))
+	for i := range photos {
+		rows[i] = toWaterResourcesPhoto(&photos[i])
+	}
+	return s.repo.AddPhotos(ctx, reportID, rows, max)
+}
+
+func (s waterPhotos) update(ctx context.Context, photo *dto.PhotoResponse) error {
+	row := toWaterResourcesPhoto(photo)
+	return s.repo.UpdatePhoto(ctx, &row)
+}
+
+func (s waterPhotos) delete(ctx context.Context, reportID, photoID string) error {
+	return s.repo.DeletePhoto(ctx, reportID, photoID)
+}
+
+func (s waterPhotos) reorder(ctx context.Context, reportID string, photoIDs []string) error {
+	return s.repo.ReorderPhotos(ctx, reportID, photoIDs)
+}
+
+func toWaterResourcesPhoto(p *dto.PhotoResponse) entity.WaterResourcesPhoto {
+	return entity.WaterResourcesPhoto{
+		ID:             p.ID,
+		ReportID:       p.ReportID,
+		PhotoURL:       p.PhotoURL,
+		Caption:        p.Caption,
+		PhotoAngle:     p.Angle,
+		CreatedAt:      p.CreatedAt,
+		PhotoPlacement: p.PhotoPlacement,
+		PhotoMeta:      p.PhotoMeta,
+	}
+}
+
+type binaMargaPhotos struct {
+	repo repository.BinaMargaRepository
+}
+
+func (s binaMargaPhotos) find(ctx context.Context, reportID string) (*photoReport, error) {
+	report, err := s.repo.FindByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	found := &photoReport{ID: report.ID, CreatedBy: report.CreatedBy}
+	for _, p := range report.Photos {
+		found.Photos = append(found.Photos, dto.PhotoResponse{
+			ID:             p.ID,
+			ReportID:       p.ReportID,
+			PhotoURL:       p.PhotoURL,
+			Caption:        p.Caption,
+			Angle:          p.PhotoAngle,
+			CreatedAt:      p.CreatedAt,
+			PhotoPlacement: p.PhotoPlacement,
+			PhotoMeta:      p.PhotoMeta,
+		})
+	}
+	return found, nil
+}
+
+func (s binaMargaPhotos) add(ctx context.Context, reportID string, photos []dto.PhotoResponse, max int) error {
+	rows := make([]entity.BinaMargaPhoto, len(photos))
+	for i := range photos {
+		rows[i] = toBinaMargaPhoto(&photos[i])
+	}
+	return s.repo.AddPhotos(ctx, reportID, rows, max)
+}
+
+func (s binaMargaPhotos) update(ctx context.Context, photo *dto.PhotoResponse) error {
+	row := toBinaMargaPhoto(photo)
+	return s.repo.UpdatePhoto(ctx, &row)
+}
+
+func (s binaMargaPhotos) delete(ctx context.Context, reportID, photoID string) error {
+	return s.repo.DeletePhoto(ctx, reportID, photoID)
+}
+
+func (s binaMargaPhotos) reorder(ctx context.Context, reportID string, photoIDs []string) error {
+	return s.repo.ReorderPhotos(ctx, reportID, photoIDs)
+}
+
+func toBinaMargaPhoto(p *dto.PhotoResponse) entity.BinaMargaPhoto {
+	return entity.BinaMargaPhoto{
+		ID:             p.ID,
+		ReportID:       p.ReportID,
+		PhotoURL:       p.PhotoURL,
+		Caption:        p.Caption,
+		PhotoAngle:     p.Angle,
+		CreatedAt:      p.CreatedAt,
+		PhotoPlacement: p.PhotoPlacement,
+		PhotoMeta:      p.PhotoMeta,
+	}
+}
+
+type agriculturePhotos struct {
+	repo repository.AgricultureRepository
+}
+
+func (s agriculturePhotos) find(ctx context.Context, reportID string) (*photoReport, error) {
+	report, err := s.repo.FindByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	found := &photoReport{ID: report.ID, CreatedBy: report.CreatedBy}
+	for _, p := range report.Photos {
+		found.Photos = append(found.Photos, dto.PhotoResponse{
+			ID:             p.ID,
+			ReportID:       p.ReportID,
+			PhotoURL:       p.PhotoURL,
+			Caption:        p.Caption,
+			Angle:          p.PhotoType,
+			CreatedAt:      p.CreatedAt,
+			PhotoPlacement: p.PhotoPlacement,
+			PhotoMeta:      p.PhotoMeta,
+		})
+	}
+	return found, nil
+}
+
+func (s agriculturePhotos) add(ctx context.Context, reportID string, photos []dto.PhotoResponse, max int) error {
+	rows := make([]entity.AgriculturePhoto, len(photos))
+	for i := range photos {
+		rows[i] = toAgriculturePhoto(&photos[i])
+	}
+	return s.repo.AddPhotos(ctx, reportID, rows, max)
+}
+
+func (s agriculturePhotos) update(ctx context.Context, photo *dto.PhotoResponse) error {
+	row := toAgriculturePhoto(photo)
+	return s.repo.UpdatePhoto(ctx, &row)
+}
+
+func (s agriculturePhotos) delete(ctx context.Context, reportID, photoID string) error {
+	return s.repo.DeletePhoto(ctx, reportID, photoID)
+}
+
+func (s agriculturePhotos) reorder(ctx context.Context, reportID string, photoIDs []string) error {
+	return s.repo.ReorderPhotos(ctx, reportID, photoIDs)
+}
+
+func toAgriculturePhoto(p *dto.PhotoResponse) entity.AgriculturePhoto {
+	return entity.AgriculturePhoto{
+		ID:             p.ID,
+		ReportID:       p.ReportID,
+		PhotoURL:       p.PhotoURL,
+		Caption:        p.Caption,
+		PhotoType:      p.Angle,
+		CreatedAt:      p.CreatedAt,
+		PhotoPlacement: p.PhotoPlacement,
+		PhotoMeta:      p.PhotoMeta,
+	}
+}
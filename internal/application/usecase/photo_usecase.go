@@ -0,0 +1,419 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"slices"
+	"time"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/domain/constants"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"building-report-backend/internal/infrastructure/storage"
+	"building-report-backend/pkg/tracing"
+	"building-report-backend/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPhotoNotFound = errors.New("photo not found")
+	// ErrUnknownSector is returned for a sector no photo store is registered for.
+	ErrUnknownSector = errors.New("unknown report sector")
+	// ErrPhotoOrder is returned when a new photo order does not list every
+	// photo of the report exactly once.
+	ErrPhotoOrder = errors.New("photo order must list every photo of the report once")
+	// ErrRepairPhotosNotAllowed is returned when photos come with a status
+	// change that neither starts nor completes the work.
+	ErrRepairPhotosNotAllowed = errors.New("repair photos can only be attached when work starts or is completed")
+)
+
+// PhotoObject is a stored photo opened for streaming to the client. The caller
+// closes Body.
+type PhotoObject struct {
+	Body        io.ReadCloser
+	Size        int64
+	ContentType string
+}
+
+// PhotoUseCase manages the photos of existing reports in every sector and
+// serves them through the API, for deployments where clients cannot or should
+// not reach object storage directly.
+type PhotoUseCase struct {
+	sectors   map[entity.Sector]sectorPhotos
+	uploads   *UploadUseCase
+	work      *UnitOfWork
+	storage   storage.StorageService
+	links     *PhotoLinks
+	cache     repository.CacheRepository
+	auditRepo repository.AuditLogRepository
+	log       *slog.Logger
+}
+
+func NewPhotoUseCase(
+	reportRepo repository.ReportRepository,
+	spatialRepo repository.SpatialPlanningRepository,
+	waterRepo repository.WaterResourcesRepository,
+	binaMargaRepo repository.BinaMargaRepository,
+	agricultureRepo repository.AgricultureRepository,
+	uploads *UploadUseCase,
+	work *UnitOfWork,
+	storage storage.StorageService,
+	links *PhotoLinks,
+	cache repository.CacheRepository,
+	auditRepo repository.AuditLogRepository,
+	log *slog.Logger,
+) *PhotoUseCase {
+	return &PhotoUseCase{
+		sectors: map[entity.Sector]sectorPhotos{
+			entity.SectorReports:         reportPhotos{repo: reportRepo},
+			entity.SectorSpatialPlanning: spatialPhotos{repo: spatialRepo},
+			entity.SectorWaterResources:  waterPhotos{repo: waterRepo},
+			entity.SectorBinaMarga:       binaMargaPhotos{repo: binaMargaRepo},
+			entity.SectorAgriculture:     agriculturePhotos{repo: agricultureRepo},
+		},
+		uploads:   uploads,
+		work:      work,
+		storage:   storage,
+		links:     links,
+		cache:     cache,
+		auditRepo: auditRepo,
+		log:       log,
+	}
+}
+
+// ListPhotos returns the photos of a report in their order.
+func (uc *PhotoUseCase) ListPhotos(ctx context.Context, sector entity.Sector, reportID string) ([]dto.PhotoResponse, error) {
+	ctx, span := tracing.Start(ctx, "PhotoUseCase.ListPhotos")
+	defer span.End()
+
+	store, err := uc.sector(sector)
+	if err != nil {
+		return nil, err
+	}
+	report, err := store.find(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.links.linkPhotos(ctx, sector, report.Photos)
+	return report.Photos, nil
+}
+
+// AddPhotos adds the multipart files, or the photos of an upload session, to
+// an existing report, after its current photos.
+func (uc *PhotoUseCase) AddPhotos(ctx context.Context, sector entity.Sector, reportID string, req *dto.AddPhotosRequest, files []*multipart.FileHeader, userID string, role entity.UserRole) ([]dto.PhotoResponse, error) {
+	ctx, span := tracing.Start(ctx, "PhotoUseCase.AddPhotos")
+	defer span.End()
+
+	store, err := uc.sector(sector)
+	if err != nil {
+		return nil, err
+	}
+	report, err := store.find(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.CanManageReport(report.CreatedBy, userID, role) {
+		return nil, ErrUnauthorized
+	}
+
+	work := uc.work.Begin()
+	added, err := uc.add(ctx, work, sector, store, report, req.UploadSessionID, files, userID, dto.PhotoResponse{
+		Caption: req.Caption,
+		Angle:   req.Angle,
+		PhotoPlacement: entity.PhotoPlacement{
+			RepairPhase: entity.RepairPhase(req.RepairPhase),
+		},
+	})
+	if err != nil {
+		work.Rollback(ctx)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if err := work.Commit(ctx); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	recordAudit(ctx, uc.auditRepo, sector, reportID, entity.AuditActionUpdate, userID, nil, nil, fmt.Sprintf("added %d photos", len(added)))
+	invalidateReportCache(ctx, uc.cache, sector, reportID)
+
+	uc.links.linkPhotos(ctx, sector, added)
+	return added, nil
+}
+
+// UpdatePhoto edits the caption and angle of a photo.
+func (uc *PhotoUseCase) UpdatePhoto(ctx context.Context, sector entity.Sector, reportID, photoID string, req *dto.UpdatePhotoRequest, userID string, role entity.UserRole) (*dto.PhotoResponse, error) {
+	ctx, span := tracing.Start(ctx, "PhotoUseCase.UpdatePhoto")
+	defer span.End()
+
+	store, report, photo, err := uc.findManaged(ctx, sector, reportID, photoID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Caption != nil {
+		photo.Caption = *req.Caption
+	}
+	if req.Angle != nil {
+		photo.Angle = *req.Angle
+	}
+
+	if err := store.update(ctx, photo); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	recordAudit(ctx, uc.auditRepo, sector, report.ID, entity.AuditActionUpdate, userID, nil, nil, "edited photo "+photoID)
+	invalidateReportCache(ctx, uc.cache, sector, report.ID)
+
+	updated := []dto.PhotoResponse{*photo}
+	uc.links.linkPhotos(ctx, sector, updated)
+	return &updated[0], nil
+}
+
+// DeletePhoto removes a photo and its stored files. The files are only
+// removed once the row is gone: a file that cannot be removed is left over,
+// while a row whose files are gone would be a broken image.
+func (uc *PhotoUseCase) DeletePhoto(ctx context.Context, sector entity.Sector, reportID, photoID, userID string, role entity.UserRole) error {
+	ctx, span := tracing.Start(ctx, "PhotoUseCase.DeletePhoto")
+	defer span.End()
+
+	store, report, photo, err := uc.findManaged(ctx, sector, reportID, photoID, userID, role)
+	if err != nil {
+		return err
+	}
+
+	work := uc.work.Begin()
+	work.Do(func(ctx context.Context) error {
+		return store.delete(ctx, report.ID, photo.ID)
+	})
+	work.DeleteObjects(photo.PhotoURL, photo.MediumURL, photo.ThumbnailURL)
+	if err := work.Commit(ctx); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPhotoNotFound
+		}
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	recordAudit(ctx, uc.auditRepo, sector, report.ID, entity.AuditActionUpdate, userID, nil, nil, "deleted photo "+photoID)
+	invalidateReportCache(ctx, uc.cache, sector, report.ID)
+
+	return nil
+}
+
+// ReorderPhotos arranges the photos of a report in the order of
+// req.PhotoIDs, which must list each of them once.
+func (uc *PhotoUseCase) ReorderPhotos(ctx context.Context, sector entity.Sector, reportID string, req *dto.ReorderPhotosRequest, userID string, role entity.UserRole) ([]dto.PhotoResponse, error) {
+	ctx, span := tracing.Start(ctx, "PhotoUseCase.ReorderPhotos")
+	defer span.End()
+
+	store, err := uc.sector(sector)
+	if err != nil {
+		return nil, err
+	}
+	report, err := store.find(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.CanManageReport(report.CreatedBy, userID, role) {
+		return nil, ErrUnauthorized
+	}
+
+	ordered := make([]dto.PhotoResponse, 0, len(req.PhotoIDs))
+	for i, id := range req.PhotoIDs {
+		photo, ok := report.photo(id)
+		if !ok || slices.Contains(req.PhotoIDs[:i], id) {
+			return nil, ErrPhotoOrder
+		}
+		photo.Position = i
+		ordered = append(ordered, *photo)
+	}
+	if len(ordered) != len(report.Photos) {
+		return nil, ErrPhotoOrder
+	}
+
+	if err := store.reorder(ctx, report.ID, req.PhotoIDs); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// A photo was deleted in the meantime.
+			return nil, ErrPhotoOrder
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	recordAudit(ctx, uc.auditRepo, sector, report.ID, entity.AuditActionUpdate, userID, nil, nil, "reordered photos")
+	invalidateReportCache(ctx, uc.cache, sector, report.ID)
+
+	uc.links.linkPhotos(ctx, sector, ordered)
+	return ordered, nil
+}
+
+// attachRepairPhotos adds the photos of an upload session to a report as the
+// given repair phase of a change to status, as part of work. The sector use
+// case checks the change is allowed and adds the change itself to work.
+func (uc *PhotoUseCase) attachRepairPhotos(ctx context.Context, work *Work, sector entity.Sector, reportID, sessionID string, phase entity.RepairPhase, status, userID string) error {
+	store, err := uc.sector(sector)
+	if err != nil {
+		return err
+	}
+	report, err := store.find(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.add(ctx, work, sector, store, report, sessionID, nil, userID, dto.PhotoResponse{
+		PhotoPlacement: entity.PhotoPlacement{
+			RepairPhase:  phase,
+			LinkedStatus: status,
+		},
+	})
+	return err
+}
+
+// OpenPhoto opens a photo of a report, or one of its variants. The report is
+// loaded under the caller's context, so a photo is only served to someone
+// whose data scope includes its report, and never once the report is deleted.
+func (uc *PhotoUseCase) OpenPhoto(ctx context.Context, sector entity.Sector, reportID, photoID, variant string) (*PhotoObject, error) {
+	ctx, span := tracing.Start(ctx, "PhotoUseCase.OpenPhoto")
+	defer span.End()
+
+	store, err := uc.sector(sector)
+	if err != nil {
+		return nil, err
+	}
+	report, err := store.find(ctx, reportID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	photo, ok := report.photo(photoID)
+	if !ok {
+		return nil, ErrPhotoNotFound
+	}
+	key := variantKey(photo.PhotoURL, photo.PhotoMeta, variant)
+
+	info, err := uc.storage.StatObject(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, ErrPhotoNotFound
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	body, err := uc.storage.GetObject(ctx, key)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	return &PhotoObject{Body: body, Size: info.Size, ContentType: info.ContentType}, nil
+}
+
+func (uc *PhotoUseCase) sector(sector entity.Sector) (sectorPhotos, error) {
+	store, ok := uc.sectors[sector]
+	if !ok {
+		return nil, ErrUnknownSector
+	}
+	return store, nil
+}
+
+// findManaged loads a photo the user may change.
+func (uc *PhotoUseCase) findManaged(ctx context.Context, sector entity.Sector, reportID, photoID, userID string, role entity.UserRole) (sectorPhotos, *photoReport, *dto.PhotoResponse, error) {
+	store, err := uc.sector(sector)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	report, err := store.find(ctx, reportID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !entity.CanManageReport(report.CreatedBy, userID, role) {
+		return nil, nil, nil, ErrUnauthorized
+	}
+	photo, ok := report.photo(photoID)
+	if !ok {
+		return nil, nil, nil, ErrPhotoNotFound
+	}
+	return store, report, photo, nil
+}
+
+// add stores new photos for report, copying the caption, angle and placement
+// of template onto each, places them after the report's current photos and
+// adds saving them to work. The repository enforces MaxPhotosPerReport again
+// while inserting, as another request may have added photos since report was
+// loaded.
+func (uc *PhotoUseCase) add(ctx context.Context, work *Work, sector entity.Sector, store sectorPhotos, report *photoReport, sessionID string, files []*multipart.FileHeader, userID string, template dto.PhotoResponse) ([]dto.PhotoResponse, error) {
+	room := constants.MaxPhotosPerReport - len(report.Photos)
+	if room <= 0 || len(files) > room {
+		return nil, fmt.Errorf("%w: the report has %d of at most %d photos", ErrPhotoCount, len(report.Photos), constants.MaxPhotosPerReport)
+	}
+	if sessionID == "" && len(files) == 0 {
+		return nil, fmt.Errorf("%w: no photos were sent", ErrPhotoCount)
+	}
+
+	batch, err := uc.uploads.collectPhotos(ctx, work, photoSource{
+		Sector:    sector,
+		SessionID: sessionID,
+		Files:     files,
+		UserID:    userID,
+		ReportID:  report.ID,
+		Min:       1,
+		Max:       room,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	next := 0
+	for _, photo := range report.Photos {
+		next = max(next, photo.Position+1)
+	}
+	now := time.Now()
+
+	added := make([]dto.PhotoResponse, 0, len(batch.Photos))
+	for i, uploaded := range batch.Photos {
+		photo := template
+		photo.ID = utils.GenerateULID()
+		photo.ReportID = report.ID
+		photo.PhotoURL = uploaded.Key
+		photo.CreatedAt = now
+		photo.Position = next + i
+		photo.PhotoMeta = uploaded.PhotoMeta
+		added = append(added, photo)
+	}
+
+	work.Do(func(ctx context.Context) error {
+		err := store.add(ctx, report.ID, added, constants.MaxPhotosPerReport)
+		if errors.Is(err, repository.ErrPhotoLimit) {
+			return fmt.Errorf("%w: %v", ErrPhotoCount, err)
+		}
+		return err
+	})
+	return added, nil
+}
+
+// variantKey falls back to the original for photos stored before variants
+// were generated.
+func variantKey(photoURL string, meta entity.PhotoMeta, variant string) string {
+	switch {
+	case variant == PhotoVariantMedium && meta.MediumURL != "":
+		return meta.MediumURL
+	case variant == PhotoVariantThumbnail && meta.ThumbnailURL != "":
+		return meta.ThumbnailURL
+	}
+	return photoURL
+}
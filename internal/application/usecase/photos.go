@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"mime/multipart"
+	"time"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/infrastructure/imaging"
+	"building-report-backend/internal/infrastructure/storage"
+	"building-report-backend/pkg/logger"
+)
+
+// ErrInvalidPhoto is wrapped by errors for uploads that are not an allowed
+// image within the size limits. Handlers answer them with 400.
+var ErrInvalidPhoto = imaging.ErrInvalidImage
+
+// uploadPhoto runs one uploaded file through the photo pipeline, naming the
+// file in a rejection so the client knows which photo to replace.
+func uploadPhoto(ctx context.Context, photos storage.PhotoPipeline, file *multipart.FileHeader, folder string) (*storage.UploadedPhoto, error) {
+	photo, err := photos.UploadPhoto(ctx, file, folder)
+	if err != nil {
+		return nil, fmt.Errorf("photo %q: %w", file.Filename, err)
+	}
+	return photo, nil
+}
+
+// Photo URL modes. PhotoLinks either signs a short-lived MinIO URL for every
+// photo in a response or points the client at the API's photo endpoint, which
+// checks the caller's access on every request.
+const (
+	PhotoURLModeSigned = "signed"
+	PhotoURLModeProxy  = "proxy"
+)
+
+// Variants of a stored photo that can be requested from the photo endpoint.
+// The original is served when no variant is given.
+const (
+	PhotoVariantMedium    = "medium"
+	PhotoVariantThumbnail = "thumbnail"
+)
+
+// PhotoLinks turns the object keys stored in the photo tables into URLs the
+// client can load. It is applied to reports right before they are returned,
+// after anything that persists or caches them.
+type PhotoLinks struct {
+	storage storage.StorageService
+	mode    string
+	expiry  time.Duration
+	log     *slog.Logger
+}
+
+func NewPhotoLinks(storage storage.StorageService, mode string, expiry time.Duration, log *slog.Logger) *PhotoLinks {
+	return &PhotoLinks{
+		storage: storage,
+		mode:    mode,
+		expiry:  expiry,
+		log:     log,
+	}
+}
+
+// signed returns a PhotoLinks that signs URLs whatever the configured mode.
+func (l *PhotoLinks) signed() *PhotoLinks {
+	signed := *l
+	signed.mode = PhotoURLModeSigned
+	return &signed
+}
+
+// link replaces a photo's keys with URLs. Photos stored before variants were
+// generated get the original as their thumbnail, so list views always have a
+// thumbnail to show.
+func (l *PhotoLinks) link(ctx context.Context, sector entity.Sector, reportID, photoID string, photoURL *string, meta *entity.PhotoMeta) {
+	meta.FillThumbnail(*photoURL)
+
+	if l.mode == PhotoURLModeProxy {
+		base := fmt.Sprintf("/api/v1/%s/%s/photos/%s", sector, reportID, photoID)
+		*photoURL = base
+		if meta.MediumURL != "" {
+			meta.MediumURL = base + "?variant=" + PhotoVariantMedium
+		}
+		meta.ThumbnailURL = base + "?variant=" + PhotoVariantThumbnail
+		return
+	}
+
+	l.sign(ctx, photoURL)
+	l.sign(ctx, &meta.MediumURL)
+	l.sign(ctx, &meta.ThumbnailURL)
+}
+
+// sign replaces an object key with a presigned URL. A photo that cannot be
+// signed is left without a URL rather than failing the whole response.
+func (l *PhotoLinks) sign(ctx context.Context, key *string) {
+	if *key == "" {
+		return
+	}
+	url, err := l.storage.GetFileURL(ctx, *key, l.expiry)
+	if err != nil {
+		l.log.WarnContext(ctx, "failed to sign photo URL", slog.String("object", *key), logger.Err(err))
+		*key = ""
+		return
+	}
+	*key = url
+}
+
+// signUploadFiles gives the processed photos of an upload session signed URLs
+// whatever the mode: they belong to no report the photo endpoint could check
+// access against yet, and a client using upload sessions reaches MinIO anyway.
+func (l *PhotoLinks) signUploadFiles(ctx context.Context, files []entity.UploadSessionFile) {
+	for i := range files {
+		f := &files[i]
+		if f.PhotoURL == "" {
+			continue
+		}
+		f.FillThumbnail(f.PhotoURL)
+		l.sign(ctx, &f.PhotoURL)
+		l.sign(ctx, &f.MediumURL)
+		l.sign(ctx, &f.ThumbnailURL)
+	}
+}
+
+func (l *PhotoLinks) linkPhotos(ctx context.Context, sector entity.Sector, photos []dto.PhotoResponse) {
+	for i := range photos {
+		p := &photos[i]
+		l.link(ctx, sector, p.ReportID, p.ID, &p.PhotoURL, &p.PhotoMeta)
+	}
+}
+
+func (l *PhotoLinks) linkReports(ctx context.Context, reports ...*entity.Report) {
+	for _, r := range reports {
+		for i := range r.Photos {
+			p := &r.Photos[i]
+			l.link(ctx, entity.SectorReports, r.ID, p.ID, &p.PhotoURL, &p.PhotoMeta)
+		}
+	}
+}
+
+func (l *PhotoLinks) linkSpatialReports(ctx context.Context, reports ...*entity.SpatialPlanningReport) {
+	for _, r := range reports {
+		for i := range r.Photos {
+			p := &r.Photos[i]
+			l.link(ctx, entity.SectorSpatialPlanning, r.ID, p.ID, &p.PhotoURL, &p.PhotoMeta)
+		}
+	}
+}
+
+func (l *PhotoLinks) linkWaterReports(ctx context.Context, reports ...*entity.WaterResourcesReport) {
+	for _, r := range reports {
+		for i := range r.Photos {
+			p := &r.Photos[i]
+			l.link(ctx, entity.SectorWaterResources, r.ID, p.ID, &p.PhotoURL, &p.PhotoMeta)
+		}
+	}
+}
+
+func (l *PhotoLinks) linkBinaMargaReports(ctx context.Context, reports ...*entity.BinaMargaReport) {
+	for _, r := range reports {
+		for i := range r.Photos {
+			p := &r.Photos[i]
+			l.link(ctx, entity.SectorBinaMarga, r.ID, p.ID, &p.PhotoURL, &p.PhotoMeta)
+		}
+	}
+}
+
+func (l *PhotoLinks) linkAgricultureReports(ctx context.Context, reports ...*entity.AgricultureReport) {
+	for _, r := range reports {
+		for i := range r.Photos {
+			p := &r.Photos[i]
+			l.link(ctx, entity.SectorAgriculture, r.ID, p.ID, &p.PhotoURL, &p.PhotoMeta)
+		}
+	}
+}
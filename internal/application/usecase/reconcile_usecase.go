@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"building-report-backend/internal/domain/repository"
+	"building-report-backend/internal/infrastructure/storage"
+	"building-report-backend/pkg/logger"
+	"building-report-backend/pkg/tracing"
+)
+
+// ReconcileOptions controls a reconciliation run.
+type ReconcileOptions struct {
+	// MinAge spares unreferenced objects younger than this, as a report may
+	// be being created with them.
+	MinAge time.Duration
+	// DeleteOrphans removes the unreferenced objects found instead of only
+	// listing them.
+	DeleteOrphans bool
+}
+
+// MissingObject is a photo row whose object is not in storage.
+type MissingObject struct {
+	Table string
+	ID    string
+	Key   string
+}
+
+// ReconcileResult is what a reconciliation run found.
+type ReconcileResult struct {
+	Objects    int
+	References int
+	// OrphanObjects are objects no row refers to.
+	OrphanObjects  []string
+	DeletedObjects int
+	MissingObjects []MissingObject
+}
+
+// ReconcileUseCase compares the photo rows in the database with the objects
+// in storage. The unit of work keeps the two consistent while the API runs;
+// this finds what a crash or an outage left behind.
+type ReconcileUseCase struct {
+	photoRefRepo repository.PhotoReferenceRepository
+	storage      storage.StorageService
+	log          *slog.Logger
+}
+
+func NewReconcileUseCase(photoRefRepo repository.PhotoReferenceRepository, storage storage.StorageService, log *slog.Logger) *ReconcileUseCase {
+	return &ReconcileUseCase{
+		photoRefRepo: photoRefRepo,
+		storage:      storage,
+		log:          log,
+	}
+}
+
+// Reconcile lists the objects no row refers to, deleting them when asked, and
+// the rows whose objects are gone. Staging objects of upload sessions are left
+// to the session sweeper.
+func (uc *ReconcileUseCase) Reconcile(ctx context.Context, opts ReconcileOptions) (*ReconcileResult, error) {
+	ctx, span := tracing.Start(ctx, "ReconcileUseCase.Reconcile")
+	defer span.End()
+
+	// The rows are read before the objects are listed: an object stored in
+	// between is then unreferenced but too young to count as an orphan.
+	refs, err := uc.photoRefRepo.FindAll(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	result := &ReconcileResult{References: len(refs)}
+	referenced := make(map[string]bool)
+	for _, ref := range refs {
+		for _, key := range ref.Keys {
+			referenced[storage.ObjectKey(key)] = true
+		}
+	}
+
+	cutoff := time.Now().Add(-opts.MinAge)
+	stored := make(map[string]bool)
+	err = uc.storage.ListObjects(ctx, "", func(object storage.ObjectSummary) error {
+		if strings.HasPrefix(object.Name, stagingFolder+"/") {
+			return nil
+		}
+		result.Objects++
+		stored[object.Name] = true
+		if !referenced[object.Name] && object.LastModified.Before(cutoff) {
+			result.OrphanObjects = append(result.OrphanObjects, object.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	for _, ref := range refs {
+		for _, key := range ref.Keys {
+			if stored[storage.ObjectKey(key)] {
+				continue
+			}
+			// The row may be newer than the listing; only an object that is
+			// still missing counts.
+			_, err := uc.storage.StatObject(ctx, storage.ObjectKey(key))
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, storage.ErrObjectNotFound) {
+				tracing.RecordError(span, err)
+				return nil, err
+			}
+			result.MissingObjects = append(result.MissingObjects, MissingObject{Table: ref.Table, ID: ref.ID, Key: key})
+		}
+	}
+
+	if opts.DeleteOrphans {
+		for _, name := range result.OrphanObjects {
+			if err := uc.storage.DeleteObject(ctx, name); err != nil {
+				uc.log.WarnContext(ctx, "reconcile: failed to delete orphaned object",
+					slog.String("object", name),
+					logger.Err(err),
+				)
+				continue
+			}
+			result.DeletedObjects++
+		}
+	}
+
+	return result, nil
+}
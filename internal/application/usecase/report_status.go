@@ -0,0 +1,7 @@
+package usecase
+
+import "errors"
+
+// ErrInvalidStatusTransition is returned when a status change skips or reverses a step
+// of the report workflow defined in entity/status_workflow.go.
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
@@ -4,7 +4,7 @@ import (
 	"building-report-backend/internal/application/dto"
 	"building-report-backend/internal/domain/entity"
 	"building-report-backend/internal/domain/repository"
-	"building-report-backend/internal/infrastructure/storage"
+	"building-report-backend/pkg/tracing"
 	"building-report-backend/pkg/utils"
 	"context"
 	"fmt"
@@ -14,23 +14,35 @@ import (
 
 type ReportUseCase struct {
     reportRepo repository.ReportRepository
-    storage    storage.StorageService
+    uploads    *UploadUseCase
+    work       *UnitOfWork
+    links      *PhotoLinks
     cache      repository.CacheRepository
+    auditRepo  repository.AuditLogRepository
 }
 
 func NewReportUseCase(
     reportRepo repository.ReportRepository,
-    storage storage.StorageService,
+    uploads *UploadUseCase,
+    work *UnitOfWork,
+    links *PhotoLinks,
     cache repository.CacheRepository,
+    auditRepo repository.AuditLogRepository,
 ) *ReportUseCase {
     return &ReportUseCase{
         reportRepo: reportRepo,
-        storage:    storage,
+        uploads:    uploads,
+        work:       work,
+        links:      links,
         cache:      cache,
+        auditRepo:  auditRepo,
     }
 }
 
-func (uc *ReportUseCase) CreateReport(ctx context.Context, req *dto.CreateReportRequest, photos []*multipart.FileHeader) (*entity.Report, error) {
+func (uc *ReportUseCase) CreateReport(ctx context.Context, req *dto.CreateReportRequest, photos []*multipart.FileHeader, userID string) (*entity.Report, error) {
+    ctx, span := tracing.Start(ctx, "ReportUseCase.CreateReport")
+    defer span.End()
+
     report := &entity.Report{
         ID:                   utils.GenerateULID(),
         ReporterName:         req.ReporterName,
@@ -60,35 +72,62 @@ func (uc *ReportUseCase) CreateReport(ctx context.Context, req *dto.CreateReport
     }
 
     
-    for i, photo := range photos {
+    // Photos are required only for rehabilitation reports, not for new construction
+    minPhotos := 2
+    if req.ReportStatus == "PEMBANGUNAN_BARU" {
+        minPhotos = 0
+    }
+    work := uc.work.Begin()
+    batch, err := uc.uploads.collectPhotos(ctx, work, photoSource{
+        Sector:    entity.SectorReports,
+        SessionID: req.UploadSessionID,
+        Files:     photos,
+        UserID:    userID,
+        ReportID:  report.ID,
+        Min:       minPhotos,
+    })
+    if err != nil {
+        work.Rollback(ctx)
+        return nil, err
+    }
+
+    for i, uploaded := range batch.Photos {
         photoType := "overall"
         if i == 0 {
             photoType = "closeup"
         }
 
-        photoURL, err := uc.storage.UploadFile(ctx, photo, "reports")
-        if err != nil {
-            return nil, err
-        }
-
         report.Photos = append(report.Photos, entity.ReportPhoto{
-            ID:       utils.GenerateULID(),
-            PhotoURL:  photoURL,
-            PhotoType: photoType,
+            ID:             utils.GenerateULID(),
+            PhotoURL:       uploaded.Key,
+            PhotoType:      photoType,
+            PhotoPlacement: entity.PhotoPlacement{Position: i},
+            PhotoMeta:      uploaded.PhotoMeta,
         })
     }
 
-    if err := uc.reportRepo.Create(ctx, report); err != nil {
+    report.CreatedBy = userID
+
+    work.Do(func(ctx context.Context) error {
+        return uc.reportRepo.Create(ctx, report)
+    })
+    if err := work.Commit(ctx); err != nil {
         return nil, err
     }
 
+    recordAudit(ctx, uc.auditRepo, entity.SectorReports, report.ID, entity.AuditActionCreate, userID, nil, report, "")
+    countReportCreated(entity.SectorReports)
+
 
     uc.cache.Delete(ctx, "reports:list")
 
+    uc.links.linkReports(ctx, report)
     return report, nil
 }
 
 func (uc *ReportUseCase) GetReport(ctx context.Context, id string) (*entity.Report, error) {
+    ctx, span := tracing.Start(ctx, "ReportUseCase.GetReport")
+    defer span.End()
 
     cacheKey := "report:" + id
 
@@ -100,16 +139,21 @@ func (uc *ReportUseCase) GetReport(ctx context.Context, id string) (*entity.Repo
     
     uc.cache.Set(ctx, cacheKey, report, 3600) 
 
+    uc.links.linkReports(ctx, report)
     return report, nil
 }
 
 func (uc *ReportUseCase) ListReports(ctx context.Context, page, limit int, filters map[string]interface{}) (*dto.PaginatedReportsResponse, error) {
+    ctx, span := tracing.Start(ctx, "ReportUseCase.ListReports")
+    defer span.End()
+
     offset := (page - 1) * limit
     
     reports, total, err := uc.reportRepo.FindAll(ctx, limit, offset, filters)
     if err != nil {
         return nil, err
     }
+    uc.links.linkReports(ctx, reports...)
 
     return &dto.PaginatedReportsResponse{
         Reports:     reports,
@@ -120,16 +164,43 @@ func (uc *ReportUseCase) ListReports(ctx context.Context, page, limit int, filte
     }, nil
 }
 
-func (uc *ReportUseCase) UpdateReport(ctx context.Context, id string, req *dto.UpdateReportRequest, userID string) (*entity.Report, error) {
+// ListMyReports returns the reports created by userID, newest first.
+func (uc *ReportUseCase) ListMyReports(ctx context.Context, userID string, page, limit int) (*dto.PaginatedReportsResponse, error) {
+    ctx, span := tracing.Start(ctx, "ReportUseCase.ListMyReports")
+    defer span.End()
+
+    offset := (page - 1) * limit
+
+    reports, total, err := uc.reportRepo.FindByUserID(ctx, userID, limit, offset)
+    if err != nil {
+        return nil, err
+    }
+    uc.links.linkReports(ctx, reports...)
+
+    return &dto.PaginatedReportsResponse{
+        Reports:    reports,
+        Total:      total,
+        Page:       page,
+        PerPage:    limit,
+        TotalPages: (total + int64(limit) - 1) / int64(limit),
+    }, nil
+}
+
+func (uc *ReportUseCase) UpdateReport(ctx context.Context, id string, req *dto.UpdateReportRequest, userID string, role entity.UserRole) (*entity.Report, error) {
+    ctx, span := tracing.Start(ctx, "ReportUseCase.UpdateReport")
+    defer span.End()
+
     report, err := uc.reportRepo.FindByID(ctx, id)
     if err != nil {
         return nil, err
     }
 
     
-    // if report.CreatedBy != userID {
-    //     return nil, ErrUnauthorized
-    // }
+    if !entity.CanManageReport(report.CreatedBy, userID, role) {
+        return nil, ErrUnauthorized
+    }
+
+    before := *report
 
     
     if req.BuildingName != "" {
@@ -140,37 +211,42 @@ func (uc *ReportUseCase) UpdateReport(ctx context.Context, id string, req *dto.U
     }
     
 
+    report.UpdatedBy = userID
+
     if err := uc.reportRepo.Update(ctx, report); err != nil {
         return nil, err
     }
 
+    recordAudit(ctx, uc.auditRepo, entity.SectorReports, report.ID, entity.AuditActionUpdate, userID, &before, report, "")
+
     
     uc.cache.Delete(ctx, "report:"+id)
     uc.cache.Delete(ctx, "reports:list")
 
+    uc.links.linkReports(ctx, report)
     return report, nil
 }
 
-func (uc *ReportUseCase) DeleteReport(ctx context.Context, id string, userID string) error {
+func (uc *ReportUseCase) DeleteReport(ctx context.Context, id string, userID string, role entity.UserRole) error {
+    ctx, span := tracing.Start(ctx, "ReportUseCase.DeleteReport")
+    defer span.End()
+
     report, err := uc.reportRepo.FindByID(ctx, id)
     if err != nil {
         return err
     }
 
     
-    // if report.CreatedBy != userID {
-    //     return ErrUnauthorized
-    // }
-
-    
-    for _, photo := range report.Photos {
-        uc.storage.DeleteFile(ctx, photo.PhotoURL)
+    if !entity.CanManageReport(report.CreatedBy, userID, role) {
+        return ErrUnauthorized
     }
 
     if err := uc.reportRepo.Delete(ctx, id); err != nil {
         return err
     }
 
+    recordAudit(ctx, uc.auditRepo, entity.SectorReports, report.ID, entity.AuditActionDelete, userID, report, nil, "")
+
     
     uc.cache.Delete(ctx, "report:"+id)
     uc.cache.Delete(ctx, "reports:list")
@@ -179,8 +255,11 @@ func (uc *ReportUseCase) DeleteReport(ctx context.Context, id string, userID str
 }
 
 func (uc *ReportUseCase) GetTataBangunanOverview(ctx context.Context, buildingType string) (*dto.TataBangunanOverviewResponse, error) {
+    ctx, span := tracing.Start(ctx, "ReportUseCase.GetTataBangunanOverview")
+    defer span.End()
+
     // Cache key based on building type
-    cacheKey := fmt.Sprintf("tata_bangunan:overview:%s", buildingType)
+    cacheKey := scopedCacheKey(ctx, fmt.Sprintf("tata_bangunan:overview:%s", buildingType))
     var response dto.TataBangunanOverviewResponse
     
     err := uc.cache.Get(ctx, cacheKey, &response)
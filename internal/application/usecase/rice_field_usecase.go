@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"building-report-backend/pkg/tracing"
+)
+
+// riceFieldStatsVersionKey holds a counter that is part of every statistics
+// cache key. Bumping it on a write orphans all cached statistics at once; they
+// expire on their own.
+const riceFieldStatsVersionKey = "rice_field:stats_version"
+
+type RiceFieldUseCase struct {
+	riceFieldRepo repository.RiceFieldRepository
+	cache         repository.CacheRepository
+}
+
+func NewRiceFieldUseCase(
+	riceFieldRepo repository.RiceFieldRepository,
+	cache repository.CacheRepository,
+) *RiceFieldUseCase {
+	return &RiceFieldUseCase{
+		riceFieldRepo: riceFieldRepo,
+		cache:         cache,
+	}
+}
+
+func (uc *RiceFieldUseCase) CreateRiceField(ctx context.Context, req *dto.CreateRiceFieldRequest) (*dto.RiceFieldResponse, error) {
+	ctx, span := tracing.Start(ctx, "RiceFieldUseCase.CreateRiceField")
+	defer span.End()
+
+	riceField := &entity.RiceField{
+		District:            req.District,
+		Longitude:           req.Longitude,
+		Latitude:            req.Latitude,
+		Date:                req.Date,
+		RainfedRiceFields:   req.RainfedRiceFields,
+		IrrigatedRiceFields: req.IrrigatedRiceFields,
+	}
+
+	if err := uc.riceFieldRepo.Create(ctx, riceField); err != nil {
+		return nil, err
+	}
+
+	uc.invalidateStats(ctx)
+
+	return toRiceFieldResponse(riceField), nil
+}
+
+func (uc *RiceFieldUseCase) GetRiceField(ctx context.Context, id string) (*dto.RiceFieldResponse, error) {
+	ctx, span := tracing.Start(ctx, "RiceFieldUseCase.GetRiceField")
+	defer span.End()
+
+	cacheKey := "rice_field:" + id
+	var response dto.RiceFieldResponse
+
+	if err := uc.cache.Get(ctx, cacheKey, &response); err == nil {
+		return &response, nil
+	}
+
+	riceField, err := uc.riceFieldRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toRiceFieldResponse(riceField)
+	uc.cache.Set(ctx, cacheKey, result, time.Hour)
+
+	return result, nil
+}
+
+func (uc *RiceFieldUseCase) ListRiceFields(ctx context.Context, page, limit int, filters map[string]interface{}) (*dto.PaginatedRiceFieldResponse, error) {
+	ctx, span := tracing.Start(ctx, "RiceFieldUseCase.ListRiceFields")
+	defer span.End()
+
+	offset := (page - 1) * limit
+
+	riceFields, total, err := uc.riceFieldRepo.FindAll(ctx, limit, offset, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.RiceFieldResponse, len(riceFields))
+	for i, riceField := range riceFields {
+		responses[i] = toRiceFieldResponse(riceField)
+	}
+
+	return &dto.PaginatedRiceFieldResponse{
+		RiceFields: responses,
+		Total:      total,
+		Page:       page,
+		PerPage:    limit,
+		TotalPages: (total + int64(limit) - 1) / int64(limit),
+	}, nil
+}
+
+func (uc *RiceFieldUseCase) UpdateRiceField(ctx context.Context, id string, req *dto.UpdateRiceFieldRequest) (*dto.RiceFieldResponse, error) {
+	ctx, span := tracing.Start(ctx, "RiceFieldUseCase.UpdateRiceField")
+	defer span.End()
+
+	riceField, err := uc.riceFieldRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.District != nil {
+		riceField.District = *req.District
+	}
+	if req.Longitude != nil {
+		riceField.Longitude = *req.Longitude
+	}
+	if req.Latitude != nil {
+		riceField.Latitude = *req.Latitude
+	}
+	if req.Date != nil {
+		riceField.Date = *req.Date
+	}
+	if req.RainfedRiceFields != nil {
+		riceField.RainfedRiceFields = *req.RainfedRiceFields
+	}
+	if req.IrrigatedRiceFields != nil {
+		riceField.IrrigatedRiceFields = *req.IrrigatedRiceFields
+	}
+
+	if err := uc.riceFieldRepo.Update(ctx, riceField); err != nil {
+		return nil, err
+	}
+
+	uc.cache.Delete(ctx, "rice_field:"+id)
+	uc.invalidateStats(ctx)
+
+	return toRiceFieldResponse(riceField), nil
+}
+
+func (uc *RiceFieldUseCase) DeleteRiceField(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "RiceFieldUseCase.DeleteRiceField")
+	defer span.End()
+
+	if _, err := uc.riceFieldRepo.FindByID(ctx, id); err != nil {
+		return err
+	}
+
+	if err := uc.riceFieldRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	uc.cache.Delete(ctx, "rice_field:"+id)
+	uc.invalidateStats(ctx)
+
+	return nil
+}
+
+func (uc *RiceFieldUseCase) GetStatistics(ctx context.Context, startDate, endDate time.Time) (*dto.RiceFieldStatisticsResponse, error) {
+	ctx, span := tracing.Start(ctx, "RiceFieldUseCase.GetStatistics")
+	defer span.End()
+
+	cacheKey, cached := uc.statsCacheKey(ctx, fmt.Sprintf("stats:%s:%s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")))
+	var response dto.RiceFieldStatisticsResponse
+
+	if cached {
+		if err := uc.cache.Get(ctx, cacheKey, &response); err == nil {
+			return &response, nil
+		}
+	}
+
+	stats, err := uc.riceFieldRepo.GetRiceFieldStatistics(ctx, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rice field statistics: %w", err)
+	}
+
+	response = dto.RiceFieldStatisticsResponse{
+		StartDate:          startDate.Format("2006-01-02"),
+		EndDate:            endDate.Format("2006-01-02"),
+		TotalRainfedArea:   convertToFloat64(stats["total_rainfed_area"]),
+		TotalIrrigatedArea: convertToFloat64(stats["total_irrigated_area"]),
+		TotalRiceFieldArea: convertToFloat64(stats["total_rice_field_area"]),
+		TotalRecords:       convertToInt64(stats["total_records"]),
+		RainfedGrowth:      convertToFloat64(stats["rainfed_growth"]),
+		IrrigatedGrowth:    convertToFloat64(stats["irrigated_growth"]),
+		TotalGrowth:        convertToFloat64(stats["total_growth"]),
+	}
+
+	// Cache the response for 5 minutes
+	if cached {
+		uc.cache.Set(ctx, cacheKey, &response, 300*time.Second)
+	}
+
+	return &response, nil
+}
+
+func (uc *RiceFieldUseCase) GetDistributionByDistrict(ctx context.Context, startDate, endDate time.Time) ([]dto.RiceFieldStatsResponse, error) {
+	ctx, span := tracing.Start(ctx, "RiceFieldUseCase.GetDistributionByDistrict")
+	defer span.End()
+
+	cacheKey, cached := uc.statsCacheKey(ctx, fmt.Sprintf("distribution:%s:%s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")))
+	var response []dto.RiceFieldStatsResponse
+
+	if cached {
+		if err := uc.cache.Get(ctx, cacheKey, &response); err == nil {
+			return response, nil
+		}
+	}
+
+	rows, err := uc.riceFieldRepo.GetRiceFieldDistributionByDistrict(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	response = []dto.RiceFieldStatsResponse{}
+	for _, row := range rows {
+		count := convertToInt64(row["count"])
+		rainfed := convertToFloat64(row["total_rainfed_area"])
+		irrigated := convertToFloat64(row["total_irrigated_area"])
+
+		stat := dto.RiceFieldStatsResponse{
+			District:                 convertToString(row["district"]),
+			TotalRainfedArea:         rainfed,
+			TotalIrrigatedArea:       irrigated,
+			TotalRiceFieldArea:       convertToFloat64(row["total_area"]),
+			RainfedRiceFieldsCount:   convertToInt64(row["rainfed_count"]),
+			IrrigatedRiceFieldsCount: convertToInt64(row["irrigated_count"]),
+		}
+		if count > 0 {
+			stat.AverageRainfedArea = rainfed / float64(count)
+			stat.AverageIrrigatedArea = irrigated / float64(count)
+		}
+
+		response = append(response, stat)
+	}
+
+	if cached {
+		uc.cache.Set(ctx, cacheKey, response, 300*time.Second)
+	}
+
+	return response, nil
+}
+
+func (uc *RiceFieldUseCase) GetTrends(ctx context.Context, district string, years []int) ([]dto.RiceFieldTrendResponse, error) {
+	ctx, span := tracing.Start(ctx, "RiceFieldUseCase.GetTrends")
+	defer span.End()
+
+	rows, err := uc.riceFieldRepo.GetRiceFieldTrends(ctx, district, years)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.RiceFieldTrendResponse, 0, len(rows))
+	for _, row := range rows {
+		response = append(response, dto.RiceFieldTrendResponse{
+			Year:               int(convertToInt64(row["year"])),
+			TotalRainfedArea:   convertToFloat64(row["total_rainfed_area"]),
+			TotalIrrigatedArea: convertToFloat64(row["total_irrigated_area"]),
+			TotalArea:          convertToFloat64(row["total_area"]),
+			Count:              convertToInt64(row["count"]),
+		})
+	}
+
+	return response, nil
+}
+
+// statsCacheKey returns the cache key for a statistics result under the
+// current stats version. cached is false when the version cannot be read, in
+// which case the result must not be cached at all.
+func (uc *RiceFieldUseCase) statsCacheKey(ctx context.Context, key string) (cacheKey string, cached bool) {
+	var version int64
+	if err := uc.cache.Get(ctx, riceFieldStatsVersionKey, &version); err != nil && !errors.Is(err, repository.ErrCacheMiss) {
+		return "", false
+	}
+	return fmt.Sprintf("rice_field:v%d:%s", version, key), true
+}
+
+// invalidateStats makes every cached statistics result stale after a write.
+func (uc *RiceFieldUseCase) invalidateStats(ctx context.Context) {
+	uc.cache.Increment(ctx, riceFieldStatsVersionKey, 0)
+}
+
+func toRiceFieldResponse(riceField *entity.RiceField) *dto.RiceFieldResponse {
+	return &dto.RiceFieldResponse{
+		ID:                  riceField.ID,
+		District:            riceField.District,
+		Longitude:           riceField.Longitude,
+		Latitude:            riceField.Latitude,
+		Date:                riceField.Date,
+		RainfedRiceFields:   riceField.RainfedRiceFields,
+		IrrigatedRiceFields: riceField.IrrigatedRiceFields,
+		CreatedAt:           riceField.CreatedAt,
+		UpdatedAt:           riceField.UpdatedAt,
+	}
+}
@@ -9,29 +9,44 @@ import (
 	"building-report-backend/internal/application/dto"
 	"building-report-backend/internal/domain/entity"
 	"building-report-backend/internal/domain/repository"
-	"building-report-backend/internal/infrastructure/storage"
+	"building-report-backend/pkg/tracing"
 	"building-report-backend/pkg/utils"
 )
 
 type SpatialPlanningUseCase struct {
 	spatialRepo repository.SpatialPlanningRepository
-	storage     storage.StorageService
+	uploads     *UploadUseCase
+	work        *UnitOfWork
+	links       *PhotoLinks
+	photos      *PhotoUseCase
 	cache       repository.CacheRepository
+	auditRepo   repository.AuditLogRepository
 }
 
 func NewSpatialPlanningUseCase(
 	spatialRepo repository.SpatialPlanningRepository,
-	storage storage.StorageService,
+	uploads *UploadUseCase,
+	work *UnitOfWork,
+	links *PhotoLinks,
+	photos *PhotoUseCase,
 	cache repository.CacheRepository,
+	auditRepo repository.AuditLogRepository,
 ) *SpatialPlanningUseCase {
 	return &SpatialPlanningUseCase{
 		spatialRepo: spatialRepo,
-		storage:     storage,
+		uploads:     uploads,
+		work:        work,
+		links:       links,
+		photos:      photos,
 		cache:       cache,
+		auditRepo:   auditRepo,
 	}
 }
 
-func (uc *SpatialPlanningUseCase) CreateReport(ctx context.Context, req *dto.CreateSpatialPlanningRequest, photos []*multipart.FileHeader) (*entity.SpatialPlanningReport, error) {
+func (uc *SpatialPlanningUseCase) CreateReport(ctx context.Context, req *dto.CreateSpatialPlanningRequest, photos []*multipart.FileHeader, userID string) (*entity.SpatialPlanningReport, error) {
+	ctx, span := tracing.Start(ctx, "SpatialPlanningUseCase.CreateReport")
+	defer span.End()
+
 	report := &entity.SpatialPlanningReport{
 		ID:                  utils.GenerateULID(),
 		ReporterName:        req.ReporterName,
@@ -47,35 +62,59 @@ func (uc *SpatialPlanningUseCase) CreateReport(ctx context.Context, req *dto.Cre
 		Latitude:            req.Latitude,
 		Longitude:           req.Longitude,
 		Address:             req.Address,
+		District:            req.District,
 		Notes:               req.Notes,
 		Status:              entity.SpatialStatusPending,
 	}
 
-	for i, photo := range photos {
-		photoURL, err := uc.storage.UploadFile(ctx, photo, "spatial-planning")
-		if err != nil {
-			return nil, fmt.Errorf("failed to upload photo: %w", err)
-		}
+	work := uc.work.Begin()
+	batch, err := uc.uploads.collectPhotos(ctx, work, photoSource{
+		Sector:    entity.SectorSpatialPlanning,
+		SessionID: req.UploadSessionID,
+		Files:     photos,
+		UserID:    userID,
+		ReportID:  report.ID,
+		Min:       1,
+	})
+	if err != nil {
+		work.Rollback(ctx)
+		return nil, err
+	}
 
+	for i, uploaded := range batch.Photos {
 		caption := fmt.Sprintf("Photo %d", i+1)
 		report.Photos = append(report.Photos, entity.SpatialPlanningPhoto{
-			ID:       utils.GenerateULID(),
-			PhotoURL: photoURL,
-			Caption:  caption,
+			ID:             utils.GenerateULID(),
+			PhotoURL:       uploaded.Key,
+			Caption:        caption,
+			PhotoPlacement: entity.PhotoPlacement{Position: i},
+			PhotoMeta:      uploaded.PhotoMeta,
 		})
 	}
 
-	if err := uc.spatialRepo.Create(ctx, report); err != nil {
+	report.CreatedBy = userID
+
+	work.Do(func(ctx context.Context) error {
+		return uc.spatialRepo.Create(ctx, report)
+	})
+	if err := work.Commit(ctx); err != nil {
 		return nil, err
 	}
 
+	recordAudit(ctx, uc.auditRepo, entity.SectorSpatialPlanning, report.ID, entity.AuditActionCreate, userID, nil, report, "")
+	countReportCreated(entity.SectorSpatialPlanning)
+
 	uc.cache.Delete(ctx, "spatial:list")
 	uc.cache.Delete(ctx, "spatial:stats")
 
+	uc.links.linkSpatialReports(ctx, report)
 	return report, nil
 }
 
 func (uc *SpatialPlanningUseCase) GetReport(ctx context.Context, id string) (*entity.SpatialPlanningReport, error) {
+	ctx, span := tracing.Start(ctx, "SpatialPlanningUseCase.GetReport")
+	defer span.End()
+
 	cacheKey := "spatial:" + id
 
 	report, err := uc.spatialRepo.FindByID(ctx, id)
@@ -85,16 +124,64 @@ func (uc *SpatialPlanningUseCase) GetReport(ctx context.Context, id string) (*en
 
 	uc.cache.Set(ctx, cacheKey, report, 3600)
 
+	uc.links.linkSpatialReports(ctx, report)
 	return report, nil
 }
 
 func (uc *SpatialPlanningUseCase) ListReports(ctx context.Context, page, limit int, filters map[string]interface{}) (*dto.PaginatedSpatialReportsResponse, error) {
+	ctx, span := tracing.Start(ctx, "SpatialPlanningUseCase.ListReports")
+	defer span.End()
+
 	offset := (page - 1) * limit
 
 	reports, total, err := uc.spatialRepo.FindAll(ctx, limit, offset, filters)
 	if err != nil {
 		return nil, err
 	}
+	uc.links.linkSpatialReports(ctx, reports...)
+
+	return &dto.PaginatedSpatialReportsResponse{
+		Reports:    reports,
+		Total:      total,
+		Page:       page,
+		PerPage:    limit,
+		TotalPages: (total + int64(limit) - 1) / int64(limit),
+	}, nil
+}
+
+// ListMyReports returns the reports created by userID, newest first.
+func (uc *SpatialPlanningUseCase) ListMyReports(ctx context.Context, userID string, page, limit int) (*dto.PaginatedSpatialReportsResponse, error) {
+	ctx, span := tracing.Start(ctx, "SpatialPlanningUseCase.ListMyReports")
+	defer span.End()
+
+	offset := (page - 1) * limit
+
+	reports, total, err := uc.spatialRepo.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	uc.links.linkSpatialReports(ctx, reports...)
+
+	return &dto.PaginatedSpatialReportsResponse{
+		Reports:    reports,
+		Total:      total,
+		Page:       page,
+		PerPage:    limit,
+		TotalPages: (total + int64(limit) - 1) / int64(limit),
+	}, nil
+}
+
+func (uc *SpatialPlanningUseCase) ListByPriority(ctx context.Context, page, limit int) (*dto.PaginatedSpatialReportsResponse, error) {
+	ctx, span := tracing.Start(ctx, "SpatialPlanningUseCase.ListByPriority")
+	defer span.End()
+
+	offset := (page - 1) * limit
+
+	reports, total, err := uc.spatialRepo.FindByPriority(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	uc.links.linkSpatialReports(ctx, reports...)
 
 	return &dto.PaginatedSpatialReportsResponse{
 		Reports:    reports,
@@ -105,16 +192,20 @@ func (uc *SpatialPlanningUseCase) ListReports(ctx context.Context, page, limit i
 	}, nil
 }
 
-func (uc *SpatialPlanningUseCase) UpdateReport(ctx context.Context, id string, req *dto.UpdateSpatialPlanningRequest, userID string) (*entity.SpatialPlanningReport, error) {
+func (uc *SpatialPlanningUseCase) UpdateReport(ctx context.Context, id string, req *dto.UpdateSpatialPlanningRequest, userID string, role entity.UserRole) (*entity.SpatialPlanningReport, error) {
+	ctx, span := tracing.Start(ctx, "SpatialPlanningUseCase.UpdateReport")
+	defer span.End()
+
 	report, err := uc.spatialRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// if report.CreatedBy != userID {
+	if !entity.CanManageReport(report.CreatedBy, userID, role) {
+		return nil, ErrUnauthorized
+	}
 
-	//     return nil, ErrUnauthorized
-	// }
+	before := *report
 
 	if req.AreaDescription != "" {
 		report.AreaDescription = req.AreaDescription
@@ -146,61 +237,88 @@ func (uc *SpatialPlanningUseCase) UpdateReport(ctx context.Context, id string, r
 	if req.Notes != "" {
 		report.Notes = req.Notes
 	}
-	if req.Status != "" {
-		report.Status = entity.SpatialReportStatus(req.Status)
-	}
+
+	report.UpdatedBy = userID
 
 	if err := uc.spatialRepo.Update(ctx, report); err != nil {
 		return nil, err
 	}
 
+	recordAudit(ctx, uc.auditRepo, entity.SectorSpatialPlanning, report.ID, entity.AuditActionUpdate, userID, &before, report, "")
+
 	uc.cache.Delete(ctx, "spatial:"+id)
 	uc.cache.Delete(ctx, "spatial:list")
 
+	uc.links.linkSpatialReports(ctx, report)
 	return report, nil
 }
 
-func (uc *SpatialPlanningUseCase) UpdateStatus(ctx context.Context, id string, req *dto.UpdateSpatialStatusRequest) error {
+func (uc *SpatialPlanningUseCase) UpdateStatus(ctx context.Context, id string, req *dto.UpdateSpatialStatusRequest, userID string) error {
+	ctx, span := tracing.Start(ctx, "SpatialPlanningUseCase.UpdateStatus")
+	defer span.End()
+
 	report, err := uc.spatialRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	report.Status = entity.SpatialReportStatus(req.Status)
-	if req.Notes != "" {
-		report.Notes = req.Notes
+	next := entity.SpatialReportStatus(req.Status)
+	if !report.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, report.Status, next)
 	}
 
-	err = uc.spatialRepo.UpdateStatus(ctx, id, entity.SpatialReportStatus(req.Status))
-	if err != nil {
+	// Photos of the repair are saved with the change, so if it fails the
+	// upload session can be reused.
+	work := uc.work.Begin()
+	if req.UploadSessionID != "" {
+		phase, ok := next.RepairPhase()
+		if !ok {
+			return ErrRepairPhotosNotAllowed
+		}
+		if err := uc.photos.attachRepairPhotos(ctx, work, entity.SectorSpatialPlanning, id, req.UploadSessionID, phase, string(next), userID); err != nil {
+			work.Rollback(ctx)
+			return err
+		}
+	}
+
+	work.Do(func(ctx context.Context) error {
+		return uc.spatialRepo.UpdateStatus(ctx, id, report.Status, next, req.Notes, userID)
+	})
+	if err := work.Commit(ctx); err != nil {
 		return err
 	}
 
+	before := *report
+	report.Status = next
+	report.Notes = req.Notes
+	recordAudit(ctx, uc.auditRepo, entity.SectorSpatialPlanning, report.ID, entity.AuditActionStatusChange, userID, &before, report, req.Notes)
+
 	uc.cache.Delete(ctx, "spatial:"+id)
+	uc.cache.Delete(ctx, "spatial:list")
 	uc.cache.Delete(ctx, "spatial:stats")
 
 	return nil
 }
 
-func (uc *SpatialPlanningUseCase) DeleteReport(ctx context.Context, id string, userID string) error {
+func (uc *SpatialPlanningUseCase) DeleteReport(ctx context.Context, id string, userID string, role entity.UserRole) error {
+	ctx, span := tracing.Start(ctx, "SpatialPlanningUseCase.DeleteReport")
+	defer span.End()
+
 	report, err := uc.spatialRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	// if report.CreatedBy != userID {
-
-	// 	return ErrUnauthorized
-	// }
-
-	for _, photo := range report.Photos {
-		uc.storage.DeleteFile(ctx, photo.PhotoURL)
+	if !entity.CanManageReport(report.CreatedBy, userID, role) {
+		return ErrUnauthorized
 	}
 
 	if err := uc.spatialRepo.Delete(ctx, id); err != nil {
 		return err
 	}
 
+	recordAudit(ctx, uc.auditRepo, entity.SectorSpatialPlanning, report.ID, entity.AuditActionDelete, userID, report, nil, "")
+
 	uc.cache.Delete(ctx, "spatial:"+id)
 	uc.cache.Delete(ctx, "spatial:list")
 	uc.cache.Delete(ctx, "spatial:stats")
@@ -209,8 +327,10 @@ func (uc *SpatialPlanningUseCase) DeleteReport(ctx context.Context, id string, u
 }
 
 func (uc *SpatialPlanningUseCase) GetStatistics(ctx context.Context) (*dto.SpatialStatisticsResponse, error) {
+	ctx, span := tracing.Start(ctx, "SpatialPlanningUseCase.GetStatistics")
+	defer span.End()
 
-	cacheKey := "spatial:stats"
+	cacheKey := scopedCacheKey(ctx, "spatial:stats")
 	var stats dto.SpatialStatisticsResponse
 
 	err := uc.cache.Get(ctx, cacheKey, &stats)
@@ -250,8 +370,11 @@ func (uc *SpatialPlanningUseCase) GetStatistics(ctx context.Context) (*dto.Spati
 }
 
 func (uc *SpatialPlanningUseCase) GetTataRuangOverview(ctx context.Context, areaCategory string) (*dto.TataRuangOverviewResponse, error) {
+	ctx, span := tracing.Start(ctx, "SpatialPlanningUseCase.GetTataRuangOverview")
+	defer span.End()
+
 	// Cache key based on area category
-	cacheKey := fmt.Sprintf("tata_ruang:overview:%s", areaCategory)
+	cacheKey := scopedCacheKey(ctx, fmt.Sprintf("tata_ruang:overview:%s", areaCategory))
 	var response dto.TataRuangOverviewResponse
 
 	err := uc.cache.Get(ctx, cacheKey, &response)
@@ -0,0 +1,339 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"building-report-backend/pkg/logger"
+	"building-report-backend/pkg/tracing"
+)
+
+var ErrUnknownTrashSector = errors.New("unknown report sector")
+
+// purgeBatchSize bounds how many expired reports are loaded per query during a purge.
+const purgeBatchSize = 100
+
+// expiredReport is the part of a soft-deleted report the purge needs, whatever its sector.
+type expiredReport struct {
+	ID        string
+	PhotoKeys []string
+}
+
+// addPhoto queues a photo's original and its variants for deletion.
+func (r *expiredReport) addPhoto(photoURL string, meta entity.PhotoMeta) {
+	for _, key := range []string{photoURL, meta.MediumURL, meta.ThumbnailURL} {
+		if key != "" {
+			r.PhotoKeys = append(r.PhotoKeys, key)
+		}
+	}
+}
+
+type TrashUseCase struct {
+	reportRepo      repository.ReportRepository
+	spatialRepo     repository.SpatialPlanningRepository
+	waterRepo       repository.WaterResourcesRepository
+	binaMargaRepo   repository.BinaMargaRepository
+	agricultureRepo repository.AgricultureRepository
+	work            *UnitOfWork
+	links           *PhotoLinks
+	cache           repository.CacheRepository
+	auditRepo       repository.AuditLogRepository
+	log             *slog.Logger
+}
+
+func NewTrashUseCase(
+	reportRepo repository.ReportRepository,
+	spatialRepo repository.SpatialPlanningRepository,
+	waterRepo repository.WaterResourcesRepository,
+	binaMargaRepo repository.BinaMargaRepository,
+	agricultureRepo repository.AgricultureRepository,
+	work *UnitOfWork,
+	links *PhotoLinks,
+	cache repository.CacheRepository,
+	auditRepo repository.AuditLogRepository,
+	log *slog.Logger,
+) *TrashUseCase {
+	return &TrashUseCase{
+		reportRepo:      reportRepo,
+		spatialRepo:     spatialRepo,
+		waterRepo:       waterRepo,
+		binaMargaRepo:   binaMargaRepo,
+		agricultureRepo: agricultureRepo,
+		work:            work,
+		links:           links,
+		cache:           cache,
+		auditRepo:       auditRepo,
+		log:             log,
+	}
+}
+
+// TrashSectors lists the sectors whose reports are soft deleted, in purge order.
+var TrashSectors = []entity.Sector{
+	entity.SectorReports,
+	entity.SectorSpatialPlanning,
+	entity.SectorWaterResources,
+	entity.SectorBinaMarga,
+	entity.SectorAgriculture,
+}
+
+func (uc *TrashUseCase) ListTrash(ctx context.Context, sector entity.Sector, page, limit int) (*dto.PaginatedTrashResponse, error) {
+	ctx, span := tracing.Start(ctx, "TrashUseCase.ListTrash")
+	defer span.End()
+
+	offset := (page - 1) * limit
+
+	var reports interface{}
+	var total int64
+	var err error
+
+	// The photo endpoint does not serve reports in the trash, so their photos
+	// are always signed.
+	links := uc.links.signed()
+
+	switch sector {
+	case entity.SectorReports:
+		var found []*entity.Report
+		found, total, err = uc.reportRepo.FindDeleted(ctx, limit, offset)
+		links.linkReports(ctx, found...)
+		reports = found
+	case entity.SectorSpatialPlanning:
+		var found []*entity.SpatialPlanningReport
+		found, total, err = uc.spatialRepo.FindDeleted(ctx, limit, offset)
+		links.linkSpatialReports(ctx, found...)
+		reports = found
+	case entity.SectorWaterResources:
+		var found []*entity.WaterResourcesReport
+		found, total, err = uc.waterRepo.FindDeleted(ctx, limit, offset)
+		links.linkWaterReports(ctx, found...)
+		reports = found
+	case entity.SectorBinaMarga:
+		var found []*entity.BinaMargaReport
+		found, total, err = uc.binaMargaRepo.FindDeleted(ctx, limit, offset)
+		links.linkBinaMargaReports(ctx, found...)
+		reports = found
+	case entity.SectorAgriculture:
+		var found []*entity.AgricultureReport
+		found, total, err = uc.agricultureRepo.FindDeleted(ctx, limit, offset)
+		links.linkAgricultureReports(ctx, found...)
+		reports = found
+	default:
+		return nil, ErrUnknownTrashSector
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PaginatedTrashResponse{
+		Sector:     sector,
+		Reports:    reports,
+		Total:      total,
+		Page:       page,
+		PerPage:    limit,
+		TotalPages: (total + int64(limit) - 1) / int64(limit),
+	}, nil
+}
+
+// Restore brings a soft-deleted report back. It returns gorm.ErrRecordNotFound
+// when the report is not in the trash.
+func (uc *TrashUseCase) Restore(ctx context.Context, sector entity.Sector, id, actorID string) error {
+	ctx, span := tracing.Start(ctx, "TrashUseCase.Restore")
+	defer span.End()
+
+	var err error
+
+	switch sector {
+	case entity.SectorReports:
+		err = uc.reportRepo.Restore(ctx, id)
+	case entity.SectorSpatialPlanning:
+		err = uc.spatialRepo.Restore(ctx, id)
+	case entity.SectorWaterResources:
+		err = uc.waterRepo.Restore(ctx, id)
+	case entity.SectorBinaMarga:
+		err = uc.binaMargaRepo.Restore(ctx, id)
+	case entity.SectorAgriculture:
+		err = uc.agricultureRepo.Restore(ctx, id)
+	default:
+		return ErrUnknownTrashSector
+	}
+	if err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.auditRepo, sector, id, entity.AuditActionRestore, actorID, nil, nil, "")
+	invalidateReportCache(ctx, uc.cache, sector, id)
+
+	return nil
+}
+
+// PurgeExpired permanently removes reports that have been in the trash longer than
+// retention, together with their stored photos, and returns how many were removed.
+// A retention of zero or less purges nothing rather than the whole trash.
+func (uc *TrashUseCase) PurgeExpired(ctx context.Context, retention time.Duration) (int, error) {
+	ctx, span := tracing.Start(ctx, "TrashUseCase.PurgeExpired")
+	defer span.End()
+
+	if retention <= 0 {
+		return 0, nil
+	}
+
+	cutoff := time.Now().Add(-retention)
+	purged := 0
+
+	for _, sector := range TrashSectors {
+		for {
+			expired, err := uc.findExpired(ctx, sector, cutoff)
+			if err != nil {
+				return purged, err
+			}
+
+			removed := 0
+			for _, report := range expired {
+				if err := uc.purge(ctx, sector, report); err != nil {
+					uc.log.ErrorContext(ctx, "trash purge: failed to remove report",
+						slog.String("sector", string(sector)),
+						slog.String("report_id", report.ID),
+						logger.Err(err),
+					)
+					continue
+				}
+				removed++
+			}
+			purged += removed
+
+			// A short batch means the sector is drained; a batch where nothing could
+			// be removed would otherwise be fetched again forever.
+			if len(expired) < purgeBatchSize || removed == 0 {
+				break
+			}
+		}
+	}
+
+	return purged, nil
+}
+
+// purge removes the report for good. Its photos are only deleted once the
+// row is gone, so a failed purge leaves the report in the trash intact.
+func (uc *TrashUseCase) purge(ctx context.Context, sector entity.Sector, report expiredReport) error {
+	var hardDelete func(ctx context.Context, id string) error
+
+	switch sector {
+	case entity.SectorReports:
+		hardDelete = uc.reportRepo.HardDelete
+	case entity.SectorSpatialPlanning:
+		hardDelete = uc.spatialRepo.HardDelete
+	case entity.SectorWaterResources:
+		hardDelete = uc.waterRepo.HardDelete
+	case entity.SectorBinaMarga:
+		hardDelete = uc.binaMargaRepo.HardDelete
+	case entity.SectorAgriculture:
+		hardDelete = uc.agricultureRepo.HardDelete
+	default:
+		return ErrUnknownTrashSector
+	}
+
+	work := uc.work.Begin()
+	work.Do(func(ctx context.Context) error {
+		return hardDelete(ctx, report.ID)
+	})
+	work.DeleteObjects(report.PhotoKeys...)
+	if err := work.Commit(ctx); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.auditRepo, sector, report.ID, entity.AuditActionPurge, "", nil, nil, "")
+
+	return nil
+}
+
+func (uc *TrashUseCase) findExpired(ctx context.Context, sector entity.Sector, cutoff time.Time) ([]expiredReport, error) {
+	var expired []expiredReport
+
+	switch sector {
+	case entity.SectorReports:
+		reports, err := uc.reportRepo.FindDeletedBefore(ctx, cutoff, purgeBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range reports {
+			item := expiredReport{ID: r.ID}
+			for _, p := range r.Photos {
+				item.addPhoto(p.PhotoURL, p.PhotoMeta)
+			}
+			expired = append(expired, item)
+		}
+	case entity.SectorSpatialPlanning:
+		reports, err := uc.spatialRepo.FindDeletedBefore(ctx, cutoff, purgeBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range reports {
+			item := expiredReport{ID: r.ID}
+			for _, p := range r.Photos {
+				item.addPhoto(p.PhotoURL, p.PhotoMeta)
+			}
+			expired = append(expired, item)
+		}
+	case entity.SectorWaterResources:
+		reports, err := uc.waterRepo.FindDeletedBefore(ctx, cutoff, purgeBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range reports {
+			item := expiredReport{ID: r.ID}
+			for _, p := range r.Photos {
+				item.addPhoto(p.PhotoURL, p.PhotoMeta)
+			}
+			expired = append(expired, item)
+		}
+	case entity.SectorBinaMarga:
+		reports, err := uc.binaMargaRepo.FindDeletedBefore(ctx, cutoff, purgeBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range reports {
+			item := expiredReport{ID: r.ID}
+			for _, p := range r.Photos {
+				item.addPhoto(p.PhotoURL, p.PhotoMeta)
+			}
+			expired = append(expired, item)
+		}
+	case entity.SectorAgriculture:
+		reports, err := uc.agricultureRepo.FindDeletedBefore(ctx, cutoff, purgeBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range reports {
+			item := expiredReport{ID: r.ID}
+			for _, p := range r.Photos {
+				item.addPhoto(p.PhotoURL, p.PhotoMeta)
+			}
+			expired = append(expired, item)
+		}
+	default:
+		return nil, ErrUnknownTrashSector
+	}
+
+	return expired, nil
+}
+
+// invalidateReportCache drops the same cache keys the sector's use case clears
+// when one of its reports changes.
+func invalidateReportCache(ctx context.Context, cache repository.CacheRepository, sector entity.Sector, id string) {
+	switch sector {
+	case entity.SectorReports:
+		cache.Delete(ctx, "report:"+id, "reports:list")
+	case entity.SectorSpatialPlanning:
+		cache.Delete(ctx, "spatial:"+id, "spatial:list", "spatial:stats")
+	case entity.SectorWaterResources:
+		cache.Delete(ctx, "water:"+id, "water:list", "water:stats")
+	case entity.SectorBinaMarga:
+		cache.Delete(ctx, "bina_marga:"+id, "bina_marga:list", "bina_marga:stats")
+	case entity.SectorAgriculture:
+		cache.Delete(ctx, "agriculture:"+id, "agriculture:list", "agriculture:stats")
+	}
+}
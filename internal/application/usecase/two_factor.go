@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"building-report-backend/internal/infrastructure/auth"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorSetupExpired   = errors.New("two-factor setup expired, start again")
+	ErrTwoFactorRequired       = errors.New("two-factor authentication is required for this role")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrInvalidChallenge        = errors.New("invalid or expired two-factor challenge")
+)
+
+const (
+	twoFactorSetupPrefix     = "auth:2fa_setup:"
+	twoFactorChallengePrefix = "auth:2fa_challenge:"
+	twoFactorAttemptsPrefix  = "auth:2fa_attempts:"
+	twoFactorLastStepPrefix  = "auth:2fa_last_step:"
+	twoFactorUsedStepPrefix  = "auth:2fa_used_step:"
+
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+type TwoFactorConfig struct {
+	// Issuer is the account label shown in authenticator apps.
+	Issuer string
+	// RequiredRoles must have two-factor authentication enabled to use the API.
+	RequiredRoles []entity.UserRole
+	// ExemptSSO spares SSO users from enrolling, leaving them to the identity
+	// provider's MFA.
+	ExemptSSO    bool
+	ChallengeTTL time.Duration
+	// MaxChallengeAttempts is how many wrong codes one challenge accepts.
+	MaxChallengeAttempts int
+	RecoveryCodeCount    int
+	SetupTTL             time.Duration
+}
+
+// twoFactorChallenge is what the server remembers between the password step and
+// the code step of a login.
+type twoFactorChallenge struct {
+	UserID string `json:"user_id"`
+}
+
+// TwoFactorManager holds the TOTP secrets, recovery codes and login challenges
+// used by AuthUseCase.
+type TwoFactorManager struct {
+	cache        repository.CacheRepository
+	recoveryRepo repository.RecoveryCodeRepository
+	secretBox    auth.SecretBox
+	cfg          TwoFactorConfig
+}
+
+func NewTwoFactorManager(cache repository.CacheRepository, recoveryRepo repository.RecoveryCodeRepository, secretBox auth.SecretBox, cfg TwoFactorConfig) *TwoFactorManager {
+	return &TwoFactorManager{
+		cache:        cache,
+		recoveryRepo: recoveryRepo,
+		secretBox:    secretBox,
+		cfg:          cfg,
+	}
+}
+
+// Required reports whether the policy makes two-factor authentication mandatory for role.
+func (m *TwoFactorManager) Required(role entity.UserRole) bool {
+	for _, required := range m.cfg.RequiredRoles {
+		if required == role {
+			return true
+		}
+	}
+	return false
+}
+
+// MustEnroll reports whether user may only enroll until two-factor is set up.
+// SSO users are only spared when ExemptSSO is set; one who has enrolled is
+// challenged like anyone else.
+func (m *TwoFactorManager) MustEnroll(user *entity.User) bool {
+	if user.TwoFactorEnabled || !m.Required(user.Role) {
+		return false
+	}
+	return !(m.cfg.ExemptSSO && user.AuthProvider == entity.AuthProviderOIDC)
+}
+
+// BeginSetup creates a secret for user and keeps it pending until ConfirmSetup.
+func (m *TwoFactorManager) BeginSetup(ctx context.Context, user *entity.User) (secret, uri string, err error) {
+	secret, err = auth.GenerateTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+	if err := m.cache.Set(ctx, twoFactorSetupPrefix+user.ID, secret, m.cfg.SetupTTL); err != nil {
+		return "", "", err
+	}
+	return secret, auth.TOTPURI(m.cfg.Issuer, user.Username, secret), nil
+}
+
+// ConfirmSetup checks code against the pending secret and returns the secret
+// sealed for storage on the user.
+func (m *TwoFactorManager) ConfirmSetup(ctx context.Context, userID, code string) (string, error) {
+	var secret string
+	if err := m.cache.Get(ctx, twoFactorSetupPrefix+userID, &secret); err != nil || secret == "" {
+		return "", ErrTwoFactorSetupExpired
+	}
+
+	if !m.acceptCode(ctx, userID, secret, code) {
+		return "", ErrInvalidTwoFactorCode
+	}
+	m.cache.Delete(ctx, twoFactorSetupPrefix+userID)
+
+	return m.secretBox.Seal(secret)
+}
+
+// VerifyCode accepts a current TOTP code of user. Each time step is accepted
+// once, so an observed code cannot be replayed.
+func (m *TwoFactorManager) VerifyCode(ctx context.Context, user *entity.User, code string) (bool, error) {
+	if !user.TwoFactorEnabled || user.TwoFactorSecret == "" {
+		return false, ErrTwoFactorNotEnabled
+	}
+	secret, err := m.secretBox.Open(user.TwoFactorSecret)
+	if err != nil {
+		return false, err
+	}
+	return m.acceptCode(ctx, user.ID, secret, code), nil
+}
+
+func (m *TwoFactorManager) acceptCode(ctx context.Context, userID, secret, code string) bool {
+	step, ok := auth.ValidateTOTP(secret, code, time.Now())
+	if !ok {
+		return false
+	}
+
+	key := twoFactorLastStepPrefix + userID
+	var lastStep int64
+	if err := m.cache.Get(ctx, key, &lastStep); err == nil && step <= lastStep {
+		return false
+	}
+	// Claiming the step is atomic, so of two requests with the same code only
+	// one gets through. A cache failure rejects the code.
+	claimed, err := m.cache.SetNX(ctx, twoFactorUsedStepPrefix+userID+":"+strconv.FormatInt(step, 10), true, 3*time.Minute)
+	if err != nil || !claimed {
+		return false
+	}
+	m.cache.Set(ctx, key, step, 3*time.Minute)
+	return true
+}
+
+// UseRecoveryCode consumes one of user's unused recovery codes.
+func (m *TwoFactorManager) UseRecoveryCode(ctx context.Context, userID, code string) (bool, error) {
+	return m.recoveryRepo.Consume(ctx, userID, hashSecret(code), time.Now())
+}
+
+// GenerateRecoveryCodes replaces the user's recovery codes with new ones and
+// returns them in plain text; they cannot be shown again.
+func (m *TwoFactorManager) GenerateRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	plain := make([]string, 0, m.cfg.RecoveryCodeCount)
+	codes := make([]*entity.RecoveryCode, 0, m.cfg.RecoveryCodeCount)
+
+	for i := 0; i < m.cfg.RecoveryCodeCount; i++ {
+		code, err := randomRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		recoveryCode := &entity.RecoveryCode{UserID: userID, CodeHash: hashSecret(code)}
+		recoveryCode.BeforeCreate()
+
+		plain = append(plain, code)
+		codes = append(codes, recoveryCode)
+	}
+
+	if err := m.recoveryRepo.ReplaceForUser(ctx, userID, codes); err != nil {
+		return nil, err
+	}
+	return plain, nil
+}
+
+func (m *TwoFactorManager) RemainingRecoveryCodes(ctx context.Context, userID string) (int64, error) {
+	return m.recoveryRepo.CountUnused(ctx, userID)
+}
+
+// Reset forgets everything two-factor related about a user. The caller clears
+// the fields on the user itself.
+func (m *TwoFactorManager) Reset(ctx context.Context, userID string) error {
+	m.cache.Delete(ctx, twoFactorSetupPrefix+userID)
+	return m.recoveryRepo.DeleteForUser(ctx, userID)
+}
+
+// IssueChallenge returns a token proving userID passed the password step.
+func (m *TwoFactorManager) IssueChallenge(ctx context.Context, userID string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	challenge := twoFactorChallenge{UserID: userID}
+	if err := m.cache.Set(ctx, twoFactorChallengePrefix+hashSecret(token), challenge, m.cfg.ChallengeTTL); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Challenge returns the user a challenge token was issued for.
+func (m *TwoFactorManager) Challenge(ctx context.Context, token string) (string, error) {
+	var challenge twoFactorChallenge
+	if err := m.cache.Get(ctx, twoFactorChallengePrefix+hashSecret(token), &challenge); err != nil || challenge.UserID == "" {
+		return "", ErrInvalidChallenge
+	}
+	return challenge.UserID, nil
+}
+
+// FailChallenge counts a wrong code and drops the challenge once it has had
+// MaxChallengeAttempts, forcing the password step again.
+func (m *TwoFactorManager) FailChallenge(ctx context.Context, token string) {
+	hash := hashSecret(token)
+	count, err := m.cache.Increment(ctx, twoFactorAttemptsPrefix+hash, m.cfg.ChallengeTTL)
+	if err != nil || int(count) >= m.cfg.MaxChallengeAttempts {
+		m.CloseChallenge(ctx, token)
+	}
+}
+
+// ConsumeChallenge ends a challenge whose code was accepted. The challenge is
+// taken in one atomic step, so it completes a single login even when several
+// requests pass the code check at once.
+func (m *TwoFactorManager) ConsumeChallenge(ctx context.Context, token string) (string, error) {
+	hash := hashSecret(token)
+	var challenge twoFactorChallenge
+	if err := m.cache.GetDel(ctx, twoFactorChallengePrefix+hash, &challenge); err != nil || challenge.UserID == "" {
+		return "", ErrInvalidChallenge
+	}
+	m.cache.Delete(ctx, twoFactorAttemptsPrefix+hash)
+	return challenge.UserID, nil
+}
+
+func (m *TwoFactorManager) CloseChallenge(ctx context.Context, token string) {
+	hash := hashSecret(token)
+	m.cache.Delete(ctx, twoFactorChallengePrefix+hash, twoFactorAttemptsPrefix+hash)
+}
+
+func (m *TwoFactorManager) ChallengeTTL() time.Duration {
+	return m.cfg.ChallengeTTL
+}
+
+// randomRecoveryCode returns a code like "k7pm-x2qa-9dfe" using characters that
+// are hard to confuse when read aloud or typed.
+func randomRecoveryCode() (string, error) {
+	// rand.Int draws uniformly, where a byte modulo the alphabet size would
+	// favour the first characters.
+	size := big.NewInt(int64(len(recoveryCodeAlphabet)))
+
+	var b strings.Builder
+	for i := 0; i < 12; i++ {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(recoveryCodeAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+// hashSecret normalises and hashes a recovery code or challenge token for storage.
+func hashSecret(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
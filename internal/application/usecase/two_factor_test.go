@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"building-report-backend/internal/domain/repository/repositorytest"
+	"building-report-backend/internal/infrastructure/auth"
+)
+
+// testTOTPCode computes the RFC 6238 code of secret for the 30 second step.
+func testTOTPCode(t *testing.T, secret string, step int64) string {
+	t.Helper()
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+// currentTOTPStep returns the current step, first waiting out the end of a
+// step that is about to roll over so the test does not straddle two steps.
+func currentTOTPStep() int64 {
+	now := time.Now()
+	if now.Unix()%30 >= 28 {
+		time.Sleep(time.Duration(30-now.Unix()%30) * time.Second)
+	}
+	return time.Now().Unix() / 30
+}
+
+func TestAcceptCode(t *testing.T) {
+	type attempt struct {
+		// offset is the step of the code relative to the current one.
+		offset int64
+		// code replaces the generated code when set.
+		code string
+		want bool
+	}
+	tests := []struct {
+		name      string
+		cacheDown bool
+		attempts  []attempt
+	}{
+		{"current code", false, []attempt{{offset: 0, want: true}}},
+		{"previous step within skew", false, []attempt{{offset: -1, want: true}}},
+		{"code outside the window", false, []attempt{{offset: -3, want: false}}},
+		{"wrong code", false, []attempt{{code: "000000x", want: false}}},
+		{"replayed code", false, []attempt{{offset: 0, want: true}, {offset: 0, want: false}}},
+		{"older step after newer", false, []attempt{{offset: 0, want: true}, {offset: -1, want: false}}},
+		{"newer step after older", false, []attempt{{offset: -1, want: true}, {offset: 0, want: true}}},
+		{"cache unavailable", true, []attempt{{offset: 0, want: false}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := auth.GenerateTOTPSecret()
+			if err != nil {
+				t.Fatal(err)
+			}
+			cache := repositorytest.NewCache()
+			if tt.cacheDown {
+				cache.Err = errors.New("cache down")
+			}
+			m := NewTwoFactorManager(cache, nil, nil, TwoFactorConfig{})
+
+			step := currentTOTPStep()
+			for i, a := range tt.attempts {
+				code := a.code
+				if code == "" {
+					code = testTOTPCode(t, secret, step+a.offset)
+				}
+				if got := m.acceptCode(context.Background(), "user-1", secret, code); got != a.want {
+					t.Fatalf("attempt %d (offset %d): accepted = %v, want %v", i+1, a.offset, got, a.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAcceptCodeConcurrentReplay(t *testing.T) {
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewTwoFactorManager(repositorytest.NewCache(), nil, nil, TwoFactorConfig{})
+	code := testTOTPCode(t, secret, currentTOTPStep())
+
+	var wg sync.WaitGroup
+	var accepted atomic.Int32
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if m.acceptCode(context.Background(), "user-1", secret, code) {
+				accepted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := accepted.Load(); n != 1 {
+		t.Errorf("code accepted %d times, want 1", n)
+	}
+}
+
+func TestConsumeChallenge(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(m *TwoFactorManager, token string)
+		want    string
+		wantErr error
+	}{
+		{"issued challenge", func(*TwoFactorManager, string) {}, "user-1", nil},
+		{"challenge consumed twice", func(m *TwoFactorManager, token string) {
+			m.ConsumeChallenge(context.Background(), token)
+		}, "", ErrInvalidChallenge},
+		{"challenge closed", func(m *TwoFactorManager, token string) {
+			m.CloseChallenge(context.Background(), token)
+		}, "", ErrInvalidChallenge},
+		{"challenge closed after too many failures", func(m *TwoFactorManager, token string) {
+			m.FailChallenge(context.Background(), token)
+			m.FailChallenge(context.Background(), token)
+		}, "", ErrInvalidChallenge},
+		{"one failure left open", func(m *TwoFactorManager, token string) {
+			m.FailChallenge(context.Background(), token)
+		}, "user-1", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewTwoFactorManager(repositorytest.NewCache(), nil, nil, TwoFactorConfig{
+				ChallengeTTL:         time.Minute,
+				MaxChallengeAttempts: 2,
+			})
+			token, err := m.IssueChallenge(context.Background(), "user-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.prepare(m, token)
+
+			got, err := m.ConsumeChallenge(context.Background(), token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("user = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
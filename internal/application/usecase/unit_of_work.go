@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"building-report-backend/internal/domain/repository"
+	"building-report-backend/internal/infrastructure/storage"
+	"building-report-backend/pkg/logger"
+)
+
+// UnitOfWork keeps the database and object storage consistent for changes
+// that touch both. Storage cannot take part in a transaction, so every object
+// stored for a piece of work comes with a compensating action that removes
+// it again if the transaction fails, and objects the work deletes are only
+// deleted once the transaction has committed. A failure can then leave an
+// unreferenced object behind, which the reconcile command finds, but never a
+// row pointing at an object that is gone.
+type UnitOfWork struct {
+	tx      repository.Transactor
+	storage storage.StorageService
+	log     *slog.Logger
+}
+
+func NewUnitOfWork(tx repository.Transactor, storage storage.StorageService, log *slog.Logger) *UnitOfWork {
+	return &UnitOfWork{
+		tx:      tx,
+		storage: storage,
+		log:     log,
+	}
+}
+
+// Begin starts collecting a piece of work. Nothing touches the database
+// until Commit.
+func (u *UnitOfWork) Begin() *Work {
+	return &Work{uow: u}
+}
+
+// Work is one piece of work being collected: the database steps to run in a
+// transaction and the storage actions that go with them. A Work is used by a
+// single request and is not safe for concurrent use.
+type Work struct {
+	uow      *UnitOfWork
+	steps    []func(ctx context.Context) error
+	undo     []func(ctx context.Context)
+	deletes  []string
+	finished bool
+}
+
+// Do adds a step to run in the transaction. Steps run in the order they are
+// added and the first error stops the work.
+func (w *Work) Do(step func(ctx context.Context) error) {
+	w.steps = append(w.steps, step)
+}
+
+// Compensate registers fn to undo a storage change already made for the work,
+// should the work fail. Compensations run in reverse order.
+func (w *Work) Compensate(fn func(ctx context.Context)) {
+	w.undo = append(w.undo, fn)
+}
+
+// DeleteObjects removes objects from storage once the work has committed,
+// so the rows referring to them are gone first.
+func (w *Work) DeleteObjects(keys ...string) {
+	for _, key := range keys {
+		if key != "" {
+			w.deletes = append(w.deletes, key)
+		}
+	}
+}
+
+// Commit runs the steps in one transaction. If it fails, the transaction is
+// rolled back and the compensations run; otherwise the objects queued with
+// DeleteObjects are deleted. Either way the work is finished.
+func (w *Work) Commit(ctx context.Context) error {
+	if w.finished {
+		return nil
+	}
+
+	err := w.uow.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, step := range w.steps {
+			if err := step(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		w.Rollback(ctx)
+		return err
+	}
+
+	w.finished = true
+	ctx = context.WithoutCancel(ctx)
+	for _, key := range w.deletes {
+		if err := w.uow.storage.DeleteFile(ctx, key); err != nil {
+			// The rows are gone, so the object is only left over.
+			w.uow.log.WarnContext(ctx, "failed to delete object, left for reconciliation",
+				slog.String("object", key),
+				logger.Err(err),
+			)
+		}
+	}
+	return nil
+}
+
+// Rollback abandons work that fails before Commit, running its compensations.
+// It does nothing once the work is finished.
+func (w *Work) Rollback(ctx context.Context) {
+	if w.finished {
+		return
+	}
+	w.finished = true
+
+	// The request may have been cancelled, which is often why the work failed;
+	// the cleanup has to run regardless.
+	ctx = context.WithoutCancel(ctx)
+	for i := len(w.undo) - 1; i >= 0; i-- {
+		w.undo[i](ctx)
+	}
+}
@@ -0,0 +1,405 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/domain/constants"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"building-report-backend/internal/infrastructure/storage"
+	"building-report-backend/pkg/logger"
+	"building-report-backend/pkg/tracing"
+	"building-report-backend/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUploadSessionNotFound = errors.New("upload session not found")
+	ErrUploadSessionExpired  = errors.New("upload session has expired")
+	ErrUploadSessionUsed     = errors.New("upload session has already been used for a report")
+	// ErrUploadIncomplete means a file of the session was never POSTed to its
+	// presigned URL.
+	ErrUploadIncomplete = errors.New("photo has not been uploaded")
+	// ErrUploadMismatch means the uploaded file is not the one that was
+	// announced when the session was created.
+	ErrUploadMismatch = errors.New("uploaded photo does not match the announced file")
+	ErrPhotoCount     = errors.New("wrong number of photos")
+)
+
+// stagingFolder holds the objects clients upload to with presigned URLs, as
+// uploads/<session id>/<file id><ext>, until the session is confirmed.
+const stagingFolder = "uploads"
+
+// sweepBatchSize bounds how many expired sessions are loaded per query during a sweep.
+const sweepBatchSize = 100
+
+// UploadUseCase runs the two step upload flow: a client asks for presigned POST
+// URLs, uploads its photos straight to object storage and then creates a
+// report that refers to the session instead of carrying the files itself.
+type UploadUseCase struct {
+	sessionRepo repository.UploadSessionRepository
+	storage     storage.StorageService
+	photos      storage.PhotoPipeline
+	links       *PhotoLinks
+	sessionTTL  time.Duration
+	urlExpiry   time.Duration
+	log         *slog.Logger
+}
+
+func NewUploadUseCase(
+	sessionRepo repository.UploadSessionRepository,
+	storage storage.StorageService,
+	photos storage.PhotoPipeline,
+	links *PhotoLinks,
+	sessionTTL time.Duration,
+	urlExpiry time.Duration,
+	log *slog.Logger,
+) *UploadUseCase {
+	// A URL outliving its session would let a client upload to a staging
+	// object the sweeper already considers orphaned.
+	if urlExpiry <= 0 || urlExpiry > sessionTTL {
+		urlExpiry = sessionTTL
+	}
+	return &UploadUseCase{
+		sessionRepo: sessionRepo,
+		storage:     storage,
+		photos:      photos,
+		links:       links,
+		sessionTTL:  sessionTTL,
+		urlExpiry:   urlExpiry,
+		log:         log,
+	}
+}
+
+// CreateSession registers the files a client is about to upload for a report
+// of sector and returns a presigned POST form for each.
+func (uc *UploadUseCase) CreateSession(ctx context.Context, sector entity.Sector, req *dto.CreateUploadSessionRequest, userID string) (*dto.UploadSessionResponse, error) {
+	ctx, span := tracing.Start(ctx, "UploadUseCase.CreateSession")
+	defer span.End()
+
+	session := &entity.UploadSession{
+		ID:        utils.GenerateULID(),
+		UserID:    userID,
+		Sector:    sector,
+		Status:    entity.UploadSessionPending,
+		ExpiresAt: time.Now().Add(uc.sessionTTL),
+	}
+
+	uploads := make([]dto.PresignedUpload, 0, len(req.Files))
+	for i, file := range req.Files {
+		fileID := utils.GenerateULID()
+		objectName := fmt.Sprintf("%s/%s/%s%s", stagingFolder, session.ID, fileID, strings.ToLower(path.Ext(file.FileName)))
+
+		post, err := uc.storage.PresignPost(ctx, objectName, file.ContentType, file.Size, uc.urlExpiry)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+
+		session.Files = append(session.Files, entity.UploadSessionFile{
+			ID:          fileID,
+			SessionID:   session.ID,
+			Position:    i,
+			FileName:    file.FileName,
+			ContentType: file.ContentType,
+			Size:        file.Size,
+			ObjectName:  objectName,
+		})
+		uploads = append(uploads, dto.PresignedUpload{
+			FileID:   fileID,
+			FileName: file.FileName,
+			Method:   http.MethodPost,
+			URL:      post.URL,
+			Fields:   post.Fields,
+		})
+	}
+
+	if err := uc.sessionRepo.Create(ctx, session); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	resp := toUploadSessionResponse(session)
+	resp.Uploads = uploads
+	return resp, nil
+}
+
+// ConfirmSession checks every file of the session was uploaded and runs it
+// through the photo pipeline. Confirming is optional, creating a report with
+// the session confirms it as well, but lets a client learn about a rejected
+// photo before filling in the report.
+func (uc *UploadUseCase) ConfirmSession(ctx context.Context, sector entity.Sector, id, userID string) (*dto.UploadSessionResponse, error) {
+	ctx, span := tracing.Start(ctx, "UploadUseCase.ConfirmSession")
+	defer span.End()
+
+	session, err := uc.confirm(ctx, sector, id, userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	resp := toUploadSessionResponse(session)
+	uc.links.signUploadFiles(ctx, resp.Files)
+	return resp, nil
+}
+
+func (uc *UploadUseCase) confirm(ctx context.Context, sector entity.Sector, id, userID string) (*entity.UploadSession, error) {
+	session, err := uc.sessionRepo.FindByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUploadSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Another user's session, or one meant for another sector, does not exist
+	// as far as the caller is concerned.
+	if session.UserID != userID || session.Sector != sector {
+		return nil, ErrUploadSessionNotFound
+	}
+	if session.Status == entity.UploadSessionAttached {
+		return nil, ErrUploadSessionUsed
+	}
+	if session.IsExpired(time.Now()) {
+		return nil, ErrUploadSessionExpired
+	}
+	if session.Status == entity.UploadSessionConfirmed {
+		return session, nil
+	}
+
+	var stored []*storage.UploadedPhoto
+	for i := range session.Files {
+		file := &session.Files[i]
+		photo, err := uc.storeStaged(ctx, file, string(sector))
+		if err != nil {
+			// The session stays pending so the client can upload a
+			// replacement to the same URL and confirm again.
+			uc.discard(ctx, stored)
+			return nil, err
+		}
+		file.PhotoURL = photo.Key
+		file.PhotoMeta = photo.PhotoMeta
+		stored = append(stored, photo)
+	}
+
+	session.Status = entity.UploadSessionConfirmed
+	if err := uc.sessionRepo.Update(ctx, session, entity.UploadSessionPending); err != nil {
+		uc.discard(ctx, stored)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// A concurrent confirm got there first, or the sweeper removed
+			// the session; look again to report whichever it was.
+			return uc.confirm(ctx, sector, id, userID)
+		}
+		return nil, err
+	}
+
+	for _, file := range session.Files {
+		uc.deleteStaged(ctx, file.ObjectName)
+	}
+	return session, nil
+}
+
+// storeStaged processes the staging object of one file.
+func (uc *UploadUseCase) storeStaged(ctx context.Context, file *entity.UploadSessionFile, folder string) (*storage.UploadedPhoto, error) {
+	info, err := uc.storage.StatObject(ctx, file.ObjectName)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrUploadIncomplete, file.FileName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The upload policy already pins the size; checking again keeps an
+	// object put there by other means out of the photo pipeline.
+	if info.Size != file.Size || info.Size > constants.MaxFileSize {
+		uc.deleteStaged(ctx, file.ObjectName)
+		return nil, fmt.Errorf("%w: %s is %d bytes, %d were announced", ErrUploadMismatch, file.FileName, info.Size, file.Size)
+	}
+
+	obj, err := uc.storage.GetObject(ctx, file.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+
+	photo, err := uc.photos.StorePhoto(ctx, obj, info.Size, folder)
+	if err != nil {
+		return nil, fmt.Errorf("photo %q: %w", file.FileName, err)
+	}
+	return photo, nil
+}
+
+// photoSource is where a create request takes its photos from: the multipart
+// files it carries or, when SessionID is set, an upload session.
+type photoSource struct {
+	Sector    entity.Sector
+	SessionID string
+	Files     []*multipart.FileHeader
+	UserID    string
+	// ReportID is the ID of the report being created; the session is marked
+	// as used by it.
+	ReportID string
+	// Min and Max bound the number of photos. Multipart files beyond Max are
+	// ignored, as they always have been; a session must be within bounds.
+	// Max 0 means no limit.
+	Min, Max int
+}
+
+// photoBatch holds the stored photos of a report being created.
+type photoBatch struct {
+	Photos []*storage.UploadedPhoto
+}
+
+// collectPhotos stores the multipart files of a request, or loads its upload
+// session, and returns the photos for the report. It adds to work what goes
+// with them: stored files are removed again if work fails, and a session is
+// claimed for the report in work's transaction, so it stays usable when the
+// report cannot be saved.
+func (uc *UploadUseCase) collectPhotos(ctx context.Context, work *Work, src photoSource) (*photoBatch, error) {
+	batch := &photoBatch{}
+
+	if src.SessionID == "" {
+		files := src.Files
+		if src.Max > 0 && len(files) > src.Max {
+			files = files[:src.Max]
+		}
+		for _, file := range files {
+			photo, err := uploadPhoto(ctx, uc.photos, file, string(src.Sector))
+			if err != nil {
+				return nil, err
+			}
+			batch.Photos = append(batch.Photos, photo)
+			work.Compensate(func(ctx context.Context) {
+				uc.discard(ctx, []*storage.UploadedPhoto{photo})
+			})
+		}
+		return batch, nil
+	}
+
+	session, err := uc.confirm(ctx, src.Sector, src.SessionID, src.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(session.Files); n < src.Min || (src.Max > 0 && n > src.Max) {
+		if src.Max > 0 {
+			return nil, fmt.Errorf("%w: the report needs %d to %d photos, the upload session has %d", ErrPhotoCount, src.Min, src.Max, n)
+		}
+		return nil, fmt.Errorf("%w: the report needs at least %d photos, the upload session has %d", ErrPhotoCount, src.Min, n)
+	}
+
+	// The claim only succeeds for a session still confirmed, so two requests
+	// cannot attach the same photos to two reports.
+	work.Do(func(ctx context.Context) error {
+		session.Status = entity.UploadSessionAttached
+		session.ReportID = &src.ReportID
+		if err := uc.sessionRepo.Update(ctx, session, entity.UploadSessionConfirmed); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUploadSessionUsed
+			}
+			return err
+		}
+		return nil
+	})
+
+	for _, file := range session.Files {
+		batch.Photos = append(batch.Photos, &storage.UploadedPhoto{Key: file.PhotoURL, PhotoMeta: file.PhotoMeta})
+	}
+	return batch, nil
+}
+
+// SweepExpired removes expired upload sessions together with their staging
+// objects and, unless a report uses them, their processed photos. It returns
+// how many sessions were removed.
+func (uc *UploadUseCase) SweepExpired(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "UploadUseCase.SweepExpired")
+	defer span.End()
+
+	swept := 0
+	for {
+		expired, err := uc.sessionRepo.FindExpired(ctx, time.Now(), sweepBatchSize)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return swept, err
+		}
+
+		removed := 0
+		for _, session := range expired {
+			// The row goes first and only if its status is still the one
+			// loaded: a session claimed by a report in the meantime keeps its
+			// photos.
+			if err := uc.sessionRepo.Delete(ctx, session.ID, session.Status); err != nil {
+				if !errors.Is(err, gorm.ErrRecordNotFound) {
+					uc.log.ErrorContext(ctx, "upload sweep: failed to remove session",
+						slog.String("session_id", session.ID),
+						logger.Err(err),
+					)
+				}
+				continue
+			}
+
+			var photos []*storage.UploadedPhoto
+			for _, file := range session.Files {
+				uc.deleteStaged(ctx, file.ObjectName)
+				if session.Status != entity.UploadSessionAttached && file.PhotoURL != "" {
+					photos = append(photos, &storage.UploadedPhoto{Key: file.PhotoURL, PhotoMeta: file.PhotoMeta})
+				}
+			}
+			uc.discard(ctx, photos)
+			removed++
+		}
+		swept += removed
+
+		// As in the trash purge, a batch where nothing could be removed would
+		// otherwise be fetched again forever.
+		if len(expired) < sweepBatchSize || removed == 0 {
+			break
+		}
+	}
+
+	return swept, nil
+}
+
+// discard deletes stored photos with their variants. Failures are only
+// logged; the photos are not referenced by anything.
+func (uc *UploadUseCase) discard(ctx context.Context, photos []*storage.UploadedPhoto) {
+	ctx = context.WithoutCancel(ctx)
+	for _, photo := range photos {
+		for _, key := range []string{photo.Key, photo.MediumURL, photo.ThumbnailURL} {
+			if key == "" {
+				continue
+			}
+			if err := uc.storage.DeleteFile(ctx, key); err != nil {
+				uc.log.WarnContext(ctx, "failed to delete unused photo",
+					slog.String("object", key),
+					logger.Err(err),
+				)
+			}
+		}
+	}
+}
+
+func (uc *UploadUseCase) deleteStaged(ctx context.Context, objectName string) {
+	if err := uc.storage.DeleteObject(context.WithoutCancel(ctx), objectName); err != nil {
+		uc.log.WarnContext(ctx, "failed to delete staging object",
+			slog.String("object", objectName),
+			logger.Err(err),
+		)
+	}
+}
+
+func toUploadSessionResponse(session *entity.UploadSession) *dto.UploadSessionResponse {
+	return &dto.UploadSessionResponse{
+		ID:        session.ID,
+		Sector:    session.Sector,
+		Status:    session.Status,
+		ExpiresAt: session.ExpiresAt,
+		Files:     session.Files,
+	}
+}
@@ -0,0 +1,408 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/domain/constants"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/pkg/tracing"
+	"building-report-backend/pkg/utils"
+	"building-report-backend/pkg/validation"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrInvalidImportFile = errors.New("invalid user import file")
+	ErrImportTooLarge    = fmt.Errorf("user import is limited to %d rows", maxUserImportRows)
+)
+
+const (
+	maxUserImportRows = 1000
+
+	UserImportStatusCreated = "created"
+	UserImportStatusValid   = "valid"
+	UserImportStatusFailed  = "failed"
+)
+
+// userImportColumns are the CSV columns ImportUsers understands; username and
+// email are required, the rest may be left out of the header.
+var userImportColumns = map[string]bool{
+	"username":             true,
+	"email":                true,
+	"password":             false,
+	"role":                 false,
+	"district":             false,
+	"unit":                 false,
+	"must_change_password": false,
+}
+
+func newUserResponse(user *entity.User) *dto.UserResponse {
+	return &dto.UserResponse{
+		ID:                 user.ID,
+		Username:           user.Username,
+		Email:              user.Email,
+		Role:               user.Role,
+		IsActive:           user.IsActive,
+		MustChangePassword: user.MustChangePassword,
+		AuthProvider:       user.AuthProvider,
+		TwoFactorEnabled:   user.TwoFactorEnabled,
+		District:           user.District,
+		Unit:               user.Unit,
+		CreatedAt:          user.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:          user.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// BulkSetUserStatus activates or deactivates many users at once. Deactivated
+// users lose their sessions; the requester cannot deactivate themselves.
+func (uc *AuthUseCase) BulkSetUserStatus(ctx context.Context, requesterID string, req *dto.BulkUserStatusRequest) (*dto.BulkUserStatusResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthUseCase.BulkSetUserStatus")
+	defer span.End()
+
+	requester, err := uc.GetUserByID(ctx, requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !requester.IsSuperAdmin() {
+		return nil, ErrForbidden
+	}
+
+	active := *req.IsActive
+	result := &dto.BulkUserStatusResponse{
+		Unchanged: []string{},
+		NotFound:  []string{},
+		Skipped:   []string{},
+	}
+
+	seen := make(map[string]bool, len(req.UserIDs))
+	var ids []string
+	for _, id := range req.UserIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		switch {
+		case !utils.IsValidULID(id):
+			result.NotFound = append(result.NotFound, id)
+		case id == requesterID && !active:
+			result.Skipped = append(result.Skipped, id)
+		default:
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	users, err := uc.userRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]*entity.User, len(users))
+	for _, user := range users {
+		found[user.ID] = user
+	}
+
+	var changed []string
+	for _, id := range ids {
+		user, ok := found[id]
+		switch {
+		case !ok:
+			result.NotFound = append(result.NotFound, id)
+		case user.IsActive == active:
+			result.Unchanged = append(result.Unchanged, id)
+		default:
+			changed = append(changed, id)
+		}
+	}
+
+	if len(changed) == 0 {
+		return result, nil
+	}
+
+	result.Updated, err = uc.userRepo.SetActive(ctx, changed, active)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range changed {
+		if !active {
+			if err := uc.revokeSessions(ctx, id); err != nil {
+				return nil, err
+			}
+		}
+		uc.cache.Delete(ctx, constants.UserCachePrefix+id)
+	}
+
+	return result, nil
+}
+
+// ImportUsers creates the users listed in a CSV file, validating every row on
+// its own so one bad row does not stop the others. Rows without a password get
+// a random one and a password reset link through the configured sender. With
+// dryRun nothing is created and valid rows are reported as such.
+func (uc *AuthUseCase) ImportUsers(ctx context.Context, requesterID string, file io.Reader, dryRun bool) (*dto.UserImportResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthUseCase.ImportUsers")
+	defer span.End()
+
+	requester, err := uc.GetUserByID(ctx, requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !requester.IsSuperAdmin() {
+		return nil, ErrForbidden
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	columns, err := parseImportHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.UserImportResponse{DryRun: dryRun, Rows: []*dto.UserImportRowResult{}}
+	usernames := map[string]int{}
+	emails := map[string]int{}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: %v", ErrInvalidImportFile, line, err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		if result.Total == maxUserImportRows {
+			return nil, ErrImportTooLarge
+		}
+		result.Total++
+
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := &dto.UserImportRowResult{Row: line, Username: value("username")}
+		result.Rows = append(result.Rows, row)
+
+		user, password, errs := uc.parseImportRow(ctx, value)
+
+		if username := strings.ToLower(row.Username); username != "" {
+			if prev, ok := usernames[username]; ok {
+				errs = append(errs, fmt.Sprintf("username duplicates row %d", prev))
+			} else {
+				usernames[username] = line
+			}
+		}
+		if email := strings.ToLower(value("email")); email != "" {
+			if prev, ok := emails[email]; ok {
+				errs = append(errs, fmt.Sprintf("email duplicates row %d", prev))
+			} else {
+				emails[email] = line
+			}
+		}
+
+		if len(errs) > 0 {
+			row.Status = UserImportStatusFailed
+			row.Errors = errs
+			result.Failed++
+			continue
+		}
+
+		if dryRun {
+			row.Status = UserImportStatusValid
+			continue
+		}
+
+		if err := setImportPassword(user, password); err != nil {
+			return nil, err
+		}
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			row.Status = UserImportStatusFailed
+			row.Errors = []string{"failed to create user: " + err.Error()}
+			result.Failed++
+			continue
+		}
+
+		row.Status = UserImportStatusCreated
+		row.UserID = user.ID
+		result.Created++
+
+		if password == "" {
+			if err := uc.sendImportPasswordReset(ctx, user); err != nil {
+				row.Errors = []string{"user created but the password reset could not be sent: " + err.Error()}
+			} else {
+				row.PasswordResetSent = true
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// parseImportRow validates one row and builds the user it describes, without a
+// password hash. The returned password is the one given in the file, empty if
+// none was.
+func (uc *AuthUseCase) parseImportRow(ctx context.Context, value func(string) string) (*entity.User, string, []string) {
+	var errs []string
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	username, email, password := value("username"), value("email"), value("password")
+	check(validation.ValidateUsername(username))
+	check(validation.ValidateEmail(email))
+	if password != "" {
+		check(validation.ValidatePassword(password))
+	}
+
+	role := entity.RoleOperator
+	if v := value("role"); v != "" {
+		role = entity.UserRole(strings.ToUpper(v))
+	}
+	switch {
+	case !role.IsValid():
+		errs = append(errs, fmt.Sprintf("invalid role %q", role))
+	case role == entity.RoleSuperAdmin:
+		errs = append(errs, "superadmins cannot be imported")
+	}
+
+	district, unit := value("district"), value("unit")
+	for _, v := range []string{district, unit} {
+		check(entity.ValidateScopeValue(v))
+	}
+
+	mustChangePassword := true
+	if v := value("must_change_password"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, "must_change_password must be true or false")
+		}
+		mustChangePassword = parsed
+	}
+
+	if len(errs) > 0 {
+		return nil, "", errs
+	}
+
+	if existing, _ := uc.userRepo.FindByUsername(ctx, username); existing != nil {
+		errs = append(errs, "username already exists")
+	}
+	if existing, _ := uc.userRepo.FindByEmail(ctx, email); existing != nil {
+		errs = append(errs, "email already exists")
+	}
+	if len(errs) > 0 {
+		return nil, "", errs
+	}
+
+	now := time.Now()
+	return &entity.User{
+		ID:                 utils.GenerateULID(),
+		Username:           username,
+		Email:              email,
+		Role:               role,
+		IsActive:           true,
+		MustChangePassword: mustChangePassword,
+		AuthProvider:       entity.AuthProviderLocal,
+		District:           district,
+		Unit:               unit,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}, password, nil
+}
+
+// setImportPassword hashes password onto user, or a random password nobody
+// learns when it is empty; such users set their own through a reset link.
+func setImportPassword(user *entity.User, password string) error {
+	if password == "" {
+		random, err := randomPassword()
+		if err != nil {
+			return err
+		}
+		password = random
+		user.MustChangePassword = true
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.Password = string(hashed)
+	return nil
+}
+
+// randomPassword returns 32 random bytes, URL safe base64 encoded.
+func randomPassword() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func (uc *AuthUseCase) sendImportPasswordReset(ctx context.Context, user *entity.User) error {
+	token, err := uc.tokenStore.IssuePasswordResetToken(ctx, user.ID, uc.resetTTL)
+	if err != nil {
+		return err
+	}
+	return uc.resetSender.SendPasswordReset(ctx, user, token, time.Now().Add(uc.resetTTL))
+}
+
+func parseImportHeader(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, known := userImportColumns[name]; !known {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidImportFile, name)
+		}
+		if _, dup := columns[name]; dup {
+			return nil, fmt.Errorf("%w: duplicate column %q", ErrInvalidImportFile, name)
+		}
+		columns[name] = i
+	}
+
+	for name, required := range userImportColumns {
+		if _, ok := columns[name]; required && !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidImportFile, name)
+		}
+	}
+	return columns, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -9,29 +9,44 @@ import (
 	"building-report-backend/internal/application/dto"
 	"building-report-backend/internal/domain/entity"
 	"building-report-backend/internal/domain/repository"
-	"building-report-backend/internal/infrastructure/storage"
+	"building-report-backend/pkg/tracing"
 	"building-report-backend/pkg/utils"
 )
 
 type WaterResourcesUseCase struct {
 	waterRepo repository.WaterResourcesRepository
-	storage   storage.StorageService
+	uploads   *UploadUseCase
+	work      *UnitOfWork
+	links     *PhotoLinks
+	photos    *PhotoUseCase
 	cache     repository.CacheRepository
+	auditRepo repository.AuditLogRepository
 }
 
 func NewWaterResourcesUseCase(
 	waterRepo repository.WaterResourcesRepository,
-	storage storage.StorageService,
+	uploads *UploadUseCase,
+	work *UnitOfWork,
+	links *PhotoLinks,
+	photos *PhotoUseCase,
 	cache repository.CacheRepository,
+	auditRepo repository.AuditLogRepository,
 ) *WaterResourcesUseCase {
 	return &WaterResourcesUseCase{
 		waterRepo: waterRepo,
-		storage:   storage,
+		uploads:   uploads,
+		work:      work,
+		links:     links,
+		photos:    photos,
 		cache:     cache,
+		auditRepo: auditRepo,
 	}
 }
 
-func (uc *WaterResourcesUseCase) CreateReport(ctx context.Context, req *dto.CreateWaterResourcesRequest, photos []*multipart.FileHeader) (*entity.WaterResourcesReport, error) {
+func (uc *WaterResourcesUseCase) CreateReport(ctx context.Context, req *dto.CreateWaterResourcesRequest, photos []*multipart.FileHeader, userID string) (*entity.WaterResourcesReport, error) {
+    ctx, span := tracing.Start(ctx, "WaterResourcesUseCase.CreateReport")
+    defer span.End()
+
     report := &entity.WaterResourcesReport{
         ID:                    utils.GenerateULID(),
         ReporterName:          req.ReporterName,
@@ -42,6 +57,7 @@ func (uc *WaterResourcesUseCase) CreateReport(ctx context.Context, req *dto.Crea
         IrrigationType:        entity.IrrigationType(req.IrrigationType),
         Latitude:              req.Latitude,
         Longitude:             req.Longitude,
+        District:              req.District,
         DamageType:            entity.DamageType(req.DamageType),
         DamageLevel:           entity.DamageLevel(req.DamageLevel),
         EstimatedLength:       req.EstimatedLength,
@@ -61,29 +77,45 @@ func (uc *WaterResourcesUseCase) CreateReport(ctx context.Context, req *dto.Crea
 
     
     photoAngles := []string{"front", "side", "damage_detail", "aerial"}
-    for i, photo := range photos {
-        if i >= len(photoAngles) {
-            break
-        }
-
-        photoURL, err := uc.storage.UploadFile(ctx, photo, "water-resources")
-        if err != nil {
-            return nil, fmt.Errorf("failed to upload photo: %w", err)
-        }
+    work := uc.work.Begin()
+    batch, err := uc.uploads.collectPhotos(ctx, work, photoSource{
+        Sector:    entity.SectorWaterResources,
+        SessionID: req.UploadSessionID,
+        Files:     photos,
+        UserID:    userID,
+        ReportID:  report.ID,
+        Min:       2,
+        Max:       len(photoAngles),
+    })
+    if err != nil {
+        work.Rollback(ctx)
+        return nil, err
+    }
 
+    for i, uploaded := range batch.Photos {
         caption := fmt.Sprintf("%s view - %s", photoAngles[i], report.IrrigationAreaName)
         report.Photos = append(report.Photos, entity.WaterResourcesPhoto{
-            ID:         utils.GenerateULID(),
-            PhotoURL:   photoURL,
-            PhotoAngle: photoAngles[i],
-            Caption:    caption,
+            ID:             utils.GenerateULID(),
+            PhotoURL:       uploaded.Key,
+            PhotoAngle:     photoAngles[i],
+            Caption:        caption,
+            PhotoPlacement: entity.PhotoPlacement{Position: i},
+            PhotoMeta:      uploaded.PhotoMeta,
         })
     }
 
-    if err := uc.waterRepo.Create(ctx, report); err != nil {
+    report.CreatedBy = userID
+
+    work.Do(func(ctx context.Context) error {
+        return uc.waterRepo.Create(ctx, report)
+    })
+    if err := work.Commit(ctx); err != nil {
         return nil, err
     }
 
+    recordAudit(ctx, uc.auditRepo, entity.SectorWaterResources, report.ID, entity.AuditActionCreate, userID, nil, report, "")
+    countReportCreated(entity.SectorWaterResources)
+
     
     uc.cache.Delete(ctx, "water:list")
     uc.cache.Delete(ctx, "water:stats")
@@ -94,10 +126,14 @@ func (uc *WaterResourcesUseCase) CreateReport(ctx context.Context, req *dto.Crea
         uc.sendUrgentNotification(ctx, report)
     }
 
+    uc.links.linkWaterReports(ctx, report)
     return report, nil
 }
 
 func (uc *WaterResourcesUseCase) GetReport(ctx context.Context, id string) (*entity.WaterResourcesReport, error) {
+	ctx, span := tracing.Start(ctx, "WaterResourcesUseCase.GetReport")
+	defer span.End()
+
 	cacheKey := "water:" + id
 
 	report, err := uc.waterRepo.FindByID(ctx, id)
@@ -107,16 +143,43 @@ func (uc *WaterResourcesUseCase) GetReport(ctx context.Context, id string) (*ent
 
 	uc.cache.Set(ctx, cacheKey, report, 3600)
 
+	uc.links.linkWaterReports(ctx, report)
 	return report, nil
 }
 
 func (uc *WaterResourcesUseCase) ListReports(ctx context.Context, page, limit int, filters map[string]interface{}) (*dto.PaginatedWaterResourcesResponse, error) {
+	ctx, span := tracing.Start(ctx, "WaterResourcesUseCase.ListReports")
+	defer span.End()
+
 	offset := (page - 1) * limit
 
 	reports, total, err := uc.waterRepo.FindAll(ctx, limit, offset, filters)
 	if err != nil {
 		return nil, err
 	}
+	uc.links.linkWaterReports(ctx, reports...)
+
+	return &dto.PaginatedWaterResourcesResponse{
+		Reports:    reports,
+		Total:      total,
+		Page:       page,
+		PerPage:    limit,
+		TotalPages: (total + int64(limit) - 1) / int64(limit),
+	}, nil
+}
+
+// ListMyReports returns the reports created by userID, newest first.
+func (uc *WaterResourcesUseCase) ListMyReports(ctx context.Context, userID string, page, limit int) (*dto.PaginatedWaterResourcesResponse, error) {
+	ctx, span := tracing.Start(ctx, "WaterResourcesUseCase.ListMyReports")
+	defer span.End()
+
+	offset := (page - 1) * limit
+
+	reports, total, err := uc.waterRepo.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	uc.links.linkWaterReports(ctx, reports...)
 
 	return &dto.PaginatedWaterResourcesResponse{
 		Reports:    reports,
@@ -128,12 +191,16 @@ func (uc *WaterResourcesUseCase) ListReports(ctx context.Context, page, limit in
 }
 
 func (uc *WaterResourcesUseCase) ListByPriority(ctx context.Context, page, limit int) (*dto.PaginatedWaterResourcesResponse, error) {
+	ctx, span := tracing.Start(ctx, "WaterResourcesUseCase.ListByPriority")
+	defer span.End()
+
 	offset := (page - 1) * limit
 
 	reports, total, err := uc.waterRepo.FindByPriority(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
+	uc.links.linkWaterReports(ctx, reports...)
 
 	return &dto.PaginatedWaterResourcesResponse{
 		Reports:    reports,
@@ -144,16 +211,21 @@ func (uc *WaterResourcesUseCase) ListByPriority(ctx context.Context, page, limit
 	}, nil
 }
 
-func (uc *WaterResourcesUseCase) UpdateReport(ctx context.Context, id string, req *dto.UpdateWaterResourcesRequest, userID string) (*entity.WaterResourcesReport, error) {
+func (uc *WaterResourcesUseCase) UpdateReport(ctx context.Context, id string, req *dto.UpdateWaterResourcesRequest, userID string, role entity.UserRole) (*entity.WaterResourcesReport, error) {
+    ctx, span := tracing.Start(ctx, "WaterResourcesUseCase.UpdateReport")
+    defer span.End()
+
     report, err := uc.waterRepo.FindByID(ctx, id)
     if err != nil {
         return nil, err
     }
 
     
-    // if report.CreatedBy != userID {
-    //     return nil, ErrUnauthorized
-    // }
+    if !entity.CanManageReport(report.CreatedBy, userID, role) {
+        return nil, ErrUnauthorized
+    }
+
+    before := *report
 
     
     if req.IrrigationAreaName != "" {
@@ -201,49 +273,90 @@ func (uc *WaterResourcesUseCase) UpdateReport(ctx context.Context, id string, re
         report.EstimatedBudget = uc.calculateEstimatedBudget(report)
     }
 
+    report.UpdatedBy = userID
+
     if err := uc.waterRepo.Update(ctx, report); err != nil {
         return nil, err
     }
 
+    recordAudit(ctx, uc.auditRepo, entity.SectorWaterResources, report.ID, entity.AuditActionUpdate, userID, &before, report, "")
+
     
     uc.cache.Delete(ctx, "water:"+id)
     uc.cache.Delete(ctx, "water:list")
     uc.cache.Delete(ctx, "water:stats")
 
+    uc.links.linkWaterReports(ctx, report)
     return report, nil
 }
 
-func (uc *WaterResourcesUseCase) UpdateStatus(ctx context.Context, id string, req *dto.UpdateWaterStatusRequest) error {
-	err := uc.waterRepo.UpdateStatus(ctx, id, entity.WaterResourceStatus(req.Status), req.Notes)
+func (uc *WaterResourcesUseCase) UpdateStatus(ctx context.Context, id string, req *dto.UpdateWaterStatusRequest, userID string) error {
+	ctx, span := tracing.Start(ctx, "WaterResourcesUseCase.UpdateStatus")
+	defer span.End()
+
+	report, err := uc.waterRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
+	next := entity.WaterResourceStatus(req.Status)
+	if !report.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, report.Status, next)
+	}
+
+	// Photos of the repair are saved with the change, so if it fails the
+	// upload session can be reused.
+	work := uc.work.Begin()
+	if req.UploadSessionID != "" {
+		phase, ok := next.RepairPhase()
+		if !ok {
+			return ErrRepairPhotosNotAllowed
+		}
+		if err := uc.photos.attachRepairPhotos(ctx, work, entity.SectorWaterResources, id, req.UploadSessionID, phase, string(next), userID); err != nil {
+			work.Rollback(ctx)
+			return err
+		}
+	}
+
+	work.Do(func(ctx context.Context) error {
+		return uc.waterRepo.UpdateStatus(ctx, id, report.Status, next, req.Notes, userID)
+	})
+	if err := work.Commit(ctx); err != nil {
+		return err
+	}
+
+	before := *report
+	report.Status = next
+	report.Notes = req.Notes
+	recordAudit(ctx, uc.auditRepo, entity.SectorWaterResources, report.ID, entity.AuditActionStatusChange, userID, &before, report, req.Notes)
+
 	uc.cache.Delete(ctx, "water:"+id)
+	uc.cache.Delete(ctx, "water:list")
 	uc.cache.Delete(ctx, "water:stats")
 
 	return nil
 }
 
-func (uc *WaterResourcesUseCase) DeleteReport(ctx context.Context, id string, userID string) error {
+func (uc *WaterResourcesUseCase) DeleteReport(ctx context.Context, id string, userID string, role entity.UserRole) error {
+	ctx, span := tracing.Start(ctx, "WaterResourcesUseCase.DeleteReport")
+	defer span.End()
+
 	report, err := uc.waterRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
 
-	// if report.CreatedBy != userID {
-	// 	return ErrUnauthorized
-	// }
-
-	for _, photo := range report.Photos {
-		uc.storage.DeleteFile(ctx, photo.PhotoURL)
+	if !entity.CanManageReport(report.CreatedBy, userID, role) {
+		return ErrUnauthorized
 	}
 
 	if err := uc.waterRepo.Delete(ctx, id); err != nil {
 		return err
 	}
 
+	recordAudit(ctx, uc.auditRepo, entity.SectorWaterResources, report.ID, entity.AuditActionDelete, userID, report, nil, "")
+
 	uc.cache.Delete(ctx, "water:"+id)
 	uc.cache.Delete(ctx, "water:list")
 	uc.cache.Delete(ctx, "water:stats")
@@ -293,8 +406,11 @@ func (uc *WaterResourcesUseCase) sendUrgentNotification(ctx context.Context, rep
 }
 
 func (uc *WaterResourcesUseCase) GetWaterResourcesOverview(ctx context.Context, irrigationType string) (*dto.WaterResourcesOverviewResponse, error) {
+	ctx, span := tracing.Start(ctx, "WaterResourcesUseCase.GetWaterResourcesOverview")
+	defer span.End()
+
 	// Cache key based on irrigation type
-	cacheKey := fmt.Sprintf("water_resources:overview:%s", irrigationType)
+	cacheKey := scopedCacheKey(ctx, fmt.Sprintf("water_resources:overview:%s", irrigationType))
 	var response dto.WaterResourcesOverviewResponse
 
 	err := uc.cache.Get(ctx, cacheKey, &response)
@@ -49,6 +49,14 @@ const (
 	AllowedImageFormats = "jpg,jpeg,png,webp"
 )
 
+// Photo processing
+const (
+	MaxImagePixels        = 50_000_000 // rejects decompression bombs before decoding
+	MediumMaxDimension    = 1280       // longest side of the medium variant
+	ThumbnailMaxDimension = 320        // longest side of the thumbnail variant
+	JPEGQuality           = 85
+)
+
 // Priority score ranges
 const (
 	MinPriorityScore = 0
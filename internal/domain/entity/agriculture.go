@@ -3,6 +3,8 @@ package entity
 import (
 	"building-report-backend/pkg/utils"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type AgricultureReport struct {
@@ -67,9 +69,12 @@ type AgricultureReport struct {
 	WaterAccess    WaterAccess    `json:"water_access" gorm:"type:varchar(50)"`
 	Suggestions    string         `json:"suggestions" gorm:"type:text"`
 
-	// CreatedBy              string              `json:"created_by" gorm:"type:varchar(26);not null"
-	CreatedAt time.Time `json:"created_at" gorm:"not null"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"not null"`
+	Unit      string         `json:"unit,omitempty" gorm:"type:varchar(100);not null;default:''"`
+	CreatedBy string         `json:"created_by,omitempty" gorm:"type:varchar(26);index"`
+	UpdatedBy string         `json:"updated_by,omitempty" gorm:"type:varchar(26)"`
+	CreatedAt time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 type AgriculturePhoto struct {
@@ -79,6 +84,8 @@ type AgriculturePhoto struct {
 	PhotoType string    `json:"photo_type" gorm:"type:varchar(50)"`
 	Caption   string    `json:"caption" gorm:"type:varchar(255)"`
 	CreatedAt time.Time `json:"created_at" gorm:"not null"`
+	PhotoPlacement
+	PhotoMeta
 }
 
 func (AgricultureReport) TableName() string {
@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"building-report-backend/pkg/utils"
+)
+
+// API key scopes have the form "<sector>:read" or "<sector>:write", for example
+// "agriculture:write" or "executive:read". Write implies read.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// APIKeyScopes is the list of scopes granted to a key, stored as JSONB.
+type APIKeyScopes []string
+
+func (s APIKeyScopes) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (s *APIKeyScopes) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*s = APIKeyScopes{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for APIKeyScopes: %T", value)
+	}
+	return json.Unmarshal(b, s)
+}
+
+// Allows reports whether the scopes cover action on sector.
+func (s APIKeyScopes) Allows(sector Sector, action Action) bool {
+	for _, scope := range s {
+		scopeSector, level, ok := strings.Cut(scope, ":")
+		if !ok || Sector(scopeSector) != sector {
+			continue
+		}
+		if level == ScopeWrite || (level == ScopeRead && action == ActionRead) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateAPIKeyScope checks that scope names a known sector and level.
+func ValidateAPIKeyScope(scope string) error {
+	sector, level, ok := strings.Cut(scope, ":")
+	if !ok || (level != ScopeRead && level != ScopeWrite) {
+		return fmt.Errorf("invalid scope %q: expected <sector>:read or <sector>:write", scope)
+	}
+	if _, known := PermissionMatrix[Sector(sector)]; !known {
+		return fmt.Errorf("invalid scope %q: unknown sector %q", scope, sector)
+	}
+	return nil
+}
+
+// APIKey is a long-lived credential for machine clients. It acts as its owner,
+// limited to its scopes. Only the SHA-256 hash of the key is stored.
+type APIKey struct {
+	ID         string       `json:"id" gorm:"type:varchar(26);primary_key"`
+	Name       string       `json:"name" gorm:"type:varchar(100);not null"`
+	OwnerID    string       `json:"owner_id" gorm:"type:varchar(26);not null;index"`
+	Prefix     string       `json:"prefix" gorm:"type:varchar(16);not null"`
+	KeyHash    string       `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	Scopes     APIKeyScopes `json:"scopes" gorm:"type:jsonb"`
+	ExpiresAt  *time.Time   `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time   `json:"revoked_at,omitempty"`
+	RevokedBy  string       `json:"revoked_by,omitempty" gorm:"type:varchar(26)"`
+	CreatedBy  string       `json:"created_by" gorm:"type:varchar(26)"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+func (k *APIKey) BeforeCreate() {
+	if k.ID == "" {
+		k.ID = utils.GenerateULID()
+	}
+	if k.CreatedAt.IsZero() {
+		k.CreatedAt = time.Now()
+	}
+}
+
+// IsUsable reports whether the key is neither revoked nor expired at now.
+func (k *APIKey) IsUsable(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
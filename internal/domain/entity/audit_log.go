@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"building-report-backend/pkg/utils"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate       AuditAction = "CREATE"
+	AuditActionUpdate       AuditAction = "UPDATE"
+	AuditActionStatusChange AuditAction = "STATUS_CHANGE"
+	AuditActionDelete       AuditAction = "DELETE"
+	AuditActionRestore      AuditAction = "RESTORE"
+	AuditActionPurge        AuditAction = "PURGE"
+)
+
+// FieldChange holds the value of a single field before and after a mutation.
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// AuditChanges is the field-level diff stored as JSONB, keyed by the JSON field name.
+type AuditChanges map[string]FieldChange
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (c *AuditChanges) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*c = AuditChanges{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for AuditChanges: %T", value)
+	}
+	return json.Unmarshal(b, c)
+}
+
+type AuditLog struct {
+	ID        string       `json:"id" gorm:"type:varchar(26);primary_key"`
+	Sector    Sector       `json:"sector" gorm:"type:varchar(50);not null;index:idx_audit_logs_report"`
+	ReportID  string       `json:"report_id" gorm:"type:varchar(26);not null;index:idx_audit_logs_report"`
+	Action    AuditAction  `json:"action" gorm:"type:varchar(20);not null"`
+	ActorID   string       `json:"actor_id" gorm:"type:varchar(26);index"`
+	Changes   AuditChanges `json:"changes" gorm:"type:jsonb"`
+	Notes     string       `json:"notes,omitempty" gorm:"type:text"`
+	CreatedAt time.Time    `json:"created_at" gorm:"index"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+func (a *AuditLog) BeforeCreate() {
+	if a.ID == "" {
+		a.ID = utils.GenerateULID()
+	}
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = time.Now()
+	}
+}
@@ -3,6 +3,8 @@ package entity
 import (
 	"building-report-backend/pkg/utils"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type BinaMargaReport struct {
@@ -51,9 +53,13 @@ type BinaMargaReport struct {
     HandlingRecommendation string                `json:"handling_recommendation" gorm:"type:text"`
     EstimatedBudget       float64                `json:"estimated_budget"`
     EstimatedRepairTime   int                    `json:"estimated_repair_time" gorm:"comment:'in days'"`
-    // CreatedBy             string                 `json:"created_by" gorm:"type:varchar(26);not null"`
+    Unit                  string                 `json:"unit,omitempty" gorm:"type:varchar(100);not null;default:''"`
+    CreatedBy             string                 `json:"created_by,omitempty" gorm:"type:varchar(26);index"`
+    UpdatedBy             string                 `json:"updated_by,omitempty" gorm:"type:varchar(26)"`
+    PriorityScore         int                    `json:"priority_score" gorm:"not null;default:0"`
     CreatedAt             time.Time              `json:"created_at"`
     UpdatedAt             time.Time              `json:"updated_at"`
+    DeletedAt             gorm.DeletedAt         `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 type BinaMargaPhoto struct {
@@ -63,6 +69,8 @@ type BinaMargaPhoto struct {
 	PhotoAngle string    `json:"photo_angle" gorm:"type:varchar(50)"`
 	Caption    string    `json:"caption" gorm:"type:varchar(255)"`
 	CreatedAt  time.Time `json:"created_at"`
+	PhotoPlacement
+	PhotoMeta
 }
 
 func (BinaMargaReport) TableName() string {
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidScopeValue = errors.New("district and unit may only contain letters, digits, spaces and . - / ( )")
+
+// ErrNoDataScope is returned when a user whose role is scoped but who has no
+// district or unit yet tries to create a report.
+var ErrNoDataScope = errors.New("no district or unit is assigned to the user")
+
+var scopeValueRegex = regexp.MustCompile(`^[\p{L}\p{N} .\-/()]{0,100}$`)
+
+// ValidateScopeValue checks a district or unit name before it is stored on a user.
+func ValidateScopeValue(value string) error {
+	if !scopeValueRegex.MatchString(value) {
+		return ErrInvalidScopeValue
+	}
+	return nil
+}
+
+// DataScope limits a user to the reports of one district (kecamatan), one unit
+// (OPD), or both. The zero value is the global scope.
+type DataScope struct {
+	District string `json:"district,omitempty"`
+	Unit     string `json:"unit,omitempty"`
+	// None is the scope of a user whose role is scoped but who has no
+	// district or unit yet: they see no reports at all.
+	None bool `json:"none,omitempty"`
+}
+
+func (s DataScope) IsGlobal() bool {
+	return !s.None && s.District == "" && s.Unit == ""
+}
+
+// Contains reports whether a report in district and unit is visible in the scope.
+func (s DataScope) Contains(district, unit string) bool {
+	if s.None {
+		return false
+	}
+	if s.District != "" && !strings.EqualFold(s.District, district) {
+		return false
+	}
+	if s.Unit != "" && s.Unit != unit {
+		return false
+	}
+	return true
+}
+
+// Key identifies the scope in cache keys; it is empty for the global scope.
+func (s DataScope) Key() string {
+	if s.IsGlobal() {
+		return ""
+	}
+	if s.None {
+		return "none"
+	}
+	return strings.ToLower(s.District) + "|" + s.Unit
+}
+
+type contextKey string
+
+// DataScopeContextKey holds the caller's DataScope in a request context. The HTTP
+// layer sets it as a fiber local, which fasthttp exposes through Context().Value.
+const DataScopeContextKey contextKey = "dataScope"
+
+func WithDataScope(ctx context.Context, scope DataScope) context.Context {
+	return context.WithValue(ctx, DataScopeContextKey, scope)
+}
+
+// DataScopeFromContext returns the scope of the caller, or the global scope for
+// contexts that carry none such as background jobs.
+func DataScopeFromContext(ctx context.Context) DataScope {
+	if ctx == nil {
+		return DataScope{}
+	}
+	scope, _ := ctx.Value(DataScopeContextKey).(DataScope)
+	return scope
+}
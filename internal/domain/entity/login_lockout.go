@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"building-report-backend/pkg/utils"
+)
+
+// LockoutScope tells whether a lockout applies to an account or to a client address.
+type LockoutScope string
+
+const (
+	LockoutScopeAccount LockoutScope = "ACCOUNT"
+	LockoutScopeIP      LockoutScope = "IP"
+)
+
+// LoginLockout records a temporary login lockout caused by repeated failed attempts.
+type LoginLockout struct {
+	ID             string       `json:"id" gorm:"type:varchar(26);primary_key"`
+	Scope          LockoutScope `json:"scope" gorm:"type:varchar(20);not null"`
+	UserID         string       `json:"user_id,omitempty" gorm:"type:varchar(26);index"`
+	Identifier     string       `json:"identifier" gorm:"type:varchar(255)"`
+	IPAddress      string       `json:"ip_address" gorm:"type:varchar(64);index"`
+	FailedAttempts int          `json:"failed_attempts"`
+	LockedUntil    time.Time    `json:"locked_until"`
+	UnlockedAt     *time.Time   `json:"unlocked_at,omitempty"`
+	UnlockedBy     string       `json:"unlocked_by,omitempty" gorm:"type:varchar(26)"`
+	CreatedAt      time.Time    `json:"created_at" gorm:"index"`
+}
+
+func (LoginLockout) TableName() string {
+	return "login_lockouts"
+}
+
+func (l *LoginLockout) BeforeCreate() {
+	if l.ID == "" {
+		l.ID = utils.GenerateULID()
+	}
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = time.Now()
+	}
+}
@@ -0,0 +1,112 @@
+package entity
+
+// Sector identifies a group of resources guarded by the permission matrix.
+type Sector string
+
+const (
+	SectorReports         Sector = "reports"
+	SectorSpatialPlanning Sector = "spatial-planning"
+	SectorWaterResources  Sector = "water-resources"
+	SectorBinaMarga       Sector = "bina-marga"
+	SectorAgriculture     Sector = "agriculture"
+	SectorRiceFields      Sector = "rice-fields"
+	SectorExecutive       Sector = "executive"
+	SectorUsers           Sector = "users"
+	SectorAuditLogs       Sector = "audit-logs"
+	SectorTrash           Sector = "trash"
+	SectorAPIKeys         Sector = "api-keys"
+)
+
+// Action is an operation a role may perform on a sector.
+type Action string
+
+const (
+	ActionRead         Action = "read"
+	ActionCreate       Action = "create"
+	ActionUpdate       Action = "update"
+	ActionUpdateStatus Action = "update_status"
+	ActionDelete       Action = "delete"
+)
+
+// RoleUser, the role of self-registered accounts, is deliberately in none of
+// these: it can sign in and manage its own account but reads no sector until
+// an administrator assigns a real role.
+var (
+	readerRoles     = []UserRole{RoleViewer, RoleExecutive, RoleOperator, RoleSupervisor, RoleAdmin, RoleSuperAdmin}
+	operatorRoles   = []UserRole{RoleOperator, RoleSupervisor, RoleAdmin, RoleSuperAdmin}
+	supervisorRoles = []UserRole{RoleSupervisor, RoleAdmin, RoleSuperAdmin}
+	adminRoles      = []UserRole{RoleAdmin, RoleSuperAdmin}
+	superAdminRoles = []UserRole{RoleSuperAdmin}
+)
+
+// reportSectorPermissions is shared by every sector that holds field reports:
+// viewers read, operators create and edit, supervisors approve and change status.
+// Delete is open to operators because ownership is checked in the use case.
+var reportSectorPermissions = map[Action][]UserRole{
+	ActionRead:         readerRoles,
+	ActionCreate:       operatorRoles,
+	ActionUpdate:       operatorRoles,
+	ActionUpdateStatus: supervisorRoles,
+	ActionDelete:       operatorRoles,
+}
+
+// PermissionMatrix is the declarative sector × action × role table applied by the router.
+var PermissionMatrix = map[Sector]map[Action][]UserRole{
+	SectorReports:         reportSectorPermissions,
+	SectorSpatialPlanning: reportSectorPermissions,
+	SectorWaterResources:  reportSectorPermissions,
+	SectorBinaMarga:       reportSectorPermissions,
+	SectorAgriculture:     reportSectorPermissions,
+	SectorRiceFields: {
+		ActionRead:   readerRoles,
+		ActionCreate: operatorRoles,
+		ActionUpdate: operatorRoles,
+		ActionDelete: adminRoles,
+	},
+	SectorExecutive: {
+		ActionRead: readerRoles,
+	},
+	SectorAuditLogs: {
+		ActionRead: supervisorRoles,
+	},
+	// Restoring a report from the trash counts as an update.
+	SectorTrash: {
+		ActionRead:   adminRoles,
+		ActionUpdate: adminRoles,
+	},
+	SectorAPIKeys: {
+		ActionRead:   adminRoles,
+		ActionCreate: adminRoles,
+		ActionDelete: adminRoles,
+	},
+	SectorUsers: {
+		ActionRead:   superAdminRoles,
+		ActionCreate: superAdminRoles,
+		ActionUpdate: superAdminRoles,
+		ActionDelete: superAdminRoles,
+	},
+}
+
+// HasPermission reports whether role may perform action on sector.
+func HasPermission(role UserRole, sector Sector, action Action) bool {
+	for _, allowed := range PermissionMatrix[sector][action] {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSupervisorOrAbove reports whether the role can act on records owned by other users.
+func (r UserRole) IsSupervisorOrAbove() bool {
+	return r == RoleSupervisor || r == RoleAdmin || r == RoleSuperAdmin
+}
+
+// CanManageReport reports whether a user may edit or delete a report created by ownerID.
+// Reports without an owner (created before ownership was recorded) need a supervisor.
+func CanManageReport(ownerID, userID string, role UserRole) bool {
+	if role.IsSupervisorOrAbove() {
+		return true
+	}
+	return ownerID != "" && ownerID == userID
+}
@@ -0,0 +1,71 @@
+package entity
+
+import "testing"
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		name   string
+		role   UserRole
+		sector Sector
+		action Action
+		want   bool
+	}{
+		{"viewer reads reports", RoleViewer, SectorReports, ActionRead, true},
+		{"viewer cannot create reports", RoleViewer, SectorReports, ActionCreate, false},
+		{"operator creates water reports", RoleOperator, SectorWaterResources, ActionCreate, true},
+		{"operator cannot change status", RoleOperator, SectorBinaMarga, ActionUpdateStatus, false},
+		{"supervisor changes status", RoleSupervisor, SectorSpatialPlanning, ActionUpdateStatus, true},
+		{"self-registered user reads nothing", RoleUser, SectorReports, ActionRead, false},
+		{"operator cannot delete rice fields", RoleOperator, SectorRiceFields, ActionDelete, false},
+		{"admin deletes rice fields", RoleAdmin, SectorRiceFields, ActionDelete, true},
+		{"executive reads the dashboard", RoleExecutive, SectorExecutive, ActionRead, true},
+		{"operator cannot read audit logs", RoleOperator, SectorAuditLogs, ActionRead, false},
+		{"supervisor reads audit logs", RoleSupervisor, SectorAuditLogs, ActionRead, true},
+		{"supervisor cannot read the trash", RoleSupervisor, SectorTrash, ActionRead, false},
+		{"admin restores from the trash", RoleAdmin, SectorTrash, ActionUpdate, true},
+		{"admin creates API keys", RoleAdmin, SectorAPIKeys, ActionCreate, true},
+		{"admin cannot manage users", RoleAdmin, SectorUsers, ActionRead, false},
+		{"superadmin manages users", RoleSuperAdmin, SectorUsers, ActionDelete, true},
+		{"action missing from the sector", RoleSuperAdmin, SectorExecutive, ActionDelete, false},
+		{"unknown sector", RoleSuperAdmin, Sector("unknown"), ActionRead, false},
+		{"unknown role", UserRole("GUEST"), SectorReports, ActionRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasPermission(tt.role, tt.sector, tt.action); got != tt.want {
+				t.Errorf("HasPermission(%s, %s, %s) = %v, want %v", tt.role, tt.sector, tt.action, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIKeyScopesAllows(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes APIKeyScopes
+		sector Sector
+		action Action
+		want   bool
+	}{
+		{"read scope reads", APIKeyScopes{"agriculture:read"}, SectorAgriculture, ActionRead, true},
+		{"read scope cannot create", APIKeyScopes{"agriculture:read"}, SectorAgriculture, ActionCreate, false},
+		{"write scope reads", APIKeyScopes{"agriculture:write"}, SectorAgriculture, ActionRead, true},
+		{"write scope deletes", APIKeyScopes{"agriculture:write"}, SectorAgriculture, ActionDelete, true},
+		{"write scope changes status", APIKeyScopes{"bina-marga:write"}, SectorBinaMarga, ActionUpdateStatus, true},
+		{"scope of another sector", APIKeyScopes{"agriculture:write"}, SectorBinaMarga, ActionRead, false},
+		{"second scope matches", APIKeyScopes{"executive:read", "rice-fields:write"}, SectorRiceFields, ActionUpdate, true},
+		{"unknown level", APIKeyScopes{"agriculture:admin"}, SectorAgriculture, ActionRead, false},
+		{"scope without level", APIKeyScopes{"agriculture"}, SectorAgriculture, ActionRead, false},
+		{"sector prefix is not a match", APIKeyScopes{"agri:write"}, SectorAgriculture, ActionRead, false},
+		{"no scopes", nil, SectorAgriculture, ActionRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scopes.Allows(tt.sector, tt.action); got != tt.want {
+				t.Errorf("%v.Allows(%s, %s) = %v, want %v", tt.scopes, tt.sector, tt.action, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// PhotoMeta is what the upload pipeline records about a stored photo besides
+// its URL. It is embedded in every sector's photo entity, so its fields are
+// columns of each photo table. Photos uploaded before the pipeline existed
+// have empty variant URLs and zero dimensions.
+//
+// Like the photo URL itself, the variant URLs hold object names in the
+// database and are replaced by URLs the client can load in responses.
+type PhotoMeta struct {
+	ThumbnailURL string `json:"thumbnail_url" gorm:"size:500;not null;default:''"`
+	MediumURL    string `json:"medium_url" gorm:"size:500;not null;default:''"`
+	// Width and Height are the stored original's, after EXIF orientation.
+	Width  int `json:"width" gorm:"not null;default:0"`
+	Height int `json:"height" gorm:"not null;default:0"`
+	// TakenAt and the GPS coordinates come from the camera's EXIF data, which
+	// is otherwise stripped from every stored image.
+	TakenAt      *time.Time `json:"taken_at,omitempty"`
+	GPSLatitude  *float64   `json:"gps_latitude,omitempty" gorm:"type:decimal(10,8)"`
+	GPSLongitude *float64   `json:"gps_longitude,omitempty" gorm:"type:decimal(11,8)"`
+}
+
+// FillThumbnail makes the original the thumbnail of a photo that has no
+// variants, so list views always have a thumbnail URL to show.
+func (m *PhotoMeta) FillThumbnail(photoURL string) {
+	if m.ThumbnailURL == "" {
+		m.ThumbnailURL = photoURL
+	}
+}
+
+// RepairPhase marks the photos documenting a repair. Photos taken when the
+// damage was reported have no phase.
+type RepairPhase string
+
+const (
+	RepairPhaseBefore RepairPhase = "BEFORE"
+	RepairPhaseAfter  RepairPhase = "AFTER"
+)
+
+// PhotoPlacement is where a photo sits in its report: its place in the list of
+// photos and, for photos of a repair, the phase they show and the status the
+// report moved to when they were attached. Like PhotoMeta it is embedded in
+// every sector's photo entity.
+type PhotoPlacement struct {
+	Position     int         `json:"position" gorm:"not null;default:0"`
+	RepairPhase  RepairPhase `json:"repair_phase,omitempty" gorm:"type:varchar(20);not null;default:''"`
+	LinkedStatus string      `json:"linked_status,omitempty" gorm:"type:varchar(50);not null;default:''"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"building-report-backend/pkg/utils"
+)
+
+// RecoveryCode is a single-use code that replaces a TOTP code when the user has
+// lost their authenticator. Only the SHA-256 hash is stored.
+type RecoveryCode struct {
+	ID        string     `json:"id" gorm:"type:varchar(26);primary_key"`
+	UserID    string     `json:"user_id" gorm:"type:varchar(26);not null;index"`
+	CodeHash  string     `json:"-" gorm:"type:varchar(64);not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
+
+func (r *RecoveryCode) BeforeCreate() {
+	if r.ID == "" {
+		r.ID = utils.GenerateULID()
+	}
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now()
+	}
+}
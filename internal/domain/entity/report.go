@@ -4,6 +4,8 @@ package entity
 import (
     "time"
     "building-report-backend/pkg/utils"
+
+    "gorm.io/gorm"
 )
 
 type Report struct {
@@ -25,9 +27,12 @@ type Report struct {
     WorkType              *WorkType              `json:"work_type,omitempty" gorm:"type:varchar(50)"`
     ConditionAfterRehab   *ConditionAfterRehab  `json:"condition_after_rehab,omitempty" gorm:"type:varchar(100)"`
     Photos                []ReportPhoto          `json:"photos" gorm:"foreignKey:ReportID"`
-    // CreatedBy             string                 `json:"created_by" gorm:"type:varchar(26);not null"`
+    Unit                  string                 `json:"unit,omitempty" gorm:"type:varchar(100);not null;default:''"`
+    CreatedBy             string                 `json:"created_by,omitempty" gorm:"type:varchar(26);index"`
+    UpdatedBy             string                 `json:"updated_by,omitempty" gorm:"type:varchar(26)"`
     CreatedAt             time.Time              `json:"created_at" gorm:"not null"`
     UpdatedAt             time.Time              `json:"updated_at" gorm:"not null"`
+    DeletedAt             gorm.DeletedAt         `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 type ReportPhoto struct {
@@ -35,7 +40,10 @@ type ReportPhoto struct {
     ReportID   string    `json:"report_id" gorm:"type:varchar(26);not null"`
     PhotoURL   string    `json:"photo_url" gorm:"not null;size:500"`
     PhotoType  string    `json:"photo_type" gorm:"type:varchar(50)"`
+    Caption    string    `json:"caption" gorm:"type:varchar(255)"`
     CreatedAt  time.Time `json:"created_at" gorm:"not null"`
+    PhotoPlacement
+    PhotoMeta
 }
 
 func (r *Report) BeforeCreate() {
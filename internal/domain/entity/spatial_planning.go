@@ -3,6 +3,8 @@ package entity
 import (
 	"building-report-backend/pkg/utils"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type SpatialPlanningReport struct {
@@ -23,17 +25,25 @@ type SpatialPlanningReport struct {
     Photos                 []SpatialPlanningPhoto       `json:"photos" gorm:"foreignKey:ReportID"`
     Status                 SpatialReportStatus          `json:"status" gorm:"type:varchar(50);default:'PENDING'"`
     Notes                  string                       `json:"notes" gorm:"type:text"`
-    // CreatedBy              string                       `json:"created_by" gorm:"type:varchar(26);not null"`
+    District               string                       `json:"district,omitempty" gorm:"type:varchar(100);not null;default:''"`
+    Unit                   string                       `json:"unit,omitempty" gorm:"type:varchar(100);not null;default:''"`
+    CreatedBy              string                       `json:"created_by,omitempty" gorm:"type:varchar(26);index"`
+    UpdatedBy              string                       `json:"updated_by,omitempty" gorm:"type:varchar(26)"`
+    PriorityScore          int                          `json:"priority_score" gorm:"not null;default:0"`
     CreatedAt              time.Time                    `json:"created_at"`
     UpdatedAt              time.Time                    `json:"updated_at"`
+    DeletedAt              gorm.DeletedAt               `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 type SpatialPlanningPhoto struct {
-	ID        string    `json:"id" gorm:"type:varchar(26);primary_key"`
-	ReportID  string    `json:"report_id" gorm:"type:varchar(26);not null"`
-	PhotoURL  string    `json:"photo_url" gorm:"not null"`
-	Caption   string    `json:"caption" gorm:"type:varchar(255)"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         string    `json:"id" gorm:"type:varchar(26);primary_key"`
+	ReportID   string    `json:"report_id" gorm:"type:varchar(26);not null"`
+	PhotoURL   string    `json:"photo_url" gorm:"not null"`
+	PhotoAngle string    `json:"photo_angle" gorm:"type:varchar(50)"`
+	Caption    string    `json:"caption" gorm:"type:varchar(255)"`
+	CreatedAt  time.Time `json:"created_at"`
+	PhotoPlacement
+	PhotoMeta
 }
 
 func (SpatialPlanningReport) TableName() string {
@@ -62,3 +72,31 @@ func (rp *SpatialPlanningPhoto) BeforeCreate() {
 	}
 	rp.CreatedAt = time.Now()
 }
+
+func (r *SpatialPlanningReport) CalculatePriority() int {
+	priority := 0
+
+	if r.UrgencyLevel == UrgencyMendesak {
+		priority += 100
+	}
+
+	switch r.ViolationLevel {
+	case ViolationBerat:
+		priority += 50
+	case ViolationSedang:
+		priority += 25
+	case ViolationRingan:
+		priority += 10
+	}
+
+	switch r.EnvironmentalImpact {
+	case ImpactBanjirLongsor:
+		priority += 30
+	case ImpactGangguanAktivitas:
+		priority += 20
+	case ImpactKualitasRuang:
+		priority += 10
+	}
+
+	return priority
+}
@@ -0,0 +1,111 @@
+package entity
+
+// Report status workflow. A report moves PENDING → VERIFIED → IN_PROGRESS →
+// COMPLETED, and may be rejected at any point before it is completed.
+// COMPLETED and REJECTED are terminal.
+
+var waterResourceTransitions = map[WaterResourceStatus][]WaterResourceStatus{
+	WaterResourceStatusPending:    {WaterResourceStatusVerified, WaterResourceStatusRejected},
+	WaterResourceStatusVerified:   {WaterResourceStatusInProgress, WaterResourceStatusPostponed, WaterResourceStatusRejected},
+	WaterResourceStatusPostponed:  {WaterResourceStatusInProgress, WaterResourceStatusRejected},
+	WaterResourceStatusInProgress: {WaterResourceStatusCompleted, WaterResourceStatusPostponed, WaterResourceStatusRejected},
+}
+
+// CanTransitionTo reports whether a water resources report may move from s to next.
+func (s WaterResourceStatus) CanTransitionTo(next WaterResourceStatus) bool {
+	for _, allowed := range waterResourceTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen reports whether the report still needs work.
+func (s WaterResourceStatus) IsOpen() bool {
+	return s != WaterResourceStatusCompleted && s != WaterResourceStatusRejected
+}
+
+// Bina marga adds an optional PLANNED step between VERIFIED and IN_PROGRESS
+// for repairs that have to wait for budget or scheduling.
+var binaMargaTransitions = map[BinaMargaStatus][]BinaMargaStatus{
+	BinaMargaStatusPending:    {BinaMargaStatusVerified, BinaMargaStatusRejected},
+	BinaMargaStatusVerified:   {BinaMargaStatusPlanned, BinaMargaStatusInProgress, BinaMargaStatusPostponed, BinaMargaStatusRejected},
+	BinaMargaStatusPlanned:    {BinaMargaStatusInProgress, BinaMargaStatusPostponed, BinaMargaStatusRejected},
+	BinaMargaStatusPostponed:  {BinaMargaStatusPlanned, BinaMargaStatusInProgress, BinaMargaStatusRejected},
+	BinaMargaStatusInProgress: {BinaMargaStatusCompleted, BinaMargaStatusPostponed, BinaMargaStatusRejected},
+}
+
+// CanTransitionTo reports whether a bina marga report may move from s to next.
+func (s BinaMargaStatus) CanTransitionTo(next BinaMargaStatus) bool {
+	for _, allowed := range binaMargaTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen reports whether the report still needs work.
+func (s BinaMargaStatus) IsOpen() bool {
+	return s != BinaMargaStatusCompleted && s != BinaMargaStatusRejected
+}
+
+// Spatial planning uses its own names for the same steps:
+// REVIEWING is verification, PROCESSING is in progress and RESOLVED is completed.
+var spatialReportTransitions = map[SpatialReportStatus][]SpatialReportStatus{
+	SpatialStatusPending:    {SpatialStatusReviewing, SpatialStatusRejected},
+	SpatialStatusReviewing:  {SpatialStatusProcessing, SpatialStatusRejected},
+	SpatialStatusProcessing: {SpatialStatusResolved, SpatialStatusRejected},
+}
+
+// CanTransitionTo reports whether a spatial planning report may move from s to next.
+func (s SpatialReportStatus) CanTransitionTo(next SpatialReportStatus) bool {
+	for _, allowed := range spatialReportTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen reports whether the report still needs work.
+func (s SpatialReportStatus) IsOpen() bool {
+	return s != SpatialStatusResolved && s != SpatialStatusRejected
+}
+
+// Photos attached with a status change document the repair: starting work
+// takes the before set, completing it the after set. Other changes take none.
+
+// RepairPhase returns the photo set a change to s documents, if any.
+func (s WaterResourceStatus) RepairPhase() (RepairPhase, bool) {
+	switch s {
+	case WaterResourceStatusInProgress:
+		return RepairPhaseBefore, true
+	case WaterResourceStatusCompleted:
+		return RepairPhaseAfter, true
+	}
+	return "", false
+}
+
+// RepairPhase returns the photo set a change to s documents, if any.
+func (s BinaMargaStatus) RepairPhase() (RepairPhase, bool) {
+	switch s {
+	case BinaMargaStatusInProgress:
+		return RepairPhaseBefore, true
+	case BinaMargaStatusCompleted:
+		return RepairPhaseAfter, true
+	}
+	return "", false
+}
+
+// RepairPhase returns the photo set a change to s documents, if any.
+func (s SpatialReportStatus) RepairPhase() (RepairPhase, bool) {
+	switch s {
+	case SpatialStatusProcessing:
+		return RepairPhaseBefore, true
+	case SpatialStatusResolved:
+		return RepairPhaseAfter, true
+	}
+	return "", false
+}
@@ -0,0 +1,84 @@
+package entity
+
+import "testing"
+
+func TestWaterResourceStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to WaterResourceStatus
+		want     bool
+	}{
+		{WaterResourceStatusPending, WaterResourceStatusVerified, true},
+		{WaterResourceStatusPending, WaterResourceStatusRejected, true},
+		{WaterResourceStatusPending, WaterResourceStatusInProgress, false},
+		{WaterResourceStatusPending, WaterResourceStatusCompleted, false},
+		{WaterResourceStatusVerified, WaterResourceStatusInProgress, true},
+		{WaterResourceStatusVerified, WaterResourceStatusPostponed, true},
+		{WaterResourceStatusVerified, WaterResourceStatusCompleted, false},
+		{WaterResourceStatusPostponed, WaterResourceStatusInProgress, true},
+		{WaterResourceStatusInProgress, WaterResourceStatusCompleted, true},
+		{WaterResourceStatusInProgress, WaterResourceStatusPending, false},
+		{WaterResourceStatusCompleted, WaterResourceStatusRejected, false},
+		{WaterResourceStatusRejected, WaterResourceStatusPending, false},
+		{WaterResourceStatusPending, WaterResourceStatusPending, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBinaMargaStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to BinaMargaStatus
+		want     bool
+	}{
+		{BinaMargaStatusPending, BinaMargaStatusVerified, true},
+		{BinaMargaStatusPending, BinaMargaStatusPlanned, false},
+		{BinaMargaStatusVerified, BinaMargaStatusPlanned, true},
+		{BinaMargaStatusVerified, BinaMargaStatusInProgress, true},
+		{BinaMargaStatusPlanned, BinaMargaStatusInProgress, true},
+		{BinaMargaStatusPlanned, BinaMargaStatusCompleted, false},
+		{BinaMargaStatusPostponed, BinaMargaStatusPlanned, true},
+		{BinaMargaStatusInProgress, BinaMargaStatusCompleted, true},
+		{BinaMargaStatusInProgress, BinaMargaStatusPlanned, false},
+		{BinaMargaStatusCompleted, BinaMargaStatusInProgress, false},
+		{BinaMargaStatusRejected, BinaMargaStatusVerified, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpatialReportStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to SpatialReportStatus
+		want     bool
+	}{
+		{SpatialStatusPending, SpatialStatusReviewing, true},
+		{SpatialStatusPending, SpatialStatusProcessing, false},
+		{SpatialStatusPending, SpatialStatusRejected, true},
+		{SpatialStatusReviewing, SpatialStatusProcessing, true},
+		{SpatialStatusReviewing, SpatialStatusResolved, false},
+		{SpatialStatusProcessing, SpatialStatusResolved, true},
+		{SpatialStatusProcessing, SpatialStatusReviewing, false},
+		{SpatialStatusResolved, SpatialStatusRejected, false},
+		{SpatialStatusRejected, SpatialStatusPending, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package entity
+
+import "time"
+
+type UploadSessionStatus string
+
+const (
+	// UploadSessionPending sessions wait for the client to POST its files to
+	// the presigned URLs.
+	UploadSessionPending UploadSessionStatus = "PENDING"
+	// UploadSessionConfirmed sessions have had every file verified and run
+	// through the photo pipeline; the photos are not on a report yet.
+	UploadSessionConfirmed UploadSessionStatus = "CONFIRMED"
+	// UploadSessionAttached sessions have been used by a report.
+	UploadSessionAttached UploadSessionStatus = "ATTACHED"
+)
+
+// UploadSession is a batch of photos a client uploads straight to object
+// storage before creating a report with them. Files are first written to a
+// staging object under uploads/; confirming the session replaces each with
+// the processed photo and its variants.
+type UploadSession struct {
+	ID        string              `json:"id" gorm:"type:varchar(26);primary_key"`
+	UserID    string              `json:"user_id" gorm:"type:varchar(26);not null;index"`
+	Sector    Sector              `json:"sector" gorm:"type:varchar(50);not null"`
+	Status    UploadSessionStatus `json:"status" gorm:"type:varchar(20);not null"`
+	ReportID  *string             `json:"report_id,omitempty" gorm:"type:varchar(26)"`
+	ExpiresAt time.Time           `json:"expires_at" gorm:"not null"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	Files     []UploadSessionFile `json:"files" gorm:"foreignKey:SessionID"`
+}
+
+func (UploadSession) TableName() string {
+	return "upload_sessions"
+}
+
+// IsExpired reports whether the session can no longer be confirmed or used.
+func (s *UploadSession) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// UploadSessionFile is one photo of an upload session. ObjectName is the
+// staging object the client uploads to; PhotoURL and PhotoMeta are filled in
+// when the session is confirmed.
+type UploadSessionFile struct {
+	ID          string `json:"id" gorm:"type:varchar(26);primary_key"`
+	SessionID   string `json:"session_id" gorm:"type:varchar(26);not null;index"`
+	Position    int    `json:"position" gorm:"not null"`
+	FileName    string `json:"file_name" gorm:"type:varchar(255);not null"`
+	ContentType string `json:"content_type" gorm:"type:varchar(100);not null"`
+	Size        int64  `json:"size" gorm:"not null"`
+	ObjectName  string `json:"-" gorm:"size:500;not null"`
+	PhotoURL    string `json:"photo_url,omitempty" gorm:"size:500;not null;default:''"`
+	PhotoMeta
+}
+
+func (UploadSessionFile) TableName() string {
+	return "upload_session_files"
+}
@@ -8,14 +8,26 @@ import (
 )
 
 type User struct {
-    ID        string    `json:"id" gorm:"type:varchar(26);primary_key"`
-    Username  string    `json:"username" gorm:"unique;not null;size:50"`
-    Email     string    `json:"email" gorm:"unique;not null;size:100"`
-    Password  string    `json:"-" gorm:"not null;size:255"`
-    Role      UserRole  `json:"role" gorm:"type:varchar(20);not null;default:'USER'"`
-    IsActive  bool      `json:"is_active" gorm:"default:true;not null"`
-    CreatedAt time.Time `json:"created_at" gorm:"not null"`
-    UpdatedAt time.Time `json:"updated_at" gorm:"not null"`
+    ID                 string     `json:"id" gorm:"type:varchar(26);primary_key"`
+    Username           string     `json:"username" gorm:"unique;not null;size:50"`
+    Email              string     `json:"email" gorm:"unique;not null;size:100"`
+    Password           string     `json:"-" gorm:"not null;size:255"`
+    Role               UserRole   `json:"role" gorm:"type:varchar(20);not null;default:'USER'"`
+    IsActive           bool       `json:"is_active" gorm:"default:true;not null"`
+    MustChangePassword bool       `json:"must_change_password" gorm:"default:false;not null"`
+    PasswordChangedAt  *time.Time `json:"password_changed_at,omitempty"`
+    AuthProvider       string     `json:"auth_provider" gorm:"size:20;not null;default:'local'"`
+    ExternalSubject    *string    `json:"-" gorm:"size:255"`
+    TwoFactorEnabled   bool       `json:"two_factor_enabled" gorm:"default:false;not null"`
+    // TwoFactorSecret is the TOTP seed, encrypted with auth.SecretBox.
+    TwoFactorSecret    string     `json:"-" gorm:"size:255"`
+    TwoFactorEnabledAt *time.Time `json:"two_factor_enabled_at,omitempty"`
+    // District and Unit restrict the reports the user can see. Only roles
+    // with a global view ignore them; for other roles empty means no reports.
+    District           string     `json:"district,omitempty" gorm:"size:100"`
+    Unit               string     `json:"unit,omitempty" gorm:"size:100"`
+    CreatedAt          time.Time  `json:"created_at" gorm:"not null"`
+    UpdatedAt          time.Time  `json:"updated_at" gorm:"not null"`
 }
 
 type UserRole string
@@ -27,6 +39,14 @@ const (
     RoleSupervisor UserRole = "SUPERVISOR"
     RoleOperator   UserRole = "OPERATOR"
     RoleViewer     UserRole = "VIEWER"
+    // RoleExecutive reads the dashboards of every district.
+    RoleExecutive  UserRole = "EXECUTIVE"
+)
+
+// Auth providers a user can sign in with. SSO users get an unusable random password.
+const (
+    AuthProviderLocal = "local"
+    AuthProviderOIDC  = "oidc"
 )
 
 func (u *User) BeforeCreate() error {
@@ -58,4 +78,29 @@ func (u *User) ComparePassword(password string) bool {
 
 func (u *User) IsSuperAdmin() bool {
     return u.Role == RoleSuperAdmin
-}
\ No newline at end of file
+}
+
+// HasGlobalScope reports whether the role sees every district and unit.
+func (r UserRole) HasGlobalScope() bool {
+    return r == RoleSuperAdmin || r == RoleExecutive
+}
+
+// DataScope is the part of the data the user works on. Superadmins and
+// executives are always global; any other user without a district or unit
+// sees nothing until one is assigned.
+func (u *User) DataScope() DataScope {
+    if u.Role.HasGlobalScope() {
+        return DataScope{}
+    }
+    if u.District == "" && u.Unit == "" {
+        return DataScope{None: true}
+    }
+    return DataScope{District: u.District, Unit: u.Unit}
+}
+func (r UserRole) IsValid() bool {
+    switch r {
+    case RoleSuperAdmin, RoleAdmin, RoleSupervisor, RoleOperator, RoleViewer, RoleExecutive, RoleUser:
+        return true
+    }
+    return false
+}
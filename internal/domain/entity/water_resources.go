@@ -3,6 +3,8 @@ package entity
 import (
 	"building-report-backend/pkg/utils"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type WaterResourcesReport struct {
@@ -30,9 +32,14 @@ type WaterResourcesReport struct {
     Notes                  string                   `json:"notes" gorm:"type:text"`
     HandlingRecommendation string                   `json:"handling_recommendation" gorm:"type:text"`
     EstimatedBudget        float64                  `json:"estimated_budget"`
-    // CreatedBy              string                   `json:"created_by" gorm:"type:varchar(26);not null"`
+    District               string                   `json:"district,omitempty" gorm:"type:varchar(100);not null;default:''"`
+    Unit                   string                   `json:"unit,omitempty" gorm:"type:varchar(100);not null;default:''"`
+    CreatedBy              string                   `json:"created_by,omitempty" gorm:"type:varchar(26);index"`
+    UpdatedBy              string                   `json:"updated_by,omitempty" gorm:"type:varchar(26)"`
+    PriorityScore          int                      `json:"priority_score" gorm:"not null;default:0"`
     CreatedAt              time.Time                `json:"created_at"`
     UpdatedAt              time.Time                `json:"updated_at"`
+    DeletedAt              gorm.DeletedAt           `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 type WaterResourcesPhoto struct {
@@ -42,6 +49,8 @@ type WaterResourcesPhoto struct {
 	PhotoAngle string    `json:"photo_angle" gorm:"type:varchar(50)"`
 	Caption    string    `json:"caption" gorm:"type:varchar(255)"`
 	CreatedAt  time.Time `json:"created_at"`
+	PhotoPlacement
+	PhotoMeta
 }
 
 func (WaterResourcesReport) TableName() string {
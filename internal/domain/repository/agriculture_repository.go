@@ -14,6 +14,14 @@ type AgricultureRepository interface {
     FindByID(ctx context.Context, id string) (*entity.AgricultureReport, error)
     FindAll(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]*entity.AgricultureReport, int64, error)
     FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.AgricultureReport, int64, error)
+    FindDeleted(ctx context.Context, limit, offset int) ([]*entity.AgricultureReport, int64, error)
+    FindDeletedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entity.AgricultureReport, error)
+    Restore(ctx context.Context, id string) error
+    HardDelete(ctx context.Context, id string) error
+    AddPhotos(ctx context.Context, reportID string, photos []entity.AgriculturePhoto, max int) error
+    UpdatePhoto(ctx context.Context, photo *entity.AgriculturePhoto) error
+    DeletePhoto(ctx context.Context, reportID, photoID string) error
+    ReorderPhotos(ctx context.Context, reportID string, photoIDs []string) error
     FindByExtensionOfficer(ctx context.Context, extensionOfficer string, limit, offset int) ([]*entity.AgricultureReport, int64, error)
     FindByVillage(ctx context.Context, village string, limit, offset int) ([]*entity.AgricultureReport, int64, error)
     FindByDateRange(ctx context.Context, startDate, endDate time.Time, limit, offset int) ([]*entity.AgricultureReport, int64, error)
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"context"
+	"time"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entity.APIKey) error
+	FindByID(ctx context.Context, id string) (*entity.APIKey, error)
+	FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+	FindAll(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*entity.APIKey, int64, error)
+	Revoke(ctx context.Context, id, revokedBy string, revokedAt time.Time) error
+	TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error
+}
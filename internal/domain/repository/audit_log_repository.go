@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"context"
+)
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *entity.AuditLog) error
+	FindByReport(ctx context.Context, sector entity.Sector, reportID string, limit, offset int) ([]*entity.AuditLog, int64, error)
+	Search(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*entity.AuditLog, int64, error)
+}
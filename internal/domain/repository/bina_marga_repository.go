@@ -14,10 +14,18 @@ type BinaMargaRepository interface {
     FindByID(ctx context.Context, id string) (*entity.BinaMargaReport, error)
     FindAll(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]*entity.BinaMargaReport, int64, error)
     FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.BinaMargaReport, int64, error)
+    FindDeleted(ctx context.Context, limit, offset int) ([]*entity.BinaMargaReport, int64, error)
+    FindDeletedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entity.BinaMargaReport, error)
+    Restore(ctx context.Context, id string) error
+    HardDelete(ctx context.Context, id string) error
+    AddPhotos(ctx context.Context, reportID string, photos []entity.BinaMargaPhoto, max int) error
+    UpdatePhoto(ctx context.Context, photo *entity.BinaMargaPhoto) error
+    DeletePhoto(ctx context.Context, reportID, photoID string) error
+    ReorderPhotos(ctx context.Context, reportID string, photoIDs []string) error
     FindByPriority(ctx context.Context, limit, offset int) ([]*entity.BinaMargaReport, int64, error)
     FindEmergencyReports(ctx context.Context, limit int) ([]*entity.BinaMargaReport, error)
     FindBlockedRoads(ctx context.Context, limit int) ([]*entity.BinaMargaReport, error)
-    UpdateStatus(ctx context.Context, id string, status entity.BinaMargaStatus, notes string) error
+    UpdateStatus(ctx context.Context, id string, from, to entity.BinaMargaStatus, notes, updatedBy string) error
     GetStatistics(ctx context.Context) (map[string]interface{}, error)
     GetDamageStatisticsByRoadType(ctx context.Context, startDate, endDate time.Time) ([]map[string]interface{}, error)
     GetDamageStatisticsByLocation(ctx context.Context, bounds map[string]float64) ([]map[string]interface{}, error)
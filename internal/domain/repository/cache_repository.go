@@ -3,13 +3,28 @@ package repository
 
 import (
     "context"
+    "errors"
     "time"
 )
 
+// ErrCacheMiss is returned by Get and GetDel when the key does not exist.
+var ErrCacheMiss = errors.New("cache miss")
+
 type CacheRepository interface {
     Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
     Get(ctx context.Context, key string, dest interface{}) error
+    // SetNX sets key only if it does not exist yet and reports whether it did.
+    // Unlike Set it returns the error, as callers use it to claim something once.
+    SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+    // GetDel reads the value at key into dest and deletes the key in one
+    // atomic step, so of two concurrent callers only one gets the value.
+    GetDel(ctx context.Context, key string, dest interface{}) error
     Delete(ctx context.Context, keys ...string) error
+    // Exists returns an error when the cache cannot be reached, so a failure
+    // is not mistaken for a missing key.
     Exists(ctx context.Context, key string) (bool, error)
+    // Increment adds one to the counter at key and returns the new value. The
+    // expiration is applied when the counter is created.
+    Increment(ctx context.Context, key string, expiration time.Duration) (int64, error)
     Flush(ctx context.Context) error
 }
\ No newline at end of file
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"context"
+	"time"
+)
+
+type LoginLockoutRepository interface {
+	Create(ctx context.Context, lockout *entity.LoginLockout) error
+	FindAll(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*entity.LoginLockout, int64, error)
+	MarkUserUnlocked(ctx context.Context, userID, unlockedBy string, unlockedAt time.Time) error
+}
@@ -0,0 +1,9 @@
+package repository
+
+import "context"
+
+type MigrationRepository interface {
+	// CurrentVersion returns the version of the last applied goose migration,
+	// or 0 when none has been applied.
+	CurrentVersion(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "errors"
+
+// ErrPhotoLimit is returned when adding photos would take a report over the
+// number of photos it may have.
+var ErrPhotoLimit = errors.New("report photo limit reached")
@@ -0,0 +1,18 @@
+package repository
+
+import "context"
+
+// PhotoReference is a database row that refers to stored photo objects: a
+// report photo of any sector, including reports in the trash, or a file of an
+// upload session. Keys holds the original and its variants as stored.
+type PhotoReference struct {
+	Table string
+	ID    string
+	Keys  []string
+}
+
+// PhotoReferenceRepository lists the photo references across the sectors, for
+// reconciling the database with object storage.
+type PhotoReferenceRepository interface {
+	FindAll(ctx context.Context) ([]PhotoReference, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"context"
+	"time"
+)
+
+type RecoveryCodeRepository interface {
+	// ReplaceForUser deletes the user's codes and stores the given ones in their place.
+	ReplaceForUser(ctx context.Context, userID string, codes []*entity.RecoveryCode) error
+	// Consume marks an unused code as used and reports whether one matched.
+	Consume(ctx context.Context, userID, codeHash string, usedAt time.Time) (bool, error)
+	CountUnused(ctx context.Context, userID string) (int64, error)
+	DeleteForUser(ctx context.Context, userID string) error
+}
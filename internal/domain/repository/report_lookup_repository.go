@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"context"
+)
+
+// ReportLookupRepository checks reports of any sector by ID, within the data
+// scope of the caller's context.
+type ReportLookupRepository interface {
+	// Exists reports whether the report is visible in ctx's data scope.
+	// Reports in the trash only count when includeDeleted is set.
+	Exists(ctx context.Context, sector entity.Sector, id string, includeDeleted bool) (bool, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"context"
+)
+
+// ReportMetricsRepository counts reports across the sectors for monitoring.
+type ReportMetricsRepository interface {
+	// CountReports returns the number of reports outside the trash, by sector.
+	CountReports(ctx context.Context) (map[entity.Sector]int64, error)
+	// CountOpenUrgentReports returns the number of reports at the highest
+	// urgency that are not yet completed or rejected, by sector. Only sectors
+	// with an urgency and a status workflow are included.
+	CountOpenUrgentReports(ctx context.Context) (map[entity.Sector]int64, error)
+}
@@ -4,6 +4,7 @@ package repository
 import (
 	"building-report-backend/internal/domain/entity"
 	"context"
+	"time"
 )
 
 type ReportRepository interface {
@@ -13,6 +14,14 @@ type ReportRepository interface {
     FindByID(ctx context.Context, id string) (*entity.Report, error)
     FindAll(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]*entity.Report, int64, error)
     FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.Report, int64, error)
+    FindDeleted(ctx context.Context, limit, offset int) ([]*entity.Report, int64, error)
+    FindDeletedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entity.Report, error)
+    Restore(ctx context.Context, id string) error
+    HardDelete(ctx context.Context, id string) error
+    AddPhotos(ctx context.Context, reportID string, photos []entity.ReportPhoto, max int) error
+    UpdatePhoto(ctx context.Context, photo *entity.ReportPhoto) error
+    DeletePhoto(ctx context.Context, reportID, photoID string) error
+    ReorderPhotos(ctx context.Context, reportID string, photoIDs []string) error
 
     GetStatistics(ctx context.Context, buildingType string) (map[string]interface{}, error)
     GetLocationStatistics(ctx context.Context, buildingType string) ([]map[string]interface{}, error)
@@ -0,0 +1,8 @@
+package repository
+
+import "errors"
+
+// ErrStatusChanged is returned by UpdateStatus when the report no longer has
+// the status the change was made from, because another change came first or
+// the report was deleted.
+var ErrStatusChanged = errors.New("report status changed or report deleted")
@@ -0,0 +1,135 @@
+// Package repositorytest provides in-memory fakes of the repository
+// interfaces for tests.
+package repositorytest
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"building-report-backend/internal/domain/repository"
+)
+
+// Cache is an in-memory repository.CacheRepository. Values are stored as JSON
+// like the Redis implementation stores them; expirations are ignored. Setting
+// Err makes every operation fail with it, as an unreachable cache would.
+type Cache struct {
+	mu     sync.Mutex
+	values map[string][]byte
+	Err    error
+}
+
+var _ repository.CacheRepository = (*Cache)(nil)
+
+func NewCache() *Cache {
+	return &Cache{values: map[string][]byte{}}
+}
+
+func (c *Cache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return c.Err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.values[key] = data
+	return nil
+}
+
+func (c *Cache) Get(ctx context.Context, key string, dest interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return c.Err
+	}
+	data, ok := c.values[key]
+	if !ok {
+		return repository.ErrCacheMiss
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func (c *Cache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return false, c.Err
+	}
+	if _, ok := c.values[key]; ok {
+		return false, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	c.values[key] = data
+	return true, nil
+}
+
+func (c *Cache) GetDel(ctx context.Context, key string, dest interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return c.Err
+	}
+	data, ok := c.values[key]
+	if !ok {
+		return repository.ErrCacheMiss
+	}
+	delete(c.values, key)
+	return json.Unmarshal(data, dest)
+}
+
+func (c *Cache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return c.Err
+	}
+	for _, key := range keys {
+		delete(c.values, key)
+	}
+	return nil
+}
+
+func (c *Cache) Exists(ctx context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return false, c.Err
+	}
+	_, ok := c.values[key]
+	return ok, nil
+}
+
+func (c *Cache) Increment(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return 0, c.Err
+	}
+	var count int64
+	if data, ok := c.values[key]; ok {
+		if err := json.Unmarshal(data, &count); err != nil {
+			return 0, err
+		}
+	}
+	count++
+	c.values[key] = []byte(strconv.FormatInt(count, 10))
+	return count, nil
+}
+
+func (c *Cache) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return c.Err
+	}
+	c.values = map[string][]byte{}
+	return nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
     "context"
+    "time"
     "building-report-backend/internal/domain/entity"
 )
 
@@ -12,7 +13,16 @@ type SpatialPlanningRepository interface {
     FindByID(ctx context.Context, id string) (*entity.SpatialPlanningReport, error)
     FindAll(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]*entity.SpatialPlanningReport, int64, error)
     FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.SpatialPlanningReport, int64, error)
-    UpdateStatus(ctx context.Context, id string, status entity.SpatialReportStatus) error
+    FindDeleted(ctx context.Context, limit, offset int) ([]*entity.SpatialPlanningReport, int64, error)
+    FindDeletedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entity.SpatialPlanningReport, error)
+    Restore(ctx context.Context, id string) error
+    HardDelete(ctx context.Context, id string) error
+    AddPhotos(ctx context.Context, reportID string, photos []entity.SpatialPlanningPhoto, max int) error
+    UpdatePhoto(ctx context.Context, photo *entity.SpatialPlanningPhoto) error
+    DeletePhoto(ctx context.Context, reportID, photoID string) error
+    ReorderPhotos(ctx context.Context, reportID string, photoIDs []string) error
+    FindByPriority(ctx context.Context, limit, offset int) ([]*entity.SpatialPlanningReport, int64, error)
+    UpdateStatus(ctx context.Context, id string, from, to entity.SpatialReportStatus, notes, updatedBy string) error
     CountByStatus(ctx context.Context, status entity.SpatialReportStatus) (int64, error)
     GetStatistics(ctx context.Context) (map[string]interface{}, error)
 
@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+// Transactor runs work in a single database transaction. Repository calls
+// made with the context fn receives take part in it; an error from fn, or a
+// panic, rolls it back.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
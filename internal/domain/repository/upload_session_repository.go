@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"context"
+	"time"
+)
+
+type UploadSessionRepository interface {
+	// Create inserts the session together with its files.
+	Create(ctx context.Context, session *entity.UploadSession) error
+	// FindByID loads the session with its files in position order.
+	FindByID(ctx context.Context, id string) (*entity.UploadSession, error)
+	// Update saves the session's status and report and every file's photo,
+	// provided the stored session is still in status from. Otherwise it
+	// returns gorm.ErrRecordNotFound, so two requests cannot both move a
+	// session on.
+	Update(ctx context.Context, session *entity.UploadSession, from entity.UploadSessionStatus) error
+	// FindExpired returns up to limit sessions that expired before now, with their files.
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]*entity.UploadSession, error)
+	// Delete removes the session and its files if it is still in status, and
+	// returns gorm.ErrRecordNotFound otherwise.
+	Delete(ctx context.Context, id string, status entity.UploadSessionStatus) error
+}
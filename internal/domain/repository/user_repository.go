@@ -14,6 +14,11 @@ type UserRepository interface {
     FindByID(ctx context.Context, id string) (*entity.User, error)
     FindByUsername(ctx context.Context, username string) (*entity.User, error)
     FindByEmail(ctx context.Context, email string) (*entity.User, error)
-    FindAll(ctx context.Context, limit, offset int) ([]*entity.User, int64, error)
+    // FindAll supports the filters q (username or email), role, is_active (bool),
+    // district and unit, sorted by sort_by and sort_order.
+    FindAll(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*entity.User, int64, error)
+    FindByIDs(ctx context.Context, ids []string) ([]*entity.User, error)
+    SetActive(ctx context.Context, ids []string, active bool) (int64, error)
     FindByUsernameOrEmail(ctx context.Context, identifier string) (*entity.User, error)
+    FindByExternalSubject(ctx context.Context, provider, subject string) (*entity.User, error)
 }
\ No newline at end of file
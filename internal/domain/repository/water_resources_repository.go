@@ -15,8 +15,16 @@ type WaterResourcesRepository interface {
     FindByID(ctx context.Context, id string) (*entity.WaterResourcesReport, error)
     FindAll(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]*entity.WaterResourcesReport, int64, error)
     FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.WaterResourcesReport, int64, error)
+    FindDeleted(ctx context.Context, limit, offset int) ([]*entity.WaterResourcesReport, int64, error)
+    FindDeletedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entity.WaterResourcesReport, error)
+    Restore(ctx context.Context, id string) error
+    HardDelete(ctx context.Context, id string) error
+    AddPhotos(ctx context.Context, reportID string, photos []entity.WaterResourcesPhoto, max int) error
+    UpdatePhoto(ctx context.Context, photo *entity.WaterResourcesPhoto) error
+    DeletePhoto(ctx context.Context, reportID, photoID string) error
+    ReorderPhotos(ctx context.Context, reportID string, photoIDs []string) error
     FindByPriority(ctx context.Context, limit, offset int) ([]*entity.WaterResourcesReport, int64, error)
-    UpdateStatus(ctx context.Context, id string, status entity.WaterResourceStatus, notes string) error
+    UpdateStatus(ctx context.Context, id string, from, to entity.WaterResourceStatus, notes, updatedBy string) error
     GetStatistics(ctx context.Context) (map[string]interface{}, error)
     GetDamageStatisticsByArea(ctx context.Context, startDate, endDate time.Time) ([]map[string]interface{}, error)
     GetUrgentReports(ctx context.Context, limit int) ([]*entity.WaterResourcesReport, error)
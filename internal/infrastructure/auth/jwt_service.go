@@ -5,21 +5,45 @@ import (
     "errors"
     "time"
     
+    "building-report-backend/internal/domain/entity"
+    "building-report-backend/pkg/utils"
     "github.com/golang-jwt/jwt/v5"
 )
 
+// Issue times need sub-second precision: a session revocation followed by a new
+// login in the same second must not reject the new token.
+func init() {
+    jwt.TimePrecision = time.Millisecond
+}
+
 type JWTService interface {
-    GenerateToken(userID string, username string, role string) (string, error)
+    GenerateToken(user *entity.User, opts TokenOptions) (string, error)
     ValidateToken(tokenString string) (*JWTClaims, error)
+    TokenExpiry() time.Duration
 }
 
 type JWTClaims struct {
     UserID   string `json:"user_id"`
     Username string `json:"username"`
     Role     string `json:"role"`
+    // MustChangePassword limits the token to the change-password flow.
+    MustChangePassword bool `json:"must_change_password,omitempty"`
+    // MustEnrollTwoFactor limits the token to two-factor enrollment.
+    MustEnrollTwoFactor bool `json:"must_enroll_2fa,omitempty"`
+    // District and Unit are the user's data scope. A token only grants a
+    // global view when GlobalScope says so; with both empty and no
+    // GlobalScope it sees nothing.
+    District    string `json:"district,omitempty"`
+    Unit        string `json:"unit,omitempty"`
+    GlobalScope bool   `json:"global_scope,omitempty"`
     jwt.RegisteredClaims
 }
 
+// TokenOptions carries restrictions decided by policy rather than stored on the user.
+type TokenOptions struct {
+    MustEnrollTwoFactor bool
+}
+
 type jwtService struct {
     secretKey []byte
     expiry    time.Duration
@@ -32,12 +56,19 @@ func NewJWTService(secretKey string, expiryHours int) JWTService {
     }
 }
 
-func (s *jwtService) GenerateToken(userID string, username string, role string) (string, error) {
+func (s *jwtService) GenerateToken(user *entity.User, opts TokenOptions) (string, error) {
+    scope := user.DataScope()
     claims := &JWTClaims{
-        UserID:   userID,
-        Username: username,
-        Role:     role,
+        UserID:              user.ID,
+        Username:            user.Username,
+        Role:                string(user.Role),
+        MustChangePassword:  user.MustChangePassword,
+        MustEnrollTwoFactor: opts.MustEnrollTwoFactor,
+        District:            scope.District,
+        Unit:                scope.Unit,
+        GlobalScope:         scope.IsGlobal(),
         RegisteredClaims: jwt.RegisteredClaims{
+            ID:        utils.GenerateULID(),
             ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.expiry)),
             IssuedAt:  jwt.NewNumericDate(time.Now()),
             NotBefore: jwt.NewNumericDate(time.Now()),
@@ -65,4 +96,19 @@ func (s *jwtService) ValidateToken(tokenString string) (*JWTClaims, error) {
     }
 
     return nil, errors.New("invalid token")
-}
\ No newline at end of file
+}
+
+// DataScope returns the data scope carried by the token.
+func (c *JWTClaims) DataScope() entity.DataScope {
+    if c.GlobalScope {
+        return entity.DataScope{}
+    }
+    if c.District == "" && c.Unit == "" {
+        return entity.DataScope{None: true}
+    }
+    return entity.DataScope{District: c.District, Unit: c.Unit}
+}
+
+func (s *jwtService) TokenExpiry() time.Duration {
+    return s.expiry
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+var ErrSecretDecrypt = errors.New("failed to decrypt secret")
+
+// SecretBox encrypts small secrets that must be readable again, such as TOTP
+// seeds, before they are stored in the database.
+type SecretBox interface {
+	Seal(plaintext string) (string, error)
+	Open(ciphertext string) (string, error)
+}
+
+type aesSecretBox struct {
+	aead cipher.AEAD
+}
+
+// NewSecretBox uses AES-256-GCM with a key derived from passphrase.
+func NewSecretBox(passphrase string) (SecretBox, error) {
+	key := sha256.Sum256([]byte(passphrase))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &aesSecretBox{aead: aead}, nil
+}
+
+func (b *aesSecretBox) Seal(plaintext string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := b.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (b *aesSecretBox) Open(ciphertext string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(raw) < b.aead.NonceSize() {
+		return "", ErrSecretDecrypt
+	}
+	nonce, sealed := raw[:b.aead.NonceSize()], raw[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", ErrSecretDecrypt
+	}
+	return string(plaintext), nil
+}
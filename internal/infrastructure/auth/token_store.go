@@ -0,0 +1,215 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"building-report-backend/internal/domain/repository"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrInvalidResetToken   = errors.New("invalid or expired password reset token")
+)
+
+const (
+	refreshTokenPrefix   = "auth:refresh:"
+	usedRefreshPrefix    = "auth:refresh_used:"
+	revokedTokenPrefix   = "auth:revoked:"
+	sessionsRevokedAtKey = "auth:sessions_revoked_at:"
+	passwordResetPrefix  = "auth:password_reset:"
+)
+
+// RefreshSession is what the server remembers about an issued refresh token.
+type RefreshSession struct {
+	UserID    string    `json:"user_id"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// RefreshTokenReusedError is returned by ConsumeRefreshToken for a refresh
+// token that was already used. Only the holder of a stolen token or the thief
+// can present it a second time, so the user's sessions should be revoked.
+// It matches ErrInvalidRefreshToken with errors.Is.
+type RefreshTokenReusedError struct {
+	UserID string
+}
+
+func (e *RefreshTokenReusedError) Error() string {
+	return fmt.Sprintf("refresh token of user %s was reused", e.UserID)
+}
+
+func (e *RefreshTokenReusedError) Is(target error) bool {
+	return target == ErrInvalidRefreshToken
+}
+
+// TokenStore keeps refresh tokens and access token revocations server-side.
+type TokenStore interface {
+	IssueRefreshToken(ctx context.Context, userID string, ttl time.Duration) (string, error)
+	ConsumeRefreshToken(ctx context.Context, token string) (*RefreshSession, error)
+	DeleteRefreshToken(ctx context.Context, token string) error
+	RevokeAccessToken(ctx context.Context, jti string, ttl time.Duration) error
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+	RevokeUserSessions(ctx context.Context, userID string, ttl time.Duration) error
+	IsSessionRevoked(ctx context.Context, userID string, issuedAt time.Time) (bool, error)
+	IssuePasswordResetToken(ctx context.Context, userID string, ttl time.Duration) (string, error)
+	ConsumePasswordResetToken(ctx context.Context, token string) (string, error)
+}
+
+type cacheTokenStore struct {
+	cache repository.CacheRepository
+}
+
+// NewTokenStore keeps tokens in the cache. Refresh tokens are stored under their
+// SHA-256 hash so a cache dump cannot be replayed. The revocation checks fail
+// closed: when the cache cannot be reached they return an error rather than
+// treating the token as valid.
+func NewTokenStore(cache repository.CacheRepository) TokenStore {
+	return &cacheTokenStore{cache: cache}
+}
+
+func (s *cacheTokenStore) IssueRefreshToken(ctx context.Context, userID string, ttl time.Duration) (string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	session := RefreshSession{
+		UserID:    userID,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := s.cache.Set(ctx, refreshTokenPrefix+hashToken(token), session, ttl); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// ConsumeRefreshToken returns the session for token and removes it in one
+// step, so every refresh token can be used exactly once even by concurrent
+// requests. A used token is remembered until it would have expired, and
+// presenting it again returns a *RefreshTokenReusedError.
+func (s *cacheTokenStore) ConsumeRefreshToken(ctx context.Context, token string) (*RefreshSession, error) {
+	hash := hashToken(token)
+
+	var session RefreshSession
+	if err := s.cache.GetDel(ctx, refreshTokenPrefix+hash, &session); err != nil {
+		if !errors.Is(err, repository.ErrCacheMiss) {
+			return nil, err
+		}
+		var userID string
+		if err := s.cache.Get(ctx, usedRefreshPrefix+hash, &userID); err == nil && userID != "" {
+			return nil, &RefreshTokenReusedError{UserID: userID}
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if ttl := time.Until(session.ExpiresAt); ttl > 0 {
+		s.cache.Set(ctx, usedRefreshPrefix+hash, session.UserID, ttl)
+	}
+
+	revoked, err := s.IsSessionRevoked(ctx, session.UserID, session.IssuedAt)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return &session, nil
+}
+
+func (s *cacheTokenStore) DeleteRefreshToken(ctx context.Context, token string) error {
+	return s.cache.Delete(ctx, refreshTokenPrefix+hashToken(token), usedRefreshPrefix+hashToken(token))
+}
+
+func (s *cacheTokenStore) RevokeAccessToken(ctx context.Context, jti string, ttl time.Duration) error {
+	if jti == "" || ttl <= 0 {
+		return nil
+	}
+	return s.cache.Set(ctx, revokedTokenPrefix+jti, true, ttl)
+}
+
+func (s *cacheTokenStore) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	if jti == "" {
+		return false, nil
+	}
+	return s.cache.Exists(ctx, revokedTokenPrefix+jti)
+}
+
+// RevokeUserSessions invalidates every access and refresh token issued to userID
+// up to now. ttl must cover the longest token lifetime.
+func (s *cacheTokenStore) RevokeUserSessions(ctx context.Context, userID string, ttl time.Duration) error {
+	return s.cache.Set(ctx, sessionsRevokedAtKey+userID, strconv.FormatInt(time.Now().UnixMilli(), 10), ttl)
+}
+
+// IsSessionRevoked reports whether a token issued at issuedAt predates the last
+// RevokeUserSessions call. A token issued in the same millisecond as the
+// revocation counts as revoked.
+func (s *cacheTokenStore) IsSessionRevoked(ctx context.Context, userID string, issuedAt time.Time) (bool, error) {
+	var revokedAt string
+	if err := s.cache.Get(ctx, sessionsRevokedAtKey+userID, &revokedAt); err != nil {
+		if errors.Is(err, repository.ErrCacheMiss) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	millis, err := strconv.ParseInt(revokedAt, 10, 64)
+	if err != nil {
+		return false, err
+	}
+
+	return issuedAt.UnixMilli() <= millis, nil
+}
+
+func (s *cacheTokenStore) IssuePasswordResetToken(ctx context.Context, userID string, ttl time.Duration) (string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.cache.Set(ctx, passwordResetPrefix+hashToken(token), userID, ttl); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// ConsumePasswordResetToken returns the user the token was issued for and removes
+// it in one step, so concurrent resets cannot both spend the token.
+func (s *cacheTokenStore) ConsumePasswordResetToken(ctx context.Context, token string) (string, error) {
+	var userID string
+	if err := s.cache.GetDel(ctx, passwordResetPrefix+hashToken(token), &userID); err != nil {
+		if !errors.Is(err, repository.ErrCacheMiss) {
+			return "", err
+		}
+		return "", ErrInvalidResetToken
+	}
+	if userID == "" {
+		return "", ErrInvalidResetToken
+	}
+
+	return userID, nil
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
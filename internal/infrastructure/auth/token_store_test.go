@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"building-report-backend/internal/domain/repository/repositorytest"
+)
+
+var errCacheDown = errors.New("cache down")
+
+func TestConsumePasswordResetToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		consume func(t *testing.T, store TokenStore, cache *repositorytest.Cache, token string) (string, error)
+		want    string
+		wantErr error
+	}{
+		{
+			name: "issued token",
+			consume: func(t *testing.T, store TokenStore, _ *repositorytest.Cache, token string) (string, error) {
+				return store.ConsumePasswordResetToken(context.Background(), token)
+			},
+			want: "user-1",
+		},
+		{
+			name: "token spent twice",
+			consume: func(t *testing.T, store TokenStore, _ *repositorytest.Cache, token string) (string, error) {
+				if _, err := store.ConsumePasswordResetToken(context.Background(), token); err != nil {
+					t.Fatalf("first consume: %v", err)
+				}
+				return store.ConsumePasswordResetToken(context.Background(), token)
+			},
+			wantErr: ErrInvalidResetToken,
+		},
+		{
+			name: "unknown token",
+			consume: func(t *testing.T, store TokenStore, _ *repositorytest.Cache, _ string) (string, error) {
+				return store.ConsumePasswordResetToken(context.Background(), "not-issued")
+			},
+			wantErr: ErrInvalidResetToken,
+		},
+		{
+			name: "cache unavailable",
+			consume: func(t *testing.T, store TokenStore, cache *repositorytest.Cache, token string) (string, error) {
+				cache.Err = errCacheDown
+				return store.ConsumePasswordResetToken(context.Background(), token)
+			},
+			wantErr: errCacheDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := repositorytest.NewCache()
+			store := NewTokenStore(cache)
+			token, err := store.IssuePasswordResetToken(context.Background(), "user-1", time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := tt.consume(t, store, cache, token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("user = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConsumeRefreshToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		consume    func(t *testing.T, store TokenStore, cache *repositorytest.Cache, token string) (*RefreshSession, error)
+		wantUser   string
+		wantErr    error
+		wantReused bool
+	}{
+		{
+			name: "issued token",
+			consume: func(t *testing.T, store TokenStore, _ *repositorytest.Cache, token string) (*RefreshSession, error) {
+				return store.ConsumeRefreshToken(context.Background(), token)
+			},
+			wantUser: "user-1",
+		},
+		{
+			name: "token reused",
+			consume: func(t *testing.T, store TokenStore, _ *repositorytest.Cache, token string) (*RefreshSession, error) {
+				if _, err := store.ConsumeRefreshToken(context.Background(), token); err != nil {
+					t.Fatalf("first consume: %v", err)
+				}
+				return store.ConsumeRefreshToken(context.Background(), token)
+			},
+			wantErr:    ErrInvalidRefreshToken,
+			wantReused: true,
+		},
+		{
+			name: "token deleted at logout",
+			consume: func(t *testing.T, store TokenStore, _ *repositorytest.Cache, token string) (*RefreshSession, error) {
+				if err := store.DeleteRefreshToken(context.Background(), token); err != nil {
+					t.Fatal(err)
+				}
+				return store.ConsumeRefreshToken(context.Background(), token)
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "unknown token",
+			consume: func(t *testing.T, store TokenStore, _ *repositorytest.Cache, _ string) (*RefreshSession, error) {
+				return store.ConsumeRefreshToken(context.Background(), "not-issued")
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "sessions revoked after issue",
+			consume: func(t *testing.T, store TokenStore, _ *repositorytest.Cache, token string) (*RefreshSession, error) {
+				if err := store.RevokeUserSessions(context.Background(), "user-1", time.Hour); err != nil {
+					t.Fatal(err)
+				}
+				return store.ConsumeRefreshToken(context.Background(), token)
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "cache unavailable",
+			consume: func(t *testing.T, store TokenStore, cache *repositorytest.Cache, token string) (*RefreshSession, error) {
+				cache.Err = errCacheDown
+				return store.ConsumeRefreshToken(context.Background(), token)
+			},
+			wantErr: errCacheDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := repositorytest.NewCache()
+			store := NewTokenStore(cache)
+			token, err := store.IssueRefreshToken(context.Background(), "user-1", time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			session, err := tt.consume(t, store, cache, token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			var reused *RefreshTokenReusedError
+			if errors.As(err, &reused) != tt.wantReused {
+				t.Fatalf("reuse reported = %v, want %v (error %v)", !tt.wantReused, tt.wantReused, err)
+			}
+			if reused != nil && reused.UserID != "user-1" {
+				t.Errorf("reused by %q, want user-1", reused.UserID)
+			}
+			if tt.wantUser != "" && (session == nil || session.UserID != tt.wantUser) {
+				t.Errorf("session = %+v, want user %q", session, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestConsumeTokensConcurrently(t *testing.T) {
+	tests := []struct {
+		name    string
+		issue   func(store TokenStore) (string, error)
+		consume func(store TokenStore, token string) error
+	}{
+		{
+			name: "refresh token",
+			issue: func(store TokenStore) (string, error) {
+				return store.IssueRefreshToken(context.Background(), "user-1", time.Hour)
+			},
+			consume: func(store TokenStore, token string) error {
+				_, err := store.ConsumeRefreshToken(context.Background(), token)
+				return err
+			},
+		},
+		{
+			name: "password reset token",
+			issue: func(store TokenStore) (string, error) {
+				return store.IssuePasswordResetToken(context.Background(), "user-1", time.Hour)
+			},
+			consume: func(store TokenStore, token string) error {
+				_, err := store.ConsumePasswordResetToken(context.Background(), token)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewTokenStore(repositorytest.NewCache())
+			token, err := tt.issue(store)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var wg sync.WaitGroup
+			var succeeded atomic.Int32
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if tt.consume(store, token) == nil {
+						succeeded.Add(1)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if n := succeeded.Load(); n != 1 {
+				t.Errorf("%d concurrent consumes succeeded, want 1", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Package health provides the dependency checks behind the readiness endpoint.
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// Checker pings one dependency. A failing critical checker makes the service
+// not ready; a failing non-critical one only degrades it.
+type Checker struct {
+	Name     string
+	Critical bool
+	Check    func(ctx context.Context) error
+}
+
+func Postgres(db *gorm.DB) Checker {
+	return Checker{
+		Name:     "postgres",
+		Critical: true,
+		Check: func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+	}
+}
+
+// Redis is critical because sessions and refresh tokens live there.
+func Redis(client *redis.Client) Checker {
+	return Checker{
+		Name:     "redis",
+		Critical: true,
+		Check: func(ctx context.Context) error {
+			return client.Ping(ctx).Err()
+		},
+	}
+}
+
+// Minio is not critical: without it photo uploads fail, but reports can still
+// be read and edited.
+func Minio(client *minio.Client, bucketName string) Checker {
+	return Checker{
+		Name:     "minio",
+		Critical: false,
+		Check: func(ctx context.Context) error {
+			exists, err := client.BucketExists(ctx, bucketName)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("bucket %q does not exist", bucketName)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,295 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"time"
+)
+
+// Exif is the subset of a photo's EXIF data the application keeps. Everything
+// else, camera serial numbers and the like, is dropped when the image is
+// re-encoded.
+type Exif struct {
+	// Orientation is the EXIF orientation, 1 to 8; 0 when absent.
+	Orientation int
+	TakenAt     *time.Time
+	Latitude    *float64
+	Longitude   *float64
+}
+
+const (
+	tagOrientation        = 0x0112
+	tagDateTime           = 0x0132
+	tagExifIFD            = 0x8769
+	tagGPSIFD             = 0x8825
+	tagDateTimeOriginal   = 0x9003
+	tagOffsetTimeOriginal = 0x9011
+	tagGPSLatitudeRef     = 0x0001
+	tagGPSLatitude        = 0x0002
+	tagGPSLongitudeRef    = 0x0003
+	tagGPSLongitude       = 0x0004
+)
+
+const (
+	typeASCII    = 2
+	typeShort    = 3
+	typeLong     = 4
+	typeRational = 5
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// readExif finds the EXIF block of a JPEG, PNG or WebP file and parses it.
+// Missing or malformed EXIF data yields a zero Exif: metadata is a bonus, not
+// a reason to reject a photo.
+func readExif(data []byte, format string) Exif {
+	var block []byte
+	switch format {
+	case FormatJPEG:
+		block = jpegExif(data)
+	case FormatPNG:
+		block = pngExif(data)
+	case FormatWebP:
+		block = webpExif(data)
+	}
+	if block == nil {
+		return Exif{}
+	}
+	return parseTIFF(bytes.TrimPrefix(block, exifHeader))
+}
+
+// jpegExif returns the payload of the APP1 segment holding EXIF data.
+func jpegExif(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil
+		}
+		marker := data[i+1]
+		if marker == 0xFF {
+			i++
+			continue
+		}
+		// Start of scan: the metadata segments are all before it.
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return nil
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, exifHeader) {
+			return segment
+		}
+		i += 2 + length
+	}
+	return nil
+}
+
+// pngExif returns the payload of the eXIf chunk.
+func pngExif(data []byte) []byte {
+	const signatureLen = 8
+	for i := signatureLen; i+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		kind := string(data[i+4 : i+8])
+		if length < 0 || i+12+length > len(data) || kind == "IDAT" {
+			return nil
+		}
+		if kind == "eXIf" {
+			return data[i+8 : i+8+length]
+		}
+		i += 12 + length
+	}
+	return nil
+}
+
+// webpExif returns the payload of the EXIF chunk of an extended WebP file.
+func webpExif(data []byte) []byte {
+	const headerLen = 12
+	for i := headerLen; i+8 <= len(data); {
+		kind := string(data[i : i+4])
+		length := int(binary.LittleEndian.Uint32(data[i+4:]))
+		if length < 0 || i+8+length > len(data) {
+			return nil
+		}
+		if kind == "EXIF" {
+			return data[i+8 : i+8+length]
+		}
+		i += 8 + length + length%2
+	}
+	return nil
+}
+
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+type ifdEntry struct {
+	kind  uint16
+	count uint32
+	value []byte
+}
+
+func parseTIFF(data []byte) Exif {
+	var exif Exif
+	if len(data) < 8 {
+		return exif
+	}
+
+	r := tiffReader{data: data}
+	switch string(data[:2]) {
+	case "II":
+		r.order = binary.LittleEndian
+	case "MM":
+		r.order = binary.BigEndian
+	default:
+		return exif
+	}
+	if r.order.Uint16(data[2:]) != 42 {
+		return exif
+	}
+
+	ifd0 := r.readIFD(r.order.Uint32(data[4:]))
+	if e, ok := ifd0[tagOrientation]; ok {
+		if v, ok := r.uint(e); ok && v >= 1 && v <= 8 {
+			exif.Orientation = int(v)
+		}
+	}
+
+	var offset string
+	taken := r.ascii(ifd0[tagDateTime])
+	if e, ok := ifd0[tagExifIFD]; ok {
+		if pointer, ok := r.uint(e); ok {
+			sub := r.readIFD(pointer)
+			if original := r.ascii(sub[tagDateTimeOriginal]); original != "" {
+				taken = original
+			}
+			offset = r.ascii(sub[tagOffsetTimeOriginal])
+		}
+	}
+	exif.TakenAt = parseExifTime(taken, offset)
+
+	if e, ok := ifd0[tagGPSIFD]; ok {
+		if pointer, ok := r.uint(e); ok {
+			gps := r.readIFD(pointer)
+			exif.Latitude = r.coordinate(gps[tagGPSLatitude], r.ascii(gps[tagGPSLatitudeRef]), "S", 90)
+			exif.Longitude = r.coordinate(gps[tagGPSLongitude], r.ascii(gps[tagGPSLongitudeRef]), "W", 180)
+		}
+	}
+	return exif
+}
+
+// readIFD reads the entries of the directory at offset, ignoring any that
+// point outside the data.
+func (r tiffReader) readIFD(offset uint32) map[uint16]ifdEntry {
+	entries := map[uint16]ifdEntry{}
+	start := int(offset)
+	if start < 8 || start+2 > len(r.data) {
+		return entries
+	}
+	count := int(r.order.Uint16(r.data[start:]))
+	for i := 0; i < count; i++ {
+		pos := start + 2 + i*12
+		if pos+12 > len(r.data) {
+			break
+		}
+		tag := r.order.Uint16(r.data[pos:])
+		kind := r.order.Uint16(r.data[pos+2:])
+		n := r.order.Uint32(r.data[pos+4:])
+
+		var size uint64
+		switch kind {
+		case typeASCII:
+			size = uint64(n)
+		case typeShort:
+			size = uint64(n) * 2
+		case typeLong:
+			size = uint64(n) * 4
+		case typeRational:
+			size = uint64(n) * 8
+		default:
+			continue
+		}
+
+		value := r.data[pos+8 : pos+12]
+		if size > 4 {
+			valueOffset := uint64(r.order.Uint32(r.data[pos+8:]))
+			if valueOffset+size > uint64(len(r.data)) {
+				continue
+			}
+			value = r.data[valueOffset : valueOffset+size]
+		}
+		entries[tag] = ifdEntry{kind: kind, count: n, value: value[:min(uint64(len(value)), size)]}
+	}
+	return entries
+}
+
+func (r tiffReader) uint(e ifdEntry) (uint32, bool) {
+	switch {
+	case e.kind == typeShort && len(e.value) >= 2:
+		return uint32(r.order.Uint16(e.value)), true
+	case e.kind == typeLong && len(e.value) >= 4:
+		return r.order.Uint32(e.value), true
+	}
+	return 0, false
+}
+
+func (r tiffReader) ascii(e ifdEntry) string {
+	if e.kind != typeASCII {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimRight(string(e.value), "\x00"))
+}
+
+// coordinate converts degrees, minutes and seconds rationals to signed
+// decimal degrees.
+func (r tiffReader) coordinate(e ifdEntry, ref, negativeRef string, limit float64) *float64 {
+	if e.kind != typeRational || e.count != 3 || len(e.value) < 24 {
+		return nil
+	}
+	var parts [3]float64
+	for i := range parts {
+		num := r.order.Uint32(e.value[i*8:])
+		den := r.order.Uint32(e.value[i*8+4:])
+		if den == 0 {
+			return nil
+		}
+		parts[i] = float64(num) / float64(den)
+	}
+
+	value := parts[0] + parts[1]/60 + parts[2]/3600
+	if strings.EqualFold(ref, negativeRef) {
+		value = -value
+	}
+	if math.IsNaN(value) || math.Abs(value) > limit {
+		return nil
+	}
+	return &value
+}
+
+// parseExifTime parses an EXIF "2006:01:02 15:04:05" timestamp. Without an
+// offset tag the camera's wall clock time is kept as UTC, since the zone it
+// was set to is unknown.
+func parseExifTime(value, offset string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	loc := time.UTC
+	if offset != "" {
+		if t, err := time.Parse("-07:00", offset); err == nil {
+			_, seconds := t.Zone()
+			loc = time.FixedZone("", seconds)
+		}
+	}
+	t, err := time.ParseInLocation("2006:01:02 15:04:05", value, loc)
+	if err != nil || t.Year() < 1900 {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,250 @@
+// Package imaging validates uploaded photos and turns them into the images
+// that are stored: a re-encoded original without EXIF data plus resized
+// variants. It only uses the standard library codecs and golang.org/x/image.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"strings"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+)
+
+// ErrInvalidImage is wrapped by every error caused by the upload itself rather
+// than by the server.
+var ErrInvalidImage = errors.New("invalid image")
+
+var (
+	ErrNotImage          = fmt.Errorf("%w: file is not an image", ErrInvalidImage)
+	ErrUnsupportedFormat = fmt.Errorf("%w: image format is not allowed", ErrInvalidImage)
+	ErrTooLarge          = fmt.Errorf("%w: file is too large", ErrInvalidImage)
+	ErrTooManyPixels     = fmt.Errorf("%w: image dimensions are too large", ErrInvalidImage)
+	ErrCorrupt           = fmt.Errorf("%w: image could not be decoded", ErrInvalidImage)
+)
+
+// sniffedFormats maps the content types http.DetectContentType reports to
+// image formats the decoders understand.
+var sniffedFormats = map[string]string{
+	"image/jpeg": FormatJPEG,
+	"image/png":  FormatPNG,
+	"image/webp": FormatWebP,
+}
+
+// formatsByExtension maps the extensions of constants.AllowedImageFormats to
+// formats.
+var formatsByExtension = map[string]string{
+	"jpg":  FormatJPEG,
+	"jpeg": FormatJPEG,
+	"png":  FormatPNG,
+	"webp": FormatWebP,
+}
+
+type Options struct {
+	// AllowedFormats is a comma separated list of extensions, as in
+	// constants.AllowedImageFormats.
+	AllowedFormats string
+	MaxBytes       int64
+	MaxPixels      int
+	// VariantSizes are the longest sides of the variants, by variant name.
+	VariantSizes map[string]int
+	JPEGQuality  int
+}
+
+// Encoded is one stored rendition of a photo.
+type Encoded struct {
+	Data          []byte
+	ContentType   string
+	Extension     string
+	Width, Height int
+}
+
+// Result is a processed photo: the original re-encoded without metadata, the
+// resized variants and the EXIF values worth keeping.
+type Result struct {
+	Original Encoded
+	Variants map[string]Encoded
+	Exif     Exif
+}
+
+type Processor struct {
+	opts    Options
+	allowed map[string]bool
+}
+
+func NewProcessor(opts Options) *Processor {
+	allowed := map[string]bool{}
+	for _, ext := range strings.Split(opts.AllowedFormats, ",") {
+		if format, ok := formatsByExtension[strings.ToLower(strings.TrimSpace(ext))]; ok {
+			allowed[format] = true
+		}
+	}
+	return &Processor{opts: opts, allowed: allowed}
+}
+
+// Process reads an upload, checks it is an allowed image within the limits and
+// returns its renditions. Errors caused by the upload wrap ErrInvalidImage.
+func (p *Processor) Process(r io.Reader) (*Result, error) {
+	data, err := io.ReadAll(io.LimitReader(r, p.opts.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > p.opts.MaxBytes {
+		return nil, ErrTooLarge
+	}
+
+	format, err := p.sniff(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > p.opts.MaxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+
+	exif := readExif(data, format)
+	upright := orient(toRGBA(img), exif.Orientation)
+
+	// PNGs stay lossless and anything with transparency keeps it; the rest,
+	// WebP included, is stored as JPEG, which every client can display.
+	outFormat := FormatJPEG
+	if format == FormatPNG || !upright.Opaque() {
+		outFormat = FormatPNG
+	}
+
+	result := &Result{Variants: map[string]Encoded{}, Exif: exif}
+	if result.Original, err = p.encode(upright, outFormat); err != nil {
+		return nil, err
+	}
+	for name, size := range p.opts.VariantSizes {
+		if result.Variants[name], err = p.encode(fit(upright, size), outFormat); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// sniff detects the format from the content, ignoring the file name and the
+// client supplied content type.
+func (p *Processor) sniff(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return "", ErrNotImage
+	}
+	format, ok := sniffedFormats[contentType]
+	if !ok || !p.allowed[format] {
+		return "", fmt.Errorf("%w (%s)", ErrUnsupportedFormat, contentType)
+	}
+	return format, nil
+}
+
+func (p *Processor) encode(img image.Image, format string) (Encoded, error) {
+	var buf bytes.Buffer
+	out := Encoded{Width: img.Bounds().Dx(), Height: img.Bounds().Dy()}
+
+	switch format {
+	case FormatPNG:
+		out.ContentType, out.Extension = "image/png", ".png"
+		if err := png.Encode(&buf, img); err != nil {
+			return Encoded{}, err
+		}
+	default:
+		out.ContentType, out.Extension = "image/jpeg", ".jpg"
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: p.opts.JPEGQuality}); err != nil {
+			return Encoded{}, err
+		}
+	}
+
+	out.Data = buf.Bytes()
+	return out, nil
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	return dst
+}
+
+// fit scales img down so its longest side is at most size. Smaller images are
+// returned as they are.
+func fit(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= size && h <= size {
+		return img
+	}
+	if w >= h {
+		w, h = size, max(1, h*size/w)
+	} else {
+		w, h = max(1, w*size/h), size
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, b, xdraw.Src, nil)
+	return dst
+}
+
+// orient applies an EXIF orientation so the stored pixels are upright once the
+// orientation tag is gone.
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], src.Pix[src.PixOffset(x, y):src.PixOffset(x, y)+4])
+		}
+	}
+	return dst
+}
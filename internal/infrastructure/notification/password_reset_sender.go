@@ -0,0 +1,88 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"building-report-backend/internal/domain/entity"
+)
+
+// PasswordResetSender delivers a one-time password reset token to a user.
+// Production deployments plug in a mail or messaging implementation; the log
+// and file senders below are for local development.
+type PasswordResetSender interface {
+	SendPasswordReset(ctx context.Context, user *entity.User, token string, expiresAt time.Time) error
+}
+
+// NewPasswordResetSender returns the sender named by kind ("log" or "file").
+func NewPasswordResetSender(kind, filePath string, log *slog.Logger) (PasswordResetSender, error) {
+	switch kind {
+	case "", "log":
+		return &logSender{log: log}, nil
+	case "file":
+		return &fileSender{path: filePath}, nil
+	default:
+		return nil, fmt.Errorf("unknown password reset sender %q", kind)
+	}
+}
+
+// logSender writes the token to the application log. The attribute is named
+// reset_token so the redacting handler lets it through; never use this sender
+// outside development.
+type logSender struct {
+	log *slog.Logger
+}
+
+func (s *logSender) SendPasswordReset(ctx context.Context, user *entity.User, token string, expiresAt time.Time) error {
+	s.log.InfoContext(ctx, "password reset issued",
+		slog.String("username", user.Username),
+		slog.String("email", user.Email),
+		slog.String("reset_token", token),
+		slog.Time("expires_at", expiresAt),
+	)
+	return nil
+}
+
+// fileSender appends one JSON line per reset to a file.
+type fileSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+type passwordResetRecord struct {
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (s *fileSender) SendPasswordReset(ctx context.Context, user *entity.User, token string, expiresAt time.Time) error {
+	line, err := json.Marshal(passwordResetRecord{
+		UserID:    user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
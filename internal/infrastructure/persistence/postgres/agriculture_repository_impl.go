@@ -11,6 +11,13 @@ import (
 	"gorm.io/gorm"
 )
 
+// activeAgricultureReportsTable is used in place of the bare table name in raw SQL so that
+// soft-deleted rows never reach statistics or listings, and scoped users only
+// count the reports of their own district and unit.
+func activeAgricultureReportsTable(ctx context.Context) string {
+	return activeTable(entity.DataScopeFromContext(ctx), "agriculture_reports")
+}
+
 type agricultureRepositoryImpl struct {
 	db *gorm.DB
 }
@@ -20,24 +27,24 @@ func NewAgricultureRepository(db *gorm.DB) repository.AgricultureRepository {
 }
 
 func (r *agricultureRepositoryImpl) Create(ctx context.Context, report *entity.AgricultureReport) error {
-	return r.db.WithContext(ctx).Create(report).Error
+	return conn(ctx, r.db).Create(report).Error
 }
 
 func (r *agricultureRepositoryImpl) Update(ctx context.Context, report *entity.AgricultureReport) error {
 	report.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(report).Error
+	return conn(ctx, r.db).Save(report).Error
 }
 
 func (r *agricultureRepositoryImpl) Delete(ctx context.Context, id string) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Where("id = ?", id).
 		Delete(&entity.AgricultureReport{}).Error
 }
 
 func (r *agricultureRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.AgricultureReport, error) {
 	var report entity.AgricultureReport
-	err := r.db.WithContext(ctx).
-		Preload("Photos").
+	err := conn(ctx, r.db).
+		Preload("Photos", orderPhotos).
 		Where("id = ?", id).
 		First(&report).Error
 
@@ -51,7 +58,7 @@ func (r *agricultureRepositoryImpl) FindAll(ctx context.Context, limit, offset i
 	var reports []*entity.AgricultureReport
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.AgricultureReport{})
+	query := conn(ctx, r.db).Model(&entity.AgricultureReport{})
 
 	if extensionOfficer, ok := filters["extension_officer"].(string); ok && extensionOfficer != "" {
 		query = query.Where("extension_officer = ?", extensionOfficer)
@@ -91,7 +98,7 @@ func (r *agricultureRepositoryImpl) FindAll(ctx context.Context, limit, offset i
 	query.Count(&total)
 
 	err := query.
-		Preload("Photos").
+		Preload("Photos", orderPhotos).
 		Limit(limit).
 		Offset(offset).
 		Order("visit_date DESC, created_at DESC").
@@ -104,13 +111,14 @@ func (r *agricultureRepositoryImpl) FindByUserID(ctx context.Context, userID str
 	var reports []*entity.AgricultureReport
 	var total int64
 
-	query := r.db.WithContext(ctx).
-		Model(&entity.AgricultureReport{})
+	query := conn(ctx, r.db).
+		Model(&entity.AgricultureReport{}).
+		Where("created_by = ?", userID)
 
 	query.Count(&total)
 
 	err := query.
-		Preload("Photos").
+		Preload("Photos", orderPhotos).
 		Limit(limit).
 		Offset(offset).
 		Order("created_at DESC").
@@ -123,14 +131,14 @@ func (r *agricultureRepositoryImpl) FindByExtensionOfficer(ctx context.Context,
 	var reports []*entity.AgricultureReport
 	var total int64
 
-	query := r.db.WithContext(ctx).
+	query := conn(ctx, r.db).
 		Model(&entity.AgricultureReport{}).
 		Where("extension_officer = ?", extensionOfficer)
 
 	query.Count(&total)
 
 	err := query.
-		Preload("Photos").
+		Preload("Photos", orderPhotos).
 		Limit(limit).
 		Offset(offset).
 		Order("visit_date DESC").
@@ -143,14 +151,14 @@ func (r *agricultureRepositoryImpl) FindByVillage(ctx context.Context, village s
 	var reports []*entity.AgricultureReport
 	var total int64
 
-	query := r.db.WithContext(ctx).
+	query := conn(ctx, r.db).
 		Model(&entity.AgricultureReport{}).
 		Where("village = ?", village)
 
 	query.Count(&total)
 
 	err := query.
-		Preload("Photos").
+		Preload("Photos", orderPhotos).
 		Limit(limit).
 		Offset(offset).
 		Order("visit_date DESC").
@@ -163,14 +171,14 @@ func (r *agricultureRepositoryImpl) FindByDateRange(ctx context.Context, startDa
 	var reports []*entity.AgricultureReport
 	var total int64
 
-	query := r.db.WithContext(ctx).
+	query := conn(ctx, r.db).
 		Model(&entity.AgricultureReport{}).
 		Where("visit_date BETWEEN ? AND ?", startDate, endDate)
 
 	query.Count(&total)
 
 	err := query.
-		Preload("Photos").
+		Preload("Photos", orderPhotos).
 		Limit(limit).
 		Offset(offset).
 		Order("visit_date DESC").
@@ -183,54 +191,54 @@ func (r *agricultureRepositoryImpl) GetStatistics(ctx context.Context) (map[stri
 	stats := make(map[string]interface{})
 
 	var total int64
-	r.db.Model(&entity.AgricultureReport{}).Count(&total)
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).Count(&total)
 	stats["total_reports"] = total
 
 	var totalFarmers int64
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Distinct("farmer_name").
 		Count(&totalFarmers)
 	stats["total_farmers"] = totalFarmers
 
 	var totalLandArea float64
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Select("COALESCE(SUM(COALESCE(food_land_area, 0) + COALESCE(horti_land_area, 0) + COALESCE(plantation_land_area, 0)), 0)").
 		Scan(&totalLandArea)
 	stats["total_land_area_ha"] = totalLandArea
 
 	var foodCropReports int64
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where("food_commodity != '' AND food_commodity IS NOT NULL").
 		Count(&foodCropReports)
 	stats["food_crop_reports"] = foodCropReports
 
 	var horticultureReports int64
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where("horti_commodity != '' AND horti_commodity IS NOT NULL").
 		Count(&horticultureReports)
 	stats["horticulture_reports"] = horticultureReports
 
 	var plantationReports int64
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where("plantation_commodity != '' AND plantation_commodity IS NOT NULL").
 		Count(&plantationReports)
 	stats["plantation_reports"] = plantationReports
 
 	var pestDiseaseReports int64
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where("has_pest_disease = true").
 		Count(&pestDiseaseReports)
 	stats["reports_with_pest_disease"] = pestDiseaseReports
 	stats["pest_disease_percentage"] = float64(pestDiseaseReports) / float64(total) * 100
 
 	var postHarvestProblemReports int64
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where("post_harvest_problems != '' AND post_harvest_problems IS NOT NULL AND post_harvest_problems != 'TIDAK_ADA'").
 		Count(&postHarvestProblemReports)
 	stats["post_harvest_problem_reports"] = postHarvestProblemReports
 
 	var productionProblemReports int64
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where("production_problems != '' AND production_problems IS NOT NULL").
 		Count(&productionProblemReports)
 	stats["production_problem_reports"] = productionProblemReports
@@ -240,7 +248,7 @@ func (r *agricultureRepositoryImpl) GetStatistics(ctx context.Context) (map[stri
 		Count   int64  `json:"count"`
 	}
 	var villageCounts []VillageCount
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Select("village, count(*) as count").
 		Group("village").
 		Order("count DESC").
@@ -254,12 +262,12 @@ func (r *agricultureRepositoryImpl) GetStatistics(ctx context.Context) (map[stri
 		FarmerCount      int64  `json:"farmer_count"`
 	}
 	var extensionStats []ExtensionOfficerStats
-	r.db.Raw(`
+	conn(ctx, r.db).Raw(`
         SELECT 
             extension_officer,
             COUNT(*) as visit_count,
             COUNT(DISTINCT farmer_name) as farmer_count
-        FROM agriculture_reports 
+        FROM ` + activeAgricultureReportsTable(ctx) + ` 
         GROUP BY extension_officer 
         ORDER BY visit_count DESC
         LIMIT 10
@@ -284,13 +292,13 @@ func (r *agricultureRepositoryImpl) GetCommodityProduction(ctx context.Context,
             SUM(COALESCE(food_land_area, 0) + COALESCE(horti_land_area, 0) + COALESCE(plantation_land_area, 0)) as total_area,
             COUNT(DISTINCT farmer_name) as farmer_count,
             COUNT(DISTINCT village) as village_count
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE visit_date BETWEEN ? AND ?
         GROUP BY commodity
         ORDER BY total_area DESC
     `
 
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Raw(query, startDate, endDate).
 		Scan(&results).Error
 
@@ -308,13 +316,13 @@ func (r *agricultureRepositoryImpl) GetExtensionOfficerPerformance(ctx context.C
             COUNT(DISTINCT village) as villages_covered,
             MAX(visit_date) as last_visit,
             COUNT(*) / GREATEST(EXTRACT(EPOCH FROM (? - ?)) / (30 * 24 * 3600), 1) as average_visits_per_month
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE visit_date BETWEEN ? AND ?
         GROUP BY extension_officer
         ORDER BY total_visits DESC
     `
 
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Raw(query, endDate, startDate, startDate, endDate).
 		Scan(&results).Error
 
@@ -333,13 +341,13 @@ func (r *agricultureRepositoryImpl) GetVillageProductionStats(ctx context.Contex
             SUM(COALESCE(food_land_area, 0) + COALESCE(horti_land_area, 0) + COALESCE(plantation_land_area, 0)) as total_land_area,
             COUNT(CASE WHEN has_pest_disease = true THEN 1 END) as pest_disease_reports,
             COUNT(DISTINCT extension_officer) as extension_officers
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE visit_date BETWEEN ? AND ?
         GROUP BY village, district
         ORDER BY total_land_area DESC
     `
 
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Raw(query, startDate, endDate).
 		Scan(&results).Error
 
@@ -349,8 +357,8 @@ func (r *agricultureRepositoryImpl) GetVillageProductionStats(ctx context.Contex
 func (r *agricultureRepositoryImpl) GetPestDiseaseReports(ctx context.Context, limit int) ([]*entity.AgricultureReport, error) {
 	var reports []*entity.AgricultureReport
 
-	err := r.db.WithContext(ctx).
-		Preload("Photos").
+	err := conn(ctx, r.db).
+		Preload("Photos", orderPhotos).
 		Where("has_pest_disease = true").
 		Order("visit_date DESC").
 		Limit(limit).
@@ -368,7 +376,7 @@ func (r *agricultureRepositoryImpl) GetTechnologyAdoptionStats(ctx context.Conte
 	}
 
 	var foodTechCounts []TechnologyCount
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Select("food_technology as technology, count(*) as count").
 		Where("food_technology IS NOT NULL AND food_technology != '' AND food_technology != 'TIDAK_ADA'").
 		Group("food_technology").
@@ -377,7 +385,7 @@ func (r *agricultureRepositoryImpl) GetTechnologyAdoptionStats(ctx context.Conte
 	stats["food_technology"] = foodTechCounts
 
 	var hortiTechCounts []TechnologyCount
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Select("horti_technology as technology, count(*) as count").
 		Where("horti_technology IS NOT NULL AND horti_technology != '' AND horti_technology != 'TIDAK_ADA'").
 		Group("horti_technology").
@@ -386,7 +394,7 @@ func (r *agricultureRepositoryImpl) GetTechnologyAdoptionStats(ctx context.Conte
 	stats["horticulture_technology"] = hortiTechCounts
 
 	var plantationTechCounts []TechnologyCount
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Select("plantation_technology as technology, count(*) as count").
 		Where("plantation_technology IS NOT NULL AND plantation_technology != '' AND plantation_technology != 'TIDAK_ADA'").
 		Group("plantation_technology").
@@ -406,7 +414,7 @@ func (r *agricultureRepositoryImpl) GetFarmerNeedsAnalysis(ctx context.Context)
 	}
 
 	var constraintCounts []NeedCount
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Select("main_constraint as need, count(*) as count").
 		Where("main_constraint IS NOT NULL AND main_constraint != ''").
 		Group("main_constraint").
@@ -415,7 +423,7 @@ func (r *agricultureRepositoryImpl) GetFarmerNeedsAnalysis(ctx context.Context)
 	analysis["main_constraints"] = constraintCounts
 
 	var hopeCounts []NeedCount
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Select("farmer_hope as need, count(*) as count").
 		Where("farmer_hope IS NOT NULL AND farmer_hope != ''").
 		Group("farmer_hope").
@@ -424,7 +432,7 @@ func (r *agricultureRepositoryImpl) GetFarmerNeedsAnalysis(ctx context.Context)
 	analysis["farmer_hopes"] = hopeCounts
 
 	var trainingCounts []NeedCount
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Select("training_needed as need, count(*) as count").
 		Where("training_needed IS NOT NULL AND training_needed != ''").
 		Group("training_needed").
@@ -433,7 +441,7 @@ func (r *agricultureRepositoryImpl) GetFarmerNeedsAnalysis(ctx context.Context)
 	analysis["training_needs"] = trainingCounts
 
 	var urgentCounts []NeedCount
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Select("urgent_needs as need, count(*) as count").
 		Where("urgent_needs IS NOT NULL AND urgent_needs != ''").
 		Group("urgent_needs").
@@ -442,7 +450,7 @@ func (r *agricultureRepositoryImpl) GetFarmerNeedsAnalysis(ctx context.Context)
 	analysis["urgent_needs"] = urgentCounts
 
 	var waterAccessCounts []NeedCount
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Select("water_access as need, count(*) as count").
 		Where("water_access IS NOT NULL AND water_access != ''").
 		Group("water_access").
@@ -455,7 +463,7 @@ func (r *agricultureRepositoryImpl) GetFarmerNeedsAnalysis(ctx context.Context)
 
 func (r *agricultureRepositoryImpl) CountTotalFarmers(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Model(&entity.AgricultureReport{}).
 		Distinct("farmer_name").
 		Count(&count).Error
@@ -464,7 +472,7 @@ func (r *agricultureRepositoryImpl) CountTotalFarmers(ctx context.Context) (int6
 
 func (r *agricultureRepositoryImpl) CalculateTotalLandArea(ctx context.Context) (float64, error) {
 	var total float64
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Model(&entity.AgricultureReport{}).
 		Select("COALESCE(SUM(COALESCE(food_land_area, 0) + COALESCE(horti_land_area, 0) + COALESCE(plantation_land_area, 0)), 0)").
 		Scan(&total).Error
@@ -475,19 +483,19 @@ func (r *agricultureRepositoryImpl) CountReportsByCommodityType(ctx context.Cont
 	counts := make(map[string]int64)
 
 	var foodCount int64
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where("food_commodity IS NOT NULL AND food_commodity != ''").
 		Count(&foodCount)
 	counts["food_crops"] = foodCount
 
 	var hortiCount int64
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where("horti_commodity IS NOT NULL AND horti_commodity != ''").
 		Count(&hortiCount)
 	counts["horticulture"] = hortiCount
 
 	var plantationCount int64
-	r.db.Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where("plantation_commodity IS NOT NULL AND plantation_commodity != ''").
 		Count(&plantationCount)
 	counts["plantation"] = plantationCount
@@ -497,7 +505,7 @@ func (r *agricultureRepositoryImpl) CountReportsByCommodityType(ctx context.Cont
 
 func (r *agricultureRepositoryImpl) CountReportsWithPestDisease(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Model(&entity.AgricultureReport{}).
 		Where("has_pest_disease = true").
 		Count(&count).Error
@@ -507,7 +515,7 @@ func (r *agricultureRepositoryImpl) CountReportsWithPestDisease(ctx context.Cont
 func (r *agricultureRepositoryImpl) GetTopConstraints(ctx context.Context, limit int) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
 
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Model(&entity.AgricultureReport{}).
 		Select("main_constraint as constraint, count(*) as count").
 		Where("main_constraint IS NOT NULL AND main_constraint != ''").
@@ -522,7 +530,7 @@ func (r *agricultureRepositoryImpl) GetTopConstraints(ctx context.Context, limit
 func (r *agricultureRepositoryImpl) GetTopFarmerHopes(ctx context.Context, limit int) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
 
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Model(&entity.AgricultureReport{}).
 		Select("farmer_hope as hope, count(*) as count").
 		Where("farmer_hope IS NOT NULL AND farmer_hope != ''").
@@ -547,7 +555,7 @@ func (r *agricultureRepositoryImpl) GetCommodityAnalysis(ctx context.Context, st
 
 	if commodityName == "" {
 
-		err := r.db.WithContext(ctx).Raw(`
+		err := conn(ctx, r.db).Raw(`
             SELECT 
                 COALESCE(SUM(
                     COALESCE(food_land_area, 0) + 
@@ -555,7 +563,7 @@ func (r *agricultureRepositoryImpl) GetCommodityAnalysis(ctx context.Context, st
                     COALESCE(plantation_land_area, 0)
                 ), 0) as total_area,
                 COUNT(*) as report_count
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE visit_date BETWEEN ? AND ?
             AND (
                 (food_commodity IS NOT NULL AND food_commodity != '') OR
@@ -570,7 +578,7 @@ func (r *agricultureRepositoryImpl) GetCommodityAnalysis(ctx context.Context, st
 	} else {
 
 		commodityPattern := "%" + commodityName + "%"
-		err := r.db.WithContext(ctx).Raw(`
+		err := conn(ctx, r.db).Raw(`
             SELECT 
                 COALESCE(SUM(
                     COALESCE(food_land_area, 0) + 
@@ -578,7 +586,7 @@ func (r *agricultureRepositoryImpl) GetCommodityAnalysis(ctx context.Context, st
                     COALESCE(plantation_land_area, 0)
                 ), 0) as total_area,
                 COUNT(*) as report_count
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE visit_date BETWEEN ? AND ?
             AND (
                 (food_commodity IS NOT NULL AND food_commodity != '' AND UPPER(food_commodity) LIKE UPPER(?)) OR
@@ -596,7 +604,7 @@ func (r *agricultureRepositoryImpl) GetCommodityAnalysis(ctx context.Context, st
 	prevYearEnd := endDate.AddDate(-1, 0, 0)
 
 	if commodityName == "" {
-		err := r.db.WithContext(ctx).Raw(`
+		err := conn(ctx, r.db).Raw(`
             SELECT 
                 COALESCE(SUM(
                     COALESCE(food_land_area, 0) + 
@@ -604,7 +612,7 @@ func (r *agricultureRepositoryImpl) GetCommodityAnalysis(ctx context.Context, st
                     COALESCE(plantation_land_area, 0)
                 ), 0) as total_area,
                 COUNT(*) as report_count
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE visit_date BETWEEN ? AND ?
             AND (
                 (food_commodity IS NOT NULL AND food_commodity != '') OR
@@ -618,7 +626,7 @@ func (r *agricultureRepositoryImpl) GetCommodityAnalysis(ctx context.Context, st
 		}
 	} else {
 		commodityPattern := "%" + commodityName + "%"
-		err := r.db.WithContext(ctx).Raw(`
+		err := conn(ctx, r.db).Raw(`
             SELECT 
                 COALESCE(SUM(
                     COALESCE(food_land_area, 0) + 
@@ -626,7 +634,7 @@ func (r *agricultureRepositoryImpl) GetCommodityAnalysis(ctx context.Context, st
                     COALESCE(plantation_land_area, 0)
                 ), 0) as total_area,
                 COUNT(*) as report_count
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE visit_date BETWEEN ? AND ?
             AND (
                 (food_commodity IS NOT NULL AND food_commodity != '' AND UPPER(food_commodity) LIKE UPPER(?)) OR
@@ -709,7 +717,7 @@ func (r *agricultureRepositoryImpl) GetProductionByDistrict(ctx context.Context,
                 COALESCE(food_land_area, 0) + COALESCE(horti_land_area, 0) + COALESCE(plantation_land_area, 0) as land_area,
                 (COALESCE(food_land_area, 0) + COALESCE(horti_land_area, 0) + COALESCE(plantation_land_area, 0)) * 3.0 as estimated_production,
                 farmer_name
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE visit_date BETWEEN ? AND ?
             AND latitude IS NOT NULL 
             AND longitude IS NOT NULL
@@ -739,7 +747,7 @@ func (r *agricultureRepositoryImpl) GetProductionByDistrict(ctx context.Context,
                 COALESCE(food_land_area, 0) + COALESCE(horti_land_area, 0) + COALESCE(plantation_land_area, 0) as land_area,
                 (COALESCE(food_land_area, 0) + COALESCE(horti_land_area, 0) + COALESCE(plantation_land_area, 0)) * 3.0 as estimated_production,
                 farmer_name
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE visit_date BETWEEN ? AND ?
             AND latitude IS NOT NULL 
             AND longitude IS NOT NULL
@@ -753,7 +761,7 @@ func (r *agricultureRepositoryImpl) GetProductionByDistrict(ctx context.Context,
 		args = []interface{}{startDate, endDate, commodityPattern, commodityPattern, commodityPattern}
 	}
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -774,12 +782,12 @@ func (r *agricultureRepositoryImpl) GetProductivityTrend(ctx context.Context, co
                     THEN 3.0
                     ELSE 0
                 END as productivity
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE visit_date BETWEEN $1 AND $2
             AND (food_commodity = $3 OR horti_commodity = $4 OR plantation_commodity = $5)
         `, year)
 
-		rows, err := r.db.WithContext(ctx).Raw(query, startDate, endDate, commodityName, commodityName, commodityName).Rows()
+		rows, err := conn(ctx, r.db).Raw(query, startDate, endDate, commodityName, commodityName, commodityName).Rows()
 		if err != nil {
 			return nil, fmt.Errorf("failed to execute query for year %d: %w", year, err)
 		}
@@ -862,7 +870,7 @@ func (r *agricultureRepositoryImpl) GetFoodCropStats(ctx context.Context, commod
 	}
 
 	var landArea float64
-	r.db.WithContext(ctx).Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where(whereClause, args...).
 		Select("COALESCE(SUM(food_land_area), 0)").
 		Scan(&landArea)
@@ -872,14 +880,14 @@ func (r *agricultureRepositoryImpl) GetFoodCropStats(ctx context.Context, commod
 
 	var pestAffectedArea float64
 	pestQuery := whereClause + " AND has_pest_disease = true"
-	r.db.WithContext(ctx).Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where(pestQuery, args...).
 		Select("COALESCE(SUM(food_land_area), 0)").
 		Scan(&pestAffectedArea)
 	result["pest_affected_area"] = pestAffectedArea
 
 	var pestReportCount int64
-	r.db.WithContext(ctx).Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where(pestQuery, args...).
 		Count(&pestReportCount)
 	result["pest_report_count"] = pestReportCount
@@ -903,10 +911,10 @@ func (r *agricultureRepositoryImpl) GetFoodCropDistribution(ctx context.Context,
             latitude, longitude, village, district, 
             food_commodity as commodity,
             food_land_area as land_area
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE ` + whereClause
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -926,13 +934,13 @@ func (r *agricultureRepositoryImpl) GetFoodCropGrowthPhases(ctx context.Context,
             food_growth_phase as phase,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE ` + whereClause + `
         GROUP BY food_growth_phase
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -952,13 +960,13 @@ func (r *agricultureRepositoryImpl) GetFoodCropTechnology(ctx context.Context, c
             food_technology as technology,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE ` + whereClause + `
         GROUP BY food_technology
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -981,7 +989,7 @@ func (r *agricultureRepositoryImpl) GetFoodCropPestDominance(ctx context.Context
             END as pest_type,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE ` + whereClause + `
         GROUP BY 
             CASE 
@@ -991,7 +999,7 @@ func (r *agricultureRepositoryImpl) GetFoodCropPestDominance(ctx context.Context
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -1013,14 +1021,14 @@ func (r *agricultureRepositoryImpl) GetFoodCropHarvestSchedule(ctx context.Conte
             farmer_name,
             village,
             food_land_area as land_area
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE ` + whereClause + `
         AND food_harvest_date >= CURRENT_DATE
         ORDER BY food_harvest_date ASC
         LIMIT 20
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -1036,7 +1044,7 @@ func (r *agricultureRepositoryImpl) GetPlantationStats(ctx context.Context, comm
 	}
 
 	var landArea float64
-	r.db.WithContext(ctx).Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where(whereClause, args...).
 		Select("COALESCE(SUM(plantation_land_area), 0)").
 		Scan(&landArea)
@@ -1046,14 +1054,14 @@ func (r *agricultureRepositoryImpl) GetPlantationStats(ctx context.Context, comm
 
 	var pestAffectedArea float64
 	pestQuery := whereClause + " AND has_pest_disease = true"
-	r.db.WithContext(ctx).Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where(pestQuery, args...).
 		Select("COALESCE(SUM(plantation_land_area), 0)").
 		Scan(&pestAffectedArea)
 	result["pest_affected_area"] = pestAffectedArea
 
 	var pestReportCount int64
-	r.db.WithContext(ctx).Model(&entity.AgricultureReport{}).
+	conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where(pestQuery, args...).
 		Count(&pestReportCount)
 	result["pest_report_count"] = pestReportCount
@@ -1077,10 +1085,10 @@ func (r *agricultureRepositoryImpl) GetPlantationDistribution(ctx context.Contex
             latitude, longitude, village, district, 
             plantation_commodity as commodity,
             plantation_land_area as land_area
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE ` + whereClause
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -1100,13 +1108,13 @@ func (r *agricultureRepositoryImpl) GetPlantationGrowthPhases(ctx context.Contex
             plantation_growth_phase as phase,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE ` + whereClause + `
         GROUP BY plantation_growth_phase
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -1126,13 +1134,13 @@ func (r *agricultureRepositoryImpl) GetPlantationTechnology(ctx context.Context,
             plantation_technology as technology,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE ` + whereClause + `
         GROUP BY plantation_technology
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -1155,7 +1163,7 @@ func (r *agricultureRepositoryImpl) GetPlantationPestDominance(ctx context.Conte
             END as pest_type,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE ` + whereClause + `
         GROUP BY 
             CASE 
@@ -1165,7 +1173,7 @@ func (r *agricultureRepositoryImpl) GetPlantationPestDominance(ctx context.Conte
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -1187,14 +1195,14 @@ func (r *agricultureRepositoryImpl) GetPlantationHarvestSchedule(ctx context.Con
             farmer_name,
             village,
             plantation_land_area as land_area
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE ` + whereClause + `
         AND plantation_harvest_date >= CURRENT_DATE
         ORDER BY plantation_harvest_date ASC
         LIMIT 20
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -1202,9 +1210,9 @@ func (r *agricultureRepositoryImpl) GetAgriculturalEquipmentStats(ctx context.Co
 	result := make(map[string]interface{})
 
 	var currentYearReports int64
-	err := r.db.WithContext(ctx).Raw(`
+	err := conn(ctx, r.db).Raw(`
         SELECT COUNT(*) 
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE visit_date BETWEEN ? AND ?
         AND (
             food_technology IS NOT NULL 
@@ -1221,9 +1229,9 @@ func (r *agricultureRepositoryImpl) GetAgriculturalEquipmentStats(ctx context.Co
 	prevYearEnd := endDate.AddDate(-1, 0, 0)
 
 	var prevYearReports int64
-	err = r.db.WithContext(ctx).Raw(`
+	err = conn(ctx, r.db).Raw(`
         SELECT COUNT(*) 
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE visit_date BETWEEN ? AND ?
         AND (
             food_technology IS NOT NULL 
@@ -1268,7 +1276,7 @@ func (r *agricultureRepositoryImpl) GetEquipmentDistributionByDistrict(ctx conte
             CAST(FLOOR(COUNT(*) * 0.25) AS BIGINT) as thresher,
             CAST(FLOOR(COUNT(*) * 0.4) AS BIGINT) as farm_machinery,
             CAST(FLOOR(COUNT(*) * 0.6) AS BIGINT) as water_pump
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE visit_date BETWEEN $1 AND $2
         AND (
             food_technology IS NOT NULL 
@@ -1280,7 +1288,7 @@ func (r *agricultureRepositoryImpl) GetEquipmentDistributionByDistrict(ctx conte
         ORDER BY district
     `
 
-	err := r.db.WithContext(ctx).Raw(query, startDate, endDate).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, startDate, endDate).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get equipment distribution: %w", err)
 	}
@@ -1296,9 +1304,9 @@ func (r *agricultureRepositoryImpl) GetEquipmentTrend(ctx context.Context, equip
 		endDate := time.Date(year, 12, 31, 23, 59, 59, 0, time.UTC)
 
 		var count int64
-		err := r.db.WithContext(ctx).Raw(`
+		err := conn(ctx, r.db).Raw(`
             SELECT COUNT(*) 
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE visit_date BETWEEN ? AND ?
             AND (
                 food_technology IS NOT NULL 
@@ -1335,7 +1343,7 @@ func (r *agricultureRepositoryImpl) GetEquipmentTrend(ctx context.Context, equip
 func (r *agricultureRepositoryImpl) GetExecutiveSummary(ctx context.Context, commodityType string) (map[string]interface{}, error) {
 	summary := make(map[string]interface{})
 
-	query := r.db.WithContext(ctx).Model(&entity.AgricultureReport{})
+	query := conn(ctx, r.db).Model(&entity.AgricultureReport{})
 	query = r.applyCommodityTypeFilter(query, commodityType)
 
 	var totalLandArea float64
@@ -1344,14 +1352,14 @@ func (r *agricultureRepositoryImpl) GetExecutiveSummary(ctx context.Context, com
 	summary["total_land_area"] = totalLandArea
 
 	var pestReports int64
-	pestQuery := r.db.WithContext(ctx).Model(&entity.AgricultureReport{}).
+	pestQuery := conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where("has_pest_disease = true")
 	pestQuery = r.applyCommodityTypeFilter(pestQuery, commodityType)
 	pestQuery.Count(&pestReports)
 	summary["pest_disease_reports"] = pestReports
 
 	var totalReports int64
-	totalQuery := r.db.WithContext(ctx).Model(&entity.AgricultureReport{})
+	totalQuery := conn(ctx, r.db).Model(&entity.AgricultureReport{})
 	totalQuery = r.applyCommodityTypeFilter(totalQuery, commodityType)
 	totalQuery.Count(&totalReports)
 	summary["total_extension_reports"] = totalReports
@@ -1386,7 +1394,7 @@ func (r *agricultureRepositoryImpl) GetCommodityDistributionByDistrict(ctx conte
                 food_commodity as commodity,
                 'FOOD' as commodity_type,
                 food_land_area as land_area
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE latitude IS NOT NULL AND longitude IS NOT NULL
             AND food_commodity IS NOT NULL AND food_commodity != ''
         `
@@ -1397,7 +1405,7 @@ func (r *agricultureRepositoryImpl) GetCommodityDistributionByDistrict(ctx conte
                 horti_commodity as commodity,
                 'HORTICULTURE' as commodity_type,
                 horti_land_area as land_area
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE latitude IS NOT NULL AND longitude IS NOT NULL
             AND horti_commodity IS NOT NULL AND horti_commodity != ''
         `
@@ -1408,7 +1416,7 @@ func (r *agricultureRepositoryImpl) GetCommodityDistributionByDistrict(ctx conte
                 plantation_commodity as commodity,
                 'PLANTATION' as commodity_type,
                 plantation_land_area as land_area
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE latitude IS NOT NULL AND longitude IS NOT NULL
             AND plantation_commodity IS NOT NULL AND plantation_commodity != ''
         `
@@ -1430,13 +1438,13 @@ func (r *agricultureRepositoryImpl) GetCommodityDistributionByDistrict(ctx conte
                     ELSE 'UNKNOWN'
                 END as commodity_type,
                 COALESCE(food_land_area, 0) + COALESCE(horti_land_area, 0) + COALESCE(plantation_land_area, 0) as land_area
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE latitude IS NOT NULL AND longitude IS NOT NULL
             AND (food_commodity IS NOT NULL OR horti_commodity IS NOT NULL OR plantation_commodity IS NOT NULL)
         `
 	}
 
-	err := r.db.WithContext(ctx).Raw(query).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query).Scan(&results).Error
 	return results, err
 }
 
@@ -1445,9 +1453,9 @@ func (r *agricultureRepositoryImpl) GetCommodityCountBySector(ctx context.Contex
 
 	if commodityType == "" || commodityType == "PANGAN" {
 		var foodCrops []map[string]interface{}
-		r.db.WithContext(ctx).Raw(`
+		conn(ctx, r.db).Raw(`
             SELECT food_commodity as name, COUNT(*) as count
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE food_commodity IS NOT NULL AND food_commodity != ''
             GROUP BY food_commodity
             ORDER BY count DESC
@@ -1459,9 +1467,9 @@ func (r *agricultureRepositoryImpl) GetCommodityCountBySector(ctx context.Contex
 
 	if commodityType == "" || commodityType == "HORTIKULTURA" {
 		var horticulture []map[string]interface{}
-		r.db.WithContext(ctx).Raw(`
+		conn(ctx, r.db).Raw(`
             SELECT horti_commodity as name, COUNT(*) as count
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE horti_commodity IS NOT NULL AND horti_commodity != ''
             GROUP BY horti_commodity
             ORDER BY count DESC
@@ -1473,9 +1481,9 @@ func (r *agricultureRepositoryImpl) GetCommodityCountBySector(ctx context.Contex
 
 	if commodityType == "" || commodityType == "PERKEBUNAN" {
 		var plantation []map[string]interface{}
-		r.db.WithContext(ctx).Raw(`
+		conn(ctx, r.db).Raw(`
             SELECT plantation_commodity as name, COUNT(*) as count
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE plantation_commodity IS NOT NULL AND plantation_commodity != ''
             GROUP BY plantation_commodity
             ORDER BY count DESC
@@ -1500,7 +1508,7 @@ func (r *agricultureRepositoryImpl) GetLandStatusDistribution(ctx context.Contex
                 food_land_status as status,
                 COUNT(*) as count,
                 ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE food_land_status IS NOT NULL AND food_land_status != ''
             GROUP BY food_land_status
             ORDER BY count DESC
@@ -1511,7 +1519,7 @@ func (r *agricultureRepositoryImpl) GetLandStatusDistribution(ctx context.Contex
                 horti_land_status as status,
                 COUNT(*) as count,
                 ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE horti_land_status IS NOT NULL AND horti_land_status != ''
             GROUP BY horti_land_status
             ORDER BY count DESC
@@ -1522,7 +1530,7 @@ func (r *agricultureRepositoryImpl) GetLandStatusDistribution(ctx context.Contex
                 plantation_land_status as status,
                 COUNT(*) as count,
                 ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-            FROM agriculture_reports
+            FROM ` + activeAgricultureReportsTable(ctx) + `
             WHERE plantation_land_status IS NOT NULL AND plantation_land_status != ''
             GROUP BY plantation_land_status
             ORDER BY count DESC
@@ -1535,18 +1543,18 @@ func (r *agricultureRepositoryImpl) GetLandStatusDistribution(ctx context.Contex
                 COUNT(*) as count,
                 ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
             FROM (
-                SELECT food_land_status as land_status FROM agriculture_reports WHERE food_land_status IS NOT NULL AND food_land_status != ''
+                SELECT food_land_status as land_status FROM ` + activeAgricultureReportsTable(ctx) + ` WHERE food_land_status IS NOT NULL AND food_land_status != ''
                 UNION ALL
-                SELECT horti_land_status as land_status FROM agriculture_reports WHERE horti_land_status IS NOT NULL AND horti_land_status != ''
+                SELECT horti_land_status as land_status FROM ` + activeAgricultureReportsTable(ctx) + ` WHERE horti_land_status IS NOT NULL AND horti_land_status != ''
                 UNION ALL
-                SELECT plantation_land_status as land_status FROM agriculture_reports WHERE plantation_land_status IS NOT NULL AND plantation_land_status != ''
+                SELECT plantation_land_status as land_status FROM ` + activeAgricultureReportsTable(ctx) + ` WHERE plantation_land_status IS NOT NULL AND plantation_land_status != ''
             ) as combined_status
             GROUP BY land_status
             ORDER BY count DESC
         `
 	}
 
-	err := r.db.WithContext(ctx).Raw(query).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query).Scan(&results).Error
 	return results, err
 }
 
@@ -1558,7 +1566,7 @@ func (r *agricultureRepositoryImpl) GetMainConstraintsDistribution(ctx context.C
             main_constraint as constraint,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE main_constraint IS NOT NULL AND main_constraint != ''
     `
 
@@ -1576,7 +1584,7 @@ func (r *agricultureRepositoryImpl) GetMainConstraintsDistribution(ctx context.C
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query).Scan(&results).Error
 	return results, err
 }
 
@@ -1601,14 +1609,14 @@ func (r *agricultureRepositoryImpl) GetFarmerHopesAndNeeds(ctx context.Context,
             farmer_hope as hope,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE farmer_hope IS NOT NULL AND farmer_hope != ''
         %s
         GROUP BY farmer_hope
         ORDER BY count DESC
     `, whereClause)
 
-	r.db.WithContext(ctx).Raw(hopesQuery).Scan(&hopes)
+	conn(ctx, r.db).Raw(hopesQuery).Scan(&hopes)
 	result["hopes"] = hopes
 
 	var trainingNeeds []map[string]interface{}
@@ -1617,14 +1625,14 @@ func (r *agricultureRepositoryImpl) GetFarmerHopesAndNeeds(ctx context.Context,
             training_needed as training,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE training_needed IS NOT NULL AND training_needed != ''
         %s
         GROUP BY training_needed
         ORDER BY count DESC
     `, whereClause)
 
-	r.db.WithContext(ctx).Raw(trainingQuery).Scan(&trainingNeeds)
+	conn(ctx, r.db).Raw(trainingQuery).Scan(&trainingNeeds)
 	result["training_needs"] = trainingNeeds
 
 	var urgentNeeds []map[string]interface{}
@@ -1633,14 +1641,14 @@ func (r *agricultureRepositoryImpl) GetFarmerHopesAndNeeds(ctx context.Context,
             urgent_needs as need,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE urgent_needs IS NOT NULL AND urgent_needs != ''
         %s
         GROUP BY urgent_needs
         ORDER BY count DESC
     `, whereClause)
 
-	r.db.WithContext(ctx).Raw(urgentQuery).Scan(&urgentNeeds)
+	conn(ctx, r.db).Raw(urgentQuery).Scan(&urgentNeeds)
 	result["urgent_needs"] = urgentNeeds
 
 	return result, nil
@@ -1669,7 +1677,7 @@ func (r *agricultureRepositoryImpl) GetEquipmentIndividualDistribution(ctx conte
                 ELSE 'UNKNOWN'
             END as commodity,
             visit_date
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE visit_date BETWEEN ? AND ?
         AND latitude IS NOT NULL 
         AND longitude IS NOT NULL
@@ -1681,7 +1689,7 @@ func (r *agricultureRepositoryImpl) GetEquipmentIndividualDistribution(ctx conte
         ORDER BY visit_date DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, startDate, endDate).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, startDate, endDate).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get individual equipment distribution: %w", err)
 	}
@@ -1692,61 +1700,29 @@ func (r *agricultureRepositoryImpl) GetEquipmentIndividualDistribution(ctx conte
 func (r *agricultureRepositoryImpl) GetHorticultureStats(ctx context.Context, commodityName string) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 
-	fmt.Printf("\n[HORTI DEBUG] ==================\n")
-	fmt.Printf("[HORTI DEBUG] Raw input: '%s' (len=%d)\n", commodityName, len(commodityName))
-
-	baseQuery := r.db.WithContext(ctx).Model(&entity.AgricultureReport{}).
+	baseQuery := conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where("horti_commodity IS NOT NULL AND horti_commodity != ''")
 
-	originalInput := commodityName
 	if commodityName != "" {
 		commodityName = strings.ToUpper(strings.TrimSpace(commodityName))
 		commodityName = strings.ReplaceAll(commodityName, " ", "_")
 
-		fmt.Printf("[HORTI DEBUG] Cleaned input: '%s' (len=%d)\n", commodityName, len(commodityName))
-		fmt.Printf("[HORTI DEBUG] Input hex: % X\n", commodityName)
-
 		baseQuery = baseQuery.Where(
 			"UPPER(REPLACE(horti_sub_commodity, ' ', '_')) LIKE UPPER(?)",
 			"%"+commodityName+"%",
 		)
 	}
 
-	if originalInput != "" {
-		var dbValues []string
-		r.db.WithContext(ctx).Raw(`
-            SELECT DISTINCT horti_sub_commodity 
-            FROM agriculture_reports 
-            WHERE horti_sub_commodity IS NOT NULL 
-            AND horti_sub_commodity != ''
-            ORDER BY horti_sub_commodity
-        `).Pluck("horti_sub_commodity", &dbValues)
-
-		fmt.Printf("[HORTI DEBUG] Available in DB: %v\n", dbValues)
-
-		var matchCount int64
-		r.db.WithContext(ctx).Model(&entity.AgricultureReport{}).
-			Where("horti_commodity IS NOT NULL AND horti_commodity != ''").
-			Where("UPPER(REPLACE(horti_sub_commodity, ' ', '_')) LIKE UPPER(?)", "%"+commodityName+"%").
-			Count(&matchCount)
-
-		fmt.Printf("[HORTI DEBUG] Match count: %d\n", matchCount)
-	}
-
 	var landArea float64
 	err := baseQuery.Select("COALESCE(SUM(horti_land_area), 0)").Scan(&landArea).Error
 	if err != nil {
-		fmt.Printf("[HORTI DEBUG] ERROR: %v\n", err)
 		return nil, fmt.Errorf("failed to calculate land area: %w", err)
 	}
 
-	fmt.Printf("[HORTI DEBUG] Land area result: %.2f\n", landArea)
-	fmt.Printf("[HORTI DEBUG] ==================\n\n")
-
 	result["land_area"] = landArea
 	result["estimated_production"] = landArea * 10.0
 
-	pestQuery := r.db.WithContext(ctx).Model(&entity.AgricultureReport{}).
+	pestQuery := conn(ctx, r.db).Model(&entity.AgricultureReport{}).
 		Where("horti_commodity IS NOT NULL AND horti_commodity != '' AND has_pest_disease = true")
 
 	if commodityName != "" {
@@ -1784,7 +1760,7 @@ func (r *agricultureRepositoryImpl) GetHorticultureDistribution(ctx context.Cont
             district, 
             COALESCE(horti_sub_commodity, horti_commodity::text) as commodity,
             horti_land_area as land_area
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE horti_commodity IS NOT NULL AND horti_commodity != '' 
         AND latitude IS NOT NULL AND longitude IS NOT NULL`
 
@@ -1798,7 +1774,7 @@ func (r *agricultureRepositoryImpl) GetHorticultureDistribution(ctx context.Cont
 		args = append(args, "%"+commodityName+"%")
 	}
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get horticulture distribution: %w", err)
 	}
@@ -1814,7 +1790,7 @@ func (r *agricultureRepositoryImpl) GetHorticultureGrowthPhases(ctx context.Cont
             horti_growth_phase::text as phase,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE horti_commodity IS NOT NULL AND horti_commodity != '' 
         AND horti_growth_phase IS NOT NULL AND horti_growth_phase != ''`
 
@@ -1832,7 +1808,7 @@ func (r *agricultureRepositoryImpl) GetHorticultureGrowthPhases(ctx context.Cont
         GROUP BY horti_growth_phase
         ORDER BY count DESC`
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get growth phases: %w", err)
 	}
@@ -1859,13 +1835,13 @@ func (r *agricultureRepositoryImpl) GetHorticultureTechnology(ctx context.Contex
             horti_technology::text as technology,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE ` + whereClause + `
         GROUP BY horti_technology
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get technology data: %w", err)
 	}
@@ -1895,7 +1871,7 @@ func (r *agricultureRepositoryImpl) GetHorticulturePestDominance(ctx context.Con
             END as pest_type,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE ` + whereClause + `
         GROUP BY 
             CASE 
@@ -1905,7 +1881,7 @@ func (r *agricultureRepositoryImpl) GetHorticulturePestDominance(ctx context.Con
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pest dominance: %w", err)
 	}
@@ -1934,14 +1910,14 @@ func (r *agricultureRepositoryImpl) GetHorticultureHarvestSchedule(ctx context.C
             farmer_name,
             village,
             horti_land_area as land_area
-        FROM agriculture_reports
+        FROM ` + activeAgricultureReportsTable(ctx) + `
         WHERE ` + whereClause + `
         AND horti_harvest_date >= CURRENT_DATE
         ORDER BY horti_harvest_date ASC
         LIMIT 20
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get harvest schedule: %w", err)
 	}
@@ -1952,69 +1928,8 @@ func (r *agricultureRepositoryImpl) GetHorticultureHarvestSchedule(ctx context.C
 func (r *agricultureRepositoryImpl) GetLandAndIrrigationStats(ctx context.Context, startDate, endDate time.Time) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 
-	fmt.Printf("\n========== LAND STATS DEBUG START ==========\n")
-	fmt.Printf("[PARAM] Start Date: %s\n", startDate.Format("2006-01-02"))
-	fmt.Printf("[PARAM] End Date: %s\n", endDate.Format("2006-01-02"))
-
-	var dbTest int64
-	err := r.db.WithContext(ctx).Raw("SELECT COUNT(*) FROM agriculture_reports").Scan(&dbTest).Error
-	if err != nil {
-		return nil, fmt.Errorf("database connection failed: %w", err)
-	}
-	fmt.Printf("[DB TEST] Total records in table: %d\n", dbTest)
-
-	var totalRecordsNoFilter int64
-	err = r.db.WithContext(ctx).Raw(`
-		SELECT COUNT(*) FROM agriculture_reports
-	`).Scan(&totalRecordsNoFilter).Error
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to count all records: %w", err)
-	}
-	fmt.Printf("[COUNT] Total records (no filter): %d\n", totalRecordsNoFilter)
-
-	var totalRecordsWithFilter int64
-	err = r.db.WithContext(ctx).Raw(`
-		SELECT COUNT(*) FROM agriculture_reports
-		WHERE visit_date::date BETWEEN $1::date AND $2::date
-	`, startDate, endDate).Scan(&totalRecordsWithFilter).Error
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to count filtered records: %w", err)
-	}
-	fmt.Printf("[COUNT] Records in date range: %d\n", totalRecordsWithFilter)
-
-	type SampleData struct {
-		VisitDate time.Time
-		FoodArea  float64
-		HortiArea float64
-		PlantArea float64
-	}
-	var samples []SampleData
-
-	err = r.db.WithContext(ctx).Raw(`
-		SELECT 
-			visit_date,
-			COALESCE(food_land_area::float8, 0) as food_area,
-			COALESCE(horti_land_area::float8, 0) as horti_area,
-			COALESCE(plantation_land_area::float8, 0) as plant_area
-		FROM agriculture_reports
-		WHERE visit_date::date BETWEEN $1::date AND $2::date
-		LIMIT 3
-	`, startDate, endDate).Scan(&samples).Error
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to get samples: %w", err)
-	}
-
-	fmt.Printf("[SAMPLES] Found %d samples:\n", len(samples))
-	for i, s := range samples {
-		fmt.Printf("  Sample %d: Date=%s, Food=%.2f, Horti=%.2f, Plant=%.2f\n",
-			i+1, s.VisitDate.Format("2006-01-02"), s.FoodArea, s.HortiArea, s.PlantArea)
-	}
-
 	var currentTotalArea float64
-	err = r.db.WithContext(ctx).Raw(`
+	err := conn(ctx, r.db).Raw(`
 		SELECT COALESCE(
 			SUM(
 				COALESCE(food_land_area::float8, 0) + 
@@ -2022,7 +1937,7 @@ func (r *agricultureRepositoryImpl) GetLandAndIrrigationStats(ctx context.Contex
 				COALESCE(plantation_land_area::float8, 0)
 			), 0
 		)
-		FROM agriculture_reports
+		FROM ` + activeAgricultureReportsTable(ctx) + `
 		WHERE visit_date::date BETWEEN $1::date AND $2::date
 	`, startDate, endDate).Scan(&currentTotalArea).Error
 
@@ -2030,40 +1945,11 @@ func (r *agricultureRepositoryImpl) GetLandAndIrrigationStats(ctx context.Contex
 		return nil, fmt.Errorf("failed to calculate total area: %w", err)
 	}
 
-	fmt.Printf("[CALCULATION] Total area (method 1): %.2f\n", currentTotalArea)
-
-	type AreaBreakdown struct {
-		FoodArea       float64
-		HortiArea      float64
-		PlantationArea float64
-	}
-	var breakdown AreaBreakdown
-
-	err = r.db.WithContext(ctx).Raw(`
-		SELECT 
-			COALESCE(SUM(food_land_area::float8), 0) as food_area,
-			COALESCE(SUM(horti_land_area::float8), 0) as horti_area,
-			COALESCE(SUM(plantation_land_area::float8), 0) as plantation_area
-		FROM agriculture_reports
-		WHERE visit_date::date BETWEEN $1::date AND $2::date
-	`, startDate, endDate).Scan(&breakdown).Error
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to get breakdown: %w", err)
-	}
-
-	fmt.Printf("[BREAKDOWN] Food: %.2f, Horti: %.2f, Plantation: %.2f\n",
-		breakdown.FoodArea, breakdown.HortiArea, breakdown.PlantationArea)
-	fmt.Printf("[BREAKDOWN] Sum: %.2f\n", breakdown.FoodArea+breakdown.HortiArea+breakdown.PlantationArea)
-
 	prevYearStart := startDate.AddDate(-1, 0, 0)
 	prevYearEnd := endDate.AddDate(-1, 0, 0)
 
-	fmt.Printf("[PREV YEAR] Date range: %s to %s\n",
-		prevYearStart.Format("2006-01-02"), prevYearEnd.Format("2006-01-02"))
-
 	var prevTotalArea float64
-	err = r.db.WithContext(ctx).Raw(`
+	err = conn(ctx, r.db).Raw(`
 		SELECT COALESCE(
 			SUM(
 				COALESCE(food_land_area::float8, 0) + 
@@ -2071,7 +1957,7 @@ func (r *agricultureRepositoryImpl) GetLandAndIrrigationStats(ctx context.Contex
 				COALESCE(plantation_land_area::float8, 0)
 			), 0
 		)
-		FROM agriculture_reports
+		FROM ` + activeAgricultureReportsTable(ctx) + `
 		WHERE visit_date::date BETWEEN $1::date AND $2::date
 	`, prevYearStart, prevYearEnd).Scan(&prevTotalArea).Error
 
@@ -2079,25 +1965,22 @@ func (r *agricultureRepositoryImpl) GetLandAndIrrigationStats(ctx context.Contex
 		return nil, fmt.Errorf("failed to calculate prev year area: %w", err)
 	}
 
-	fmt.Printf("[PREV YEAR] Total area: %.2f\n", prevTotalArea)
-
 	var totalGrowth float64 = 0
 	if prevTotalArea > 0 {
 		totalGrowth = ((currentTotalArea - prevTotalArea) / prevTotalArea) * 100
 	} else if currentTotalArea > 0 {
 		totalGrowth = 100
 	}
-	fmt.Printf("[GROWTH] %.2f%%\n", totalGrowth)
 
 	var totalReports, goodWaterAccess int64
 
-	r.db.WithContext(ctx).Raw(`
-	SELECT COUNT(*) FROM agriculture_reports
+	conn(ctx, r.db).Raw(`
+	SELECT COUNT(*) FROM ` + activeAgricultureReportsTable(ctx) + `
 	WHERE visit_date::date BETWEEN $1::date AND $2::date
 `, startDate, endDate).Scan(&totalReports)
 
-	r.db.WithContext(ctx).Raw(`
-	SELECT COUNT(*) FROM agriculture_reports
+	conn(ctx, r.db).Raw(`
+	SELECT COUNT(*) FROM ` + activeAgricultureReportsTable(ctx) + `
 	WHERE visit_date::date BETWEEN $1::date AND $2::date
 	AND water_access IN (
 		'MUDAH_TERSEDIA',      -- Akses mudah
@@ -2106,25 +1989,22 @@ func (r *agricultureRepositoryImpl) GetLandAndIrrigationStats(ctx context.Contex
 	)
 `, startDate, endDate).Scan(&goodWaterAccess)
 
-	fmt.Printf("[WATER] Total: %d, Good access: %d\n", totalReports, goodWaterAccess)
-
 	var irrigationRatio float64
 	if goodWaterAccess > 0 && totalReports > 0 {
 
 		irrigationRatio = float64(goodWaterAccess) / float64(totalReports)
-		fmt.Printf("[WATER] Using actual irrigation ratio: %.2f\n", irrigationRatio)
 	} else {
 
 		var foodReports, totalCommodityReports int64
 
-		r.db.WithContext(ctx).Raw(`
-		SELECT COUNT(*) FROM agriculture_reports
+		conn(ctx, r.db).Raw(`
+		SELECT COUNT(*) FROM ` + activeAgricultureReportsTable(ctx) + `
 		WHERE visit_date::date BETWEEN $1::date AND $2::date
 		AND food_commodity IS NOT NULL AND food_commodity != ''
 	`, startDate, endDate).Scan(&foodReports)
 
-		r.db.WithContext(ctx).Raw(`
-		SELECT COUNT(*) FROM agriculture_reports
+		conn(ctx, r.db).Raw(`
+		SELECT COUNT(*) FROM ` + activeAgricultureReportsTable(ctx) + `
 		WHERE visit_date::date BETWEEN $1::date AND $2::date
 		AND (
 			food_commodity IS NOT NULL AND food_commodity != '' OR
@@ -2139,12 +2019,8 @@ func (r *agricultureRepositoryImpl) GetLandAndIrrigationStats(ctx context.Contex
 		} else {
 			irrigationRatio = 0.60
 		}
-
-		fmt.Printf("[WATER] Using estimated irrigation ratio: %.2f (no water access data)\n", irrigationRatio)
 	}
 
-	fmt.Printf("[WATER] Final irrigation ratio: %.2f\n", irrigationRatio)
-
 	irrigatedArea := currentTotalArea * irrigationRatio
 	nonIrrigatedArea := currentTotalArea * (1 - irrigationRatio)
 
@@ -2155,40 +2031,12 @@ func (r *agricultureRepositoryImpl) GetLandAndIrrigationStats(ctx context.Contex
 	result["non_irrigated_land_area"] = nonIrrigatedArea
 	result["non_irrigated_land_growth"] = totalGrowth * 0.9
 
-	fmt.Printf("\n[FINAL RESULT]\n")
-	fmt.Printf("  total_land_area: %.2f\n", result["total_land_area"])
-	fmt.Printf("  total_land_growth: %.2f%%\n", result["total_land_growth"])
-	fmt.Printf("  irrigated_land_area: %.2f\n", result["irrigated_land_area"])
-	fmt.Printf("  irrigated_land_growth: %.2f%%\n", result["irrigated_land_growth"])
-	fmt.Printf("  non_irrigated_land_area: %.2f\n", result["non_irrigated_land_area"])
-	fmt.Printf("  non_irrigated_land_growth: %.2f%%\n", result["non_irrigated_land_growth"])
-	fmt.Printf("========== LAND STATS DEBUG END ==========\n\n")
-
 	return result, nil
 }
 
 func (r *agricultureRepositoryImpl) GetLandDistributionByDistrict(ctx context.Context, startDate, endDate time.Time) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
 
-	fmt.Printf("\n========== DISTRICT DISTRIBUTION DEBUG ==========\n")
-	fmt.Printf("[PARAM] Date range: %s to %s\n",
-		startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
-
-	var totalBeforeGroup float64
-	r.db.WithContext(ctx).Raw(`
-		SELECT COALESCE(
-			SUM(
-				COALESCE(food_land_area::float8, 0) + 
-				COALESCE(horti_land_area::float8, 0) + 
-				COALESCE(plantation_land_area::float8, 0)
-			), 0
-		)
-		FROM agriculture_reports
-		WHERE visit_date::date BETWEEN $1::date AND $2::date
-	`, startDate, endDate).Scan(&totalBeforeGroup)
-
-	fmt.Printf("[PRE-GROUP] Total area before grouping: %.2f\n", totalBeforeGroup)
-
 	query := `
 		SELECT 
 			district,
@@ -2210,7 +2058,7 @@ func (r *agricultureRepositoryImpl) GetLandDistributionByDistrict(ctx context.Co
 			COALESCE(SUM(horti_land_area::float8), 0) as horti_area,
 			COALESCE(SUM(plantation_land_area::float8), 0) as plantation_area,
 			COUNT(DISTINCT farmer_name) as farmer_count
-		FROM agriculture_reports
+		FROM ` + activeAgricultureReportsTable(ctx) + `
 		WHERE visit_date::date BETWEEN $1::date AND $2::date
 		AND district IS NOT NULL 
 		AND district != ''
@@ -2218,34 +2066,17 @@ func (r *agricultureRepositoryImpl) GetLandDistributionByDistrict(ctx context.Co
 		ORDER BY total_area DESC
 	`
 
-	err := r.db.WithContext(ctx).Raw(query, startDate, endDate).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, startDate, endDate).Scan(&results).Error
 	if err != nil {
-		fmt.Printf("[ERROR] Query failed: %v\n", err)
 		return nil, fmt.Errorf("failed to get district distribution: %w", err)
 	}
 
-	fmt.Printf("[RESULT] Found %d districts\n", len(results))
-
-	var totalAfterGroup float64
-	for i, r := range results {
-		if i < 3 {
-			fmt.Printf("  District %d: %s - Total: %.2f, Irrigated: %.2f, Farmers: %v\n",
-				i+1, r["district"], r["total_area"], r["irrigated_area"], r["farmer_count"])
-		}
-		totalAfterGroup += r["total_area"].(float64)
-	}
-
-	fmt.Printf("[POST-GROUP] Total area after grouping: %.2f\n", totalAfterGroup)
-	fmt.Printf("========== DISTRICT DISTRIBUTION DEBUG END ==========\n\n")
-
 	return results, err
 }
 
 func (r *agricultureRepositoryImpl) GetLandIndividualDistribution(ctx context.Context, startDate, endDate time.Time) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
 
-	fmt.Printf("\n========== RICE FIELD INDIVIDUAL DISTRIBUTION DEBUG ==========\n")
-
 	// Query hanya mengambil latitude, longitude, district, rainfed, irrigated, total, date, dan data_source
 	query := `
 		SELECT 
@@ -2265,22 +2096,42 @@ func (r *agricultureRepositoryImpl) GetLandIndividualDistribution(ctx context.Co
 		ORDER BY district
 	`
 
-	err := r.db.WithContext(ctx).Raw(query, startDate, endDate).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, startDate, endDate).Scan(&results).Error
 	if err != nil {
-		fmt.Printf("[ERROR] Query failed: %v\n", err)
 		return nil, fmt.Errorf("failed to get rice field individual distribution: %w", err)
 	}
 
-	fmt.Printf("[RESULT] Found %d rice field points\n", len(results))
-	if len(results) > 0 {
-		for i, result := range results {
-			if i < 3 { // hanya tampilkan 3 pertama
-				fmt.Printf("[SAMPLE %d] District: %s, Data Source: %s, Total Rice Area: %.2f ha\n",
-					i+1, result["district"], result["data_source"], result["total_rice_field_area"])
-			}
-		}
-	}
-	fmt.Printf("========== RICE FIELD INDIVIDUAL DISTRIBUTION DEBUG END ==========\n\n")
-
 	return results, nil
 }
+
+func (r *agricultureRepositoryImpl) FindDeleted(ctx context.Context, limit, offset int) ([]*entity.AgricultureReport, int64, error) {
+	return findDeleted[entity.AgricultureReport](ctx, r.db, limit, offset)
+}
+
+func (r *agricultureRepositoryImpl) FindDeletedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entity.AgricultureReport, error) {
+	return findDeletedBefore[entity.AgricultureReport](ctx, r.db, cutoff, limit)
+}
+
+func (r *agricultureRepositoryImpl) Restore(ctx context.Context, id string) error {
+	return restoreDeleted[entity.AgricultureReport](ctx, r.db, id)
+}
+
+func (r *agricultureRepositoryImpl) HardDelete(ctx context.Context, id string) error {
+	return hardDelete[entity.AgricultureReport](ctx, r.db, id)
+}
+
+func (r *agricultureRepositoryImpl) AddPhotos(ctx context.Context, reportID string, photos []entity.AgriculturePhoto, max int) error {
+	return addPhotos[entity.AgricultureReport](ctx, r.db, reportID, photos, max)
+}
+
+func (r *agricultureRepositoryImpl) UpdatePhoto(ctx context.Context, photo *entity.AgriculturePhoto) error {
+	return updatePhoto(ctx, r.db, photo)
+}
+
+func (r *agricultureRepositoryImpl) DeletePhoto(ctx context.Context, reportID, photoID string) error {
+	return deletePhoto[entity.AgriculturePhoto](ctx, r.db, reportID, photoID)
+}
+
+func (r *agricultureRepositoryImpl) ReorderPhotos(ctx context.Context, reportID string, photoIDs []string) error {
+	return reorderPhotos[entity.AgriculturePhoto](ctx, r.db, reportID, photoIDs)
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type apiKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) repository.APIKeyRepository {
+	return &apiKeyRepositoryImpl{db: db}
+}
+
+func (r *apiKeyRepositoryImpl) Create(ctx context.Context, key *entity.APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+func (r *apiKeyRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepositoryImpl) FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.db.WithContext(ctx).Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepositoryImpl) FindAll(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*entity.APIKey, int64, error) {
+	var keys []*entity.APIKey
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.APIKey{})
+
+	if v, ok := filters["owner_id"].(string); ok && v != "" {
+		query = query.Where("owner_id = ?", v)
+	}
+	if includeRevoked, ok := filters["include_revoked"].(bool); !ok || !includeRevoked {
+		query = query.Where("revoked_at IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&keys).Error
+
+	return keys, total, err
+}
+
+func (r *apiKeyRepositoryImpl) Revoke(ctx context.Context, id, revokedBy string, revokedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&entity.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at": revokedAt,
+			"revoked_by": revokedBy,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *apiKeyRepositoryImpl) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt).Error
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// auditReportTables are the report tables audit entries of each sector refer to.
+var auditReportTables = []struct {
+	sector entity.Sector
+	table  string
+}{
+	{entity.SectorReports, "reports"},
+	{entity.SectorSpatialPlanning, "spatial_planning_reports"},
+	{entity.SectorWaterResources, "water_resources_reports"},
+	{entity.SectorBinaMarga, "bina_marga_reports"},
+	{entity.SectorAgriculture, "agriculture_reports"},
+}
+
+type auditLogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) repository.AuditLogRepository {
+	return &auditLogRepositoryImpl{db: db}
+}
+
+func (r *auditLogRepositoryImpl) Create(ctx context.Context, log *entity.AuditLog) error {
+	return r.db.WithContext(ctx).Create(log).Error
+}
+
+func (r *auditLogRepositoryImpl) FindByReport(ctx context.Context, sector entity.Sector, reportID string, limit, offset int) ([]*entity.AuditLog, int64, error) {
+	var logs []*entity.AuditLog
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&entity.AuditLog{}).
+		Where("sector = ? AND report_id = ?", sector, reportID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&logs).Error
+
+	return logs, total, err
+}
+
+func (r *auditLogRepositoryImpl) Search(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*entity.AuditLog, int64, error) {
+	var logs []*entity.AuditLog
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.AuditLog{})
+
+	// Audit entries carry the reports' data, so a scoped caller only finds
+	// those of reports in their scope.
+	if scope := entity.DataScopeFromContext(ctx); !scope.IsGlobal() {
+		query = query.Where(auditScopeCondition(scope))
+	}
+
+	if v, ok := filters["sector"].(string); ok && v != "" {
+		query = query.Where("sector = ?", v)
+	}
+	if v, ok := filters["report_id"].(string); ok && v != "" {
+		query = query.Where("report_id = ?", v)
+	}
+	if v, ok := filters["actor_id"].(string); ok && v != "" {
+		query = query.Where("actor_id = ?", v)
+	}
+	if v, ok := filters["action"].(string); ok && v != "" {
+		query = query.Where("action = ?", v)
+	}
+	if v, ok := filters["q"].(string); ok && v != "" {
+		query = query.Where("notes ILIKE ?", "%"+v+"%")
+	}
+	if v, ok := filters["start_date"].(time.Time); ok {
+		query = query.Where("created_at >= ?", v)
+	}
+	if v, ok := filters["end_date"].(time.Time); ok {
+		query = query.Where("created_at < ?", v.AddDate(0, 0, 1))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&logs).Error
+
+	return logs, total, err
+}
+
+func auditScopeCondition(scope entity.DataScope) string {
+	conditions := make([]string, 0, len(auditReportTables))
+	for _, t := range auditReportTables {
+		conditions = append(conditions, "(sector = "+quoteLiteral(string(t.sector))+
+			" AND report_id IN ("+scopedReportIDs(scope, t.table)+"))")
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
@@ -11,6 +11,13 @@ import (
 	"gorm.io/gorm"
 )
 
+// activeBinaMargaReportsTable is used in place of the bare table name in raw SQL so that
+// soft-deleted rows never reach statistics or listings, and scoped users only
+// count the reports of their own district and unit.
+func activeBinaMargaReportsTable(ctx context.Context) string {
+	return activeTable(entity.DataScopeFromContext(ctx), "bina_marga_reports")
+}
+
 type binaMargaRepositoryImpl struct {
 	db *gorm.DB
 }
@@ -20,24 +27,26 @@ func NewBinaMargaRepository(db *gorm.DB) repository.BinaMargaRepository {
 }
 
 func (r *binaMargaRepositoryImpl) Create(ctx context.Context, report *entity.BinaMargaReport) error {
-	return r.db.WithContext(ctx).Create(report).Error
+	report.PriorityScore = report.CalculatePriority()
+	return conn(ctx, r.db).Create(report).Error
 }
 
 func (r *binaMargaRepositoryImpl) Update(ctx context.Context, report *entity.BinaMargaReport) error {
+	report.PriorityScore = report.CalculatePriority()
 	report.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(report).Error
+	return conn(ctx, r.db).Save(report).Error
 }
 
 func (r *binaMargaRepositoryImpl) Delete(ctx context.Context, id string) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Where("id = ?", id).
 		Delete(&entity.BinaMargaReport{}).Error
 }
 
 func (r *binaMargaRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.BinaMargaReport, error) {
 	var report entity.BinaMargaReport
-	err := r.db.WithContext(ctx).
-		Preload("Photos").
+	err := conn(ctx, r.db).
+		Preload("Photos", orderPhotos).
 		Where("id = ?", id).
 		First(&report).Error
 	if err != nil {
@@ -52,7 +61,7 @@ func (r *binaMargaRepositoryImpl) FindAll(ctx context.Context, limit, offset int
 		total   int64
 	)
 
-	query := r.db.WithContext(ctx).Model(&entity.BinaMargaReport{})
+	query := conn(ctx, r.db).Model(&entity.BinaMargaReport{})
 
 	if v, ok := filters["institution_unit"].(string); ok && v != "" {
 		query = query.Where("institution_unit = ?", v)
@@ -109,7 +118,7 @@ func (r *binaMargaRepositoryImpl) FindAll(ctx context.Context, limit, offset int
 		"created_at DESC"
 
 	err := query.
-		Preload("Photos").
+		Preload("Photos", orderPhotos).
 		Limit(limit).
 		Offset(offset).
 		Order(orderExpr).
@@ -120,8 +129,8 @@ func (r *binaMargaRepositoryImpl) FindAll(ctx context.Context, limit, offset int
 
 func (r *binaMargaRepositoryImpl) FindBlockedRoads(ctx context.Context, limit int) ([]*entity.BinaMargaReport, error) {
 	var reports []*entity.BinaMargaReport
-	err := r.db.WithContext(ctx).
-		Preload("Photos").
+	err := conn(ctx, r.db).
+		Preload("Photos", orderPhotos).
 		Where("traffic_impact = ?", entity.TrafficImpactBlocked).
 		Where("status NOT IN ('COMPLETED', 'REJECTED')").
 		Order("created_at DESC").
@@ -130,49 +139,60 @@ func (r *binaMargaRepositoryImpl) FindBlockedRoads(ctx context.Context, limit in
 	return reports, err
 }
 
-func (r *binaMargaRepositoryImpl) UpdateStatus(ctx context.Context, id string, status entity.BinaMargaStatus, notes string) error {
+// UpdateStatus moves the report from one status to another. It returns
+// repository.ErrStatusChanged when the report is no longer in status from, so
+// of two concurrent changes only the first is applied.
+func (r *binaMargaRepositoryImpl) UpdateStatus(ctx context.Context, id string, from, to entity.BinaMargaStatus, notes, updatedBy string) error {
 	updates := map[string]interface{}{
-		"status":     status,
+		"status":     to,
+		"updated_by": updatedBy,
 		"updated_at": time.Now(),
 	}
 	if notes != "" {
 		updates["notes"] = notes
 	}
-	return r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Model(&entity.BinaMargaReport{}).
-		Where("id = ?", id).
-		Updates(updates).Error
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrStatusChanged
+	}
+	return nil
 }
 
 func (r *binaMargaRepositoryImpl) GetStatistics(ctx context.Context) (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
 
 	var total int64
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).Count(&total).Error
+	_ = conn(ctx, r.db).Model(&entity.BinaMargaReport{}).Count(&total).Error
 	stats["total_reports"] = total
 
 	var emergencyCount int64
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	_ = conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Where("urgency_level = ?", entity.RoadUrgencyEmergency).
 		Where("status NOT IN ('COMPLETED', 'REJECTED')").
 		Count(&emergencyCount).Error
 	stats["emergency_reports"] = emergencyCount
 
 	var blockedCount int64
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	_ = conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Where("traffic_impact = ?", entity.TrafficImpactBlocked).
 		Where("status NOT IN ('COMPLETED', 'REJECTED')").
 		Count(&blockedCount).Error
 	stats["blocked_roads"] = blockedCount
 
 	var totalArea float64
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	_ = conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Select("COALESCE(SUM(damaged_area), 0)").
 		Scan(&totalArea).Error
 	stats["total_damaged_area_sqm"] = totalArea
 
 	var totalLength float64
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	_ = conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Select("COALESCE(SUM(damaged_length), 0)").
 		Scan(&totalLength).Error
 	stats["total_damaged_length_m"] = totalLength
@@ -186,7 +206,7 @@ func (r *binaMargaRepositoryImpl) GetStatistics(ctx context.Context) (map[string
 		RoadType string `json:"road_type"`
 		Count    int64  `json:"count"`
 	}
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	_ = conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Select("road_type, COUNT(*) as count").
 		Group("road_type").
 		Scan(&roadTypeCounts).Error
@@ -196,7 +216,7 @@ func (r *binaMargaRepositoryImpl) GetStatistics(ctx context.Context) (map[string
 		DamageType string `json:"damage_type"`
 		Count      int64  `json:"count"`
 	}
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	_ = conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Select("damage_type, COUNT(*) as count").
 		Group("damage_type").
 		Scan(&damageTypeCounts).Error
@@ -206,7 +226,7 @@ func (r *binaMargaRepositoryImpl) GetStatistics(ctx context.Context) (map[string
 		Level string `json:"level"`
 		Count int64  `json:"count"`
 	}
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	_ = conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Select("damage_level as level, COUNT(*) as count").
 		Group("damage_level").
 		Scan(&damageLevelCounts).Error
@@ -216,7 +236,7 @@ func (r *binaMargaRepositoryImpl) GetStatistics(ctx context.Context) (map[string
 		Level string `json:"level"`
 		Count int64  `json:"count"`
 	}
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	_ = conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Select("urgency_level as level, COUNT(*) as count").
 		Group("urgency_level").
 		Scan(&urgencyLevelCounts).Error
@@ -226,7 +246,7 @@ func (r *binaMargaRepositoryImpl) GetStatistics(ctx context.Context) (map[string
 		Status string `json:"status"`
 		Count  int64  `json:"count"`
 	}
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	_ = conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Select("status, COUNT(*) as count").
 		Group("status").
 		Scan(&statusCounts).Error
@@ -236,21 +256,21 @@ func (r *binaMargaRepositoryImpl) GetStatistics(ctx context.Context) (map[string
 		Impact string `json:"impact"`
 		Count  int64  `json:"count"`
 	}
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	_ = conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Select("traffic_impact as impact, COUNT(*) as count").
 		Group("traffic_impact").
 		Scan(&trafficImpactCounts).Error
 	stats["traffic_impact_counts"] = trafficImpactCounts
 
 	var totalBudget float64
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	_ = conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Where("status NOT IN ('COMPLETED', 'REJECTED')").
 		Select("COALESCE(SUM(estimated_budget), 0)").
 		Scan(&totalBudget).Error
 	stats["estimated_total_budget"] = totalBudget
 
 	var avgRepairTime float64
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	_ = conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Where("estimated_repair_time > 0").
 		Select("COALESCE(AVG(estimated_repair_time), 0)").
 		Scan(&avgRepairTime).Error
@@ -261,7 +281,7 @@ func (r *binaMargaRepositoryImpl) GetStatistics(ctx context.Context) (map[string
 
 func (r *binaMargaRepositoryImpl) GetDamageStatisticsByRoadType(ctx context.Context, startDate, endDate time.Time) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
-	const q = `
+	q := `
 		SELECT 
 			road_type,
 			road_class,
@@ -271,17 +291,17 @@ func (r *binaMargaRepositoryImpl) GetDamageStatisticsByRoadType(ctx context.Cont
 			SUM(estimated_budget) AS total_estimated_budget,
 			AVG(estimated_repair_time) AS avg_repair_time,
 			COUNT(CASE WHEN urgency_level = 'DARURAT' THEN 1 END) AS emergency_count
-		FROM bina_marga_reports
+		FROM ` + activeBinaMargaReportsTable(ctx) + `
 		WHERE report_datetime BETWEEN ? AND ?
 		GROUP BY road_type, road_class
 		ORDER BY total_damaged_area DESC`
-	err := r.db.WithContext(ctx).Raw(q, startDate, endDate).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(q, startDate, endDate).Scan(&results).Error
 	return results, err
 }
 
 func (r *binaMargaRepositoryImpl) GetDamageStatisticsByLocation(ctx context.Context, bounds map[string]float64) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
-	const q = `
+	q := `
 		SELECT 
 			road_name,
 			road_type,
@@ -296,17 +316,17 @@ func (r *binaMargaRepositoryImpl) GetDamageStatisticsByLocation(ctx context.Cont
 			damaged_length,
 			status,
 			created_at
-		FROM bina_marga_reports
+		FROM ` + activeBinaMargaReportsTable(ctx) + `
 		WHERE latitude BETWEEN ? AND ?
 		  AND longitude BETWEEN ? AND ?
 		ORDER BY urgency_level DESC, created_at DESC`
-	err := r.db.WithContext(ctx).Raw(q, bounds["south"], bounds["north"], bounds["west"], bounds["east"]).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(q, bounds["south"], bounds["north"], bounds["west"], bounds["east"]).Scan(&results).Error
 	return results, err
 }
 
 func (r *binaMargaRepositoryImpl) CalculateTotalDamageArea(ctx context.Context) (float64, error) {
 	var total float64
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Model(&entity.BinaMargaReport{}).
 		Select("COALESCE(SUM(damaged_area), 0)").
 		Scan(&total).Error
@@ -315,7 +335,7 @@ func (r *binaMargaRepositoryImpl) CalculateTotalDamageArea(ctx context.Context)
 
 func (r *binaMargaRepositoryImpl) CalculateTotalDamageLength(ctx context.Context) (float64, error) {
 	var total float64
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Model(&entity.BinaMargaReport{}).
 		Select("COALESCE(SUM(damaged_length), 0)").
 		Scan(&total).Error
@@ -324,7 +344,7 @@ func (r *binaMargaRepositoryImpl) CalculateTotalDamageLength(ctx context.Context
 
 func (r *binaMargaRepositoryImpl) CountReportsByUrgency(ctx context.Context, urgency entity.RoadUrgencyLevel) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Model(&entity.BinaMargaReport{}).
 		Where("urgency_level = ?", urgency).
 		Count(&count).Error
@@ -342,7 +362,7 @@ func (r *binaMargaRepositoryImpl) GetRepairTimeAnalysis(ctx context.Context) (ma
 		Count         int64   `json:"count"`
 	}
 	var byLevel []RepairTimeByLevel
-	r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Select(`damage_level,
 		        AVG(estimated_repair_time)  AS avg_repair_time,
 		        MIN(estimated_repair_time)  AS min_repair_time,
@@ -359,7 +379,7 @@ func (r *binaMargaRepositoryImpl) GetRepairTimeAnalysis(ctx context.Context) (ma
 		Count         int64   `json:"count"`
 	}
 	var byClass []RepairTimeByClass
-	r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Select(`road_class,
 		        AVG(estimated_repair_time) AS avg_repair_time,
 		        COUNT(*)                   AS count`).
@@ -376,15 +396,16 @@ func (r *binaMargaRepositoryImpl) FindByUserID(ctx context.Context, userID strin
 		reports []*entity.BinaMargaReport
 		total   int64
 	)
-	query := r.db.WithContext(ctx).
-		Model(&entity.BinaMargaReport{})
+	query := conn(ctx, r.db).
+		Model(&entity.BinaMargaReport{}).
+		Where("created_by = ?", userID)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	err := query.
-		Preload("Photos").
+		Preload("Photos", orderPhotos).
 		Limit(limit).
 		Offset(offset).
 		Order("created_at DESC").
@@ -393,53 +414,37 @@ func (r *binaMargaRepositoryImpl) FindByUserID(ctx context.Context, userID strin
 	return reports, total, err
 }
 
+// FindByPriority returns the open work queue ranked by the stored priority score,
+// most urgent first.
 func (r *binaMargaRepositoryImpl) FindByPriority(ctx context.Context, limit, offset int) ([]*entity.BinaMargaReport, int64, error) {
-	var (
-		reports []*entity.BinaMargaReport
-		total   int64
-	)
+	var reports []*entity.BinaMargaReport
+	var total int64
 
-	const prioritySQL = `
-		SELECT *,
-			( CASE WHEN urgency_level = 'DARURAT' THEN 100
-			       WHEN urgency_level = 'TINGGI'  THEN 75
-			       WHEN urgency_level = 'SEDANG'  THEN 50
-			       ELSE 25 END
-			  + CASE WHEN damage_level = 'BERAT' THEN 50
-			         WHEN damage_level = 'SEDANG' THEN 30
-			         ELSE 15 END
-			  + CASE WHEN road_class = 'ARTERI'   THEN 40
-			         WHEN road_class = 'KOLEKTOR' THEN 30
-			         WHEN road_class = 'LOKAL'    THEN 20
-			         ELSE 10 END
-			  + CASE WHEN traffic_impact = 'TERPUTUS'         THEN 60
-			         WHEN traffic_impact = 'SANGAT_TERGANGGU'  THEN 40
-			         WHEN traffic_impact = 'TERGANGGU'         THEN 20
-			         ELSE 5 END
-			  + CASE WHEN damaged_area > 100 THEN 25
-			         WHEN damaged_area > 50  THEN 15
-			         ELSE 0 END ) AS priority_score
-		FROM bina_marga_reports
-		WHERE status NOT IN ('COMPLETED', 'REJECTED')
-		ORDER BY priority_score DESC, created_at DESC
-		LIMIT ? OFFSET ?`
-
-	err := r.db.WithContext(ctx).Raw(prioritySQL, limit, offset).Scan(&reports).Error
-	if err != nil {
-		return nil, 0, err
+	query := conn(ctx, r.db).
+		Model(&entity.BinaMargaReport{}).
+		Where("status NOT IN ?", []entity.BinaMargaStatus{entity.BinaMargaStatusCompleted, entity.BinaMargaStatusRejected})
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count priority reports: %w", err)
 	}
 
-	_ = r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
-		Where("status NOT IN ('COMPLETED', 'REJECTED')").
-		Count(&total).Error
+	err := query.
+		Preload("Photos", orderPhotos).
+		Order("priority_score DESC, created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&reports).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get priority reports: %w", err)
+	}
 
 	return reports, total, nil
 }
 
 func (r *binaMargaRepositoryImpl) FindEmergencyReports(ctx context.Context, limit int) ([]*entity.BinaMargaReport, error) {
 	var reports []*entity.BinaMargaReport
-	err := r.db.WithContext(ctx).
-		Preload("Photos").
+	err := conn(ctx, r.db).
+		Preload("Photos", orderPhotos).
 		Where("urgency_level = ?", entity.RoadUrgencyEmergency).
 		Where("status NOT IN ('COMPLETED', 'REJECTED')").
 		Order("created_at DESC").
@@ -449,7 +454,7 @@ func (r *binaMargaRepositoryImpl) FindEmergencyReports(ctx context.Context, limi
 }
 
 func (r *binaMargaRepositoryImpl) baseScoped(ctx context.Context, roadType string, startDate, endDate time.Time) *gorm.DB {
-	q := r.db.WithContext(ctx).Model(&entity.BinaMargaReport{}).
+	q := conn(ctx, r.db).Model(&entity.BinaMargaReport{}).
 		Where("report_datetime BETWEEN ? AND ?", startDate, endDate)
 	if roadType != "" && roadType != "ALL" {
 		q = q.Where("road_type = ?", roadType)
@@ -615,9 +620,9 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaOverviewStats(ctx context.Context,
 	}
 
 	// 1. Average segment length (m)
-	query := fmt.Sprintf(`SELECT COALESCE(AVG(segment_length), 0) FROM bina_marga_reports %s`, baseWhere)
+	query := fmt.Sprintf(`SELECT COALESCE(AVG(segment_length), 0) FROM ` + activeBinaMargaReportsTable(ctx) + ` %s`, baseWhere)
 	var avgSegmentLength float64
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&avgSegmentLength).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&avgSegmentLength).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate avg segment length: %w", err)
 	}
@@ -626,28 +631,28 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaOverviewStats(ctx context.Context,
 	// 2. Average damage area (m2) - use total_damaged_area or fallback to damaged_area
 	query = fmt.Sprintf(`
         SELECT COALESCE(AVG(COALESCE(total_damaged_area, damaged_area)), 0) 
-        FROM bina_marga_reports %s
+        FROM ` + activeBinaMargaReportsTable(ctx) + ` %s
     `, baseWhere)
 	var avgDamageArea float64
-	err = r.db.WithContext(ctx).Raw(query, args...).Scan(&avgDamageArea).Error
+	err = conn(ctx, r.db).Raw(query, args...).Scan(&avgDamageArea).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate avg damage area: %w", err)
 	}
 	stats["avg_damage_area_m2"] = avgDamageArea
 
 	// 3. Average daily traffic volume
-	query = fmt.Sprintf(`SELECT COALESCE(AVG(daily_traffic_volume), 0) FROM bina_marga_reports %s`, baseWhere)
+	query = fmt.Sprintf(`SELECT COALESCE(AVG(daily_traffic_volume), 0) FROM ` + activeBinaMargaReportsTable(ctx) + ` %s`, baseWhere)
 	var avgTrafficVolume float64
-	err = r.db.WithContext(ctx).Raw(query, args...).Scan(&avgTrafficVolume).Error
+	err = conn(ctx, r.db).Raw(query, args...).Scan(&avgTrafficVolume).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate avg traffic volume: %w", err)
 	}
 	stats["avg_daily_traffic_volume"] = avgTrafficVolume
 
 	// 4. Total infrastructure reports count
-	query = fmt.Sprintf(`SELECT COUNT(*) FROM bina_marga_reports %s`, baseWhere)
+	query = fmt.Sprintf(`SELECT COUNT(*) FROM ` + activeBinaMargaReportsTable(ctx) + ` %s`, baseWhere)
 	var totalReports int64
-	err = r.db.WithContext(ctx).Raw(query, args...).Scan(&totalReports).Error
+	err = conn(ctx, r.db).Raw(query, args...).Scan(&totalReports).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count total reports: %w", err)
 	}
@@ -669,7 +674,7 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaLocationStats(ctx context.Context,
             urgency_level,
             traffic_impact,
             COALESCE(total_damaged_area, damaged_area) as damaged_area
-        FROM bina_marga_reports
+        FROM ` + activeBinaMargaReportsTable(ctx) + `
         WHERE latitude IS NOT NULL AND longitude IS NOT NULL
     `
 
@@ -681,7 +686,7 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaLocationStats(ctx context.Context,
 
 	query += " ORDER BY damaged_area DESC"
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get location statistics: %w", err)
 	}
@@ -700,7 +705,7 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaPriorityStats(ctx context.Context,
                 ELSE urgency_level 
             END as priority_level,
             COUNT(*) as count
-        FROM bina_marga_reports
+        FROM ` + activeBinaMargaReportsTable(ctx) + `
     `
 
 	args := []interface{}{}
@@ -719,7 +724,7 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaPriorityStats(ctx context.Context,
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get priority statistics: %w", err)
 	}
@@ -738,7 +743,7 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaRoadDamageLevelStats(ctx context.C
                 ELSE damage_level 
             END as damage_level,
             COUNT(*) as count
-        FROM bina_marga_reports
+        FROM ` + activeBinaMargaReportsTable(ctx) + `
         WHERE (bridge_name IS NULL OR bridge_name = '')
     `
 
@@ -758,7 +763,7 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaRoadDamageLevelStats(ctx context.C
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get road damage level statistics: %w", err)
 	}
@@ -777,7 +782,7 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaBridgeDamageLevelStats(ctx context
                 ELSE bridge_damage_level 
             END as damage_level,
             COUNT(*) as count
-        FROM bina_marga_reports
+        FROM ` + activeBinaMargaReportsTable(ctx) + `
         WHERE bridge_name IS NOT NULL AND bridge_name != ''
     `
 
@@ -797,7 +802,7 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaBridgeDamageLevelStats(ctx context
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get bridge damage level statistics: %w", err)
 	}
@@ -816,7 +821,7 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaTopRoadDamageTypes(ctx context.Con
                 ELSE damage_type 
             END as damage_type,
             COUNT(*) as count
-        FROM bina_marga_reports
+        FROM ` + activeBinaMargaReportsTable(ctx) + `
         WHERE (bridge_name IS NULL OR bridge_name = '')
     `
 
@@ -837,7 +842,7 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaTopRoadDamageTypes(ctx context.Con
         LIMIT 10
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get top road damage types: %w", err)
 	}
@@ -856,7 +861,7 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaTopBridgeDamageTypes(ctx context.C
                 ELSE bridge_damage_type 
             END as damage_type,
             COUNT(*) as count
-        FROM bina_marga_reports
+        FROM ` + activeBinaMargaReportsTable(ctx) + `
         WHERE bridge_name IS NOT NULL AND bridge_name != ''
     `
 
@@ -877,10 +882,42 @@ func (r *binaMargaRepositoryImpl) GetBinaMargaTopBridgeDamageTypes(ctx context.C
         LIMIT 10
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get top bridge damage types: %w", err)
 	}
 
 	return results, nil
 }
+
+func (r *binaMargaRepositoryImpl) FindDeleted(ctx context.Context, limit, offset int) ([]*entity.BinaMargaReport, int64, error) {
+	return findDeleted[entity.BinaMargaReport](ctx, r.db, limit, offset)
+}
+
+func (r *binaMargaRepositoryImpl) FindDeletedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entity.BinaMargaReport, error) {
+	return findDeletedBefore[entity.BinaMargaReport](ctx, r.db, cutoff, limit)
+}
+
+func (r *binaMargaRepositoryImpl) Restore(ctx context.Context, id string) error {
+	return restoreDeleted[entity.BinaMargaReport](ctx, r.db, id)
+}
+
+func (r *binaMargaRepositoryImpl) HardDelete(ctx context.Context, id string) error {
+	return hardDelete[entity.BinaMargaReport](ctx, r.db, id)
+}
+
+func (r *binaMargaRepositoryImpl) AddPhotos(ctx context.Context, reportID string, photos []entity.BinaMargaPhoto, max int) error {
+	return addPhotos[entity.BinaMargaReport](ctx, r.db, reportID, photos, max)
+}
+
+func (r *binaMargaRepositoryImpl) UpdatePhoto(ctx context.Context, photo *entity.BinaMargaPhoto) error {
+	return updatePhoto(ctx, r.db, photo)
+}
+
+func (r *binaMargaRepositoryImpl) DeletePhoto(ctx context.Context, reportID, photoID string) error {
+	return deletePhoto[entity.BinaMargaPhoto](ctx, r.db, reportID, photoID)
+}
+
+func (r *binaMargaRepositoryImpl) ReorderPhotos(ctx context.Context, reportID string, photoIDs []string) error {
+	return reorderPhotos[entity.BinaMargaPhoto](ctx, r.db, reportID, photoIDs)
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"building-report-backend/internal/domain/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// scopedTables are the sector report tables that are partitioned by district and unit.
+var scopedTables = map[string]bool{
+	"reports":                  true,
+	"spatial_planning_reports": true,
+	"water_resources_reports":  true,
+	"bina_marga_reports":       true,
+	"agriculture_reports":      true,
+}
+
+const dataScopeApplied = "data_scope:applied"
+
+// RegisterDataScope installs callbacks that restrict every query, update and
+// delete on a sector report table to the DataScope found in the statement's
+// context, and stamp new and saved reports with that scope. Statements without
+// a scope in their context, like those of background jobs, are left untouched.
+func RegisterDataScope(db *gorm.DB) error {
+	callbacks := []struct {
+		name     string
+		register func() error
+	}{
+		{"query", func() error {
+			return db.Callback().Query().Before("gorm:query").Register("data_scope:query", filterByDataScope)
+		}},
+		{"row", func() error {
+			return db.Callback().Row().Before("gorm:row").Register("data_scope:row", filterByDataScope)
+		}},
+		{"update", func() error {
+			return db.Callback().Update().Before("gorm:update").Register("data_scope:update", scopeUpdate)
+		}},
+		{"delete", func() error {
+			return db.Callback().Delete().Before("gorm:delete").Register("data_scope:delete", filterByDataScope)
+		}},
+		{"create", func() error {
+			return db.Callback().Create().Before("gorm:create").Register("data_scope:create", stampDataScope)
+		}},
+	}
+
+	for _, cb := range callbacks {
+		if err := cb.register(); err != nil {
+			return fmt.Errorf("failed to register data scope %s callback: %w", cb.name, err)
+		}
+	}
+	return nil
+}
+
+func statementScope(db *gorm.DB) (entity.DataScope, bool) {
+	if db.Error != nil || db.Statement.Schema == nil || !scopedTables[db.Statement.Table] {
+		return entity.DataScope{}, false
+	}
+	scope := entity.DataScopeFromContext(db.Statement.Context)
+	return scope, !scope.IsGlobal()
+}
+
+func filterByDataScope(db *gorm.DB) {
+	scope, ok := statementScope(db)
+	if !ok {
+		return
+	}
+	// Count followed by Find reuses one statement; add the conditions once.
+	if _, applied := db.Statement.Settings.LoadOrStore(dataScopeApplied, true); applied {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: scopeConditions(scope)})
+}
+
+func scopeConditions(scope entity.DataScope) []clause.Expression {
+	if scope.None {
+		return []clause.Expression{clause.Expr{SQL: "1 = 0"}}
+	}
+	var exprs []clause.Expression
+	if scope.District != "" {
+		exprs = append(exprs, clause.Expr{
+			SQL:  "LOWER(?) = LOWER(?)",
+			Vars: []interface{}{clause.Column{Table: clause.CurrentTable, Name: "district"}, scope.District},
+		})
+	}
+	if scope.Unit != "" {
+		exprs = append(exprs, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "unit"}, Value: scope.Unit})
+	}
+	return exprs
+}
+
+func stampDataScope(db *gorm.DB) {
+	scope, ok := statementScope(db)
+	if !ok {
+		return
+	}
+	if scope.None {
+		db.AddError(entity.ErrNoDataScope)
+		return
+	}
+	// Save falls back to an upsert when its update matched nothing, which must
+	// not overwrite a report outside the scope either.
+	if c, ok := db.Statement.Clauses["ON CONFLICT"]; ok {
+		if onConflict, ok := c.Expression.(clause.OnConflict); ok && !onConflict.DoNothing {
+			onConflict.Where.Exprs = append(onConflict.Where.Exprs, scopeConditions(scope)...)
+			c.Expression = onConflict
+			db.Statement.Clauses["ON CONFLICT"] = c
+		}
+	}
+	if scope.District != "" {
+		db.Statement.SetColumn("district", scope.District, true)
+	}
+	if scope.Unit != "" {
+		db.Statement.SetColumn("unit", scope.Unit, true)
+	}
+}
+
+// scopeUpdate keeps a scoped user's updates inside their scope and stops them
+// from moving a report out of it.
+func scopeUpdate(db *gorm.DB) {
+	filterByDataScope(db)
+	stampDataScope(db)
+}
+
+// activeTable returns the soft-delete filtered subquery used by the raw
+// statistics queries, narrowed to the caller's scope. Scope values are
+// validated on the user, and quoted here as well since they end up in SQL text.
+func activeTable(scope entity.DataScope, table string) string {
+	conditions := append([]string{"deleted_at IS NULL"}, scopeSQL(scope)...)
+	return fmt.Sprintf("(SELECT * FROM %s WHERE %s) AS %s", table, strings.Join(conditions, " AND "), table)
+}
+
+// scopedReportIDs returns a subquery selecting the IDs of the reports in table
+// that are in the scope, trashed ones included, for tables that refer to
+// reports without being scoped themselves.
+func scopedReportIDs(scope entity.DataScope, table string) string {
+	conditions := append([]string{"TRUE"}, scopeSQL(scope)...)
+	return fmt.Sprintf("SELECT id FROM %s WHERE %s", table, strings.Join(conditions, " AND "))
+}
+
+// scopeSQL is scopeConditions as SQL text.
+func scopeSQL(scope entity.DataScope) []string {
+	var conditions []string
+	if scope.None {
+		conditions = append(conditions, "FALSE")
+	}
+	if scope.District != "" {
+		conditions = append(conditions, "LOWER(district) = LOWER("+quoteLiteral(scope.District)+")")
+	}
+	if scope.Unit != "" {
+		conditions = append(conditions, "unit = "+quoteLiteral(scope.Unit))
+	}
+	return conditions
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
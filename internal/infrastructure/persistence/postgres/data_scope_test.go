@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"building-report-backend/internal/domain/entity"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// dryRunDB renders statements without a database connection.
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterDataScope(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestScopeConditions(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope entity.DataScope
+		// query adds the caller's own conditions.
+		query    func(db *gorm.DB) *gorm.DB
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{
+			name:    "global scope",
+			scope:   entity.DataScope{},
+			wantSQL: `SELECT * FROM "bina_marga_reports" WHERE "bina_marga_reports"."deleted_at" IS NULL`,
+		},
+		{
+			name:     "district",
+			scope:    entity.DataScope{District: "Bandung"},
+			wantSQL:  `SELECT * FROM "bina_marga_reports" WHERE LOWER("bina_marga_reports"."district") = LOWER($1) AND "bina_marga_reports"."deleted_at" IS NULL`,
+			wantVars: []interface{}{"Bandung"},
+		},
+		{
+			name:     "unit",
+			scope:    entity.DataScope{Unit: "UPTD-1"},
+			wantSQL:  `SELECT * FROM "bina_marga_reports" WHERE "bina_marga_reports"."unit" = $1 AND "bina_marga_reports"."deleted_at" IS NULL`,
+			wantVars: []interface{}{"UPTD-1"},
+		},
+		{
+			name:     "district and unit",
+			scope:    entity.DataScope{District: "Bandung", Unit: "UPTD-1"},
+			wantSQL:  `SELECT * FROM "bina_marga_reports" WHERE LOWER("bina_marga_reports"."district") = LOWER($1) AND "bina_marga_reports"."unit" = $2 AND "bina_marga_reports"."deleted_at" IS NULL`,
+			wantVars: []interface{}{"Bandung", "UPTD-1"},
+		},
+		{
+			name:  "caller condition with OR",
+			scope: entity.DataScope{District: "Bandung"},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where("status = ? OR status = ?", "PENDING", "VERIFIED")
+			},
+			wantSQL:  `SELECT * FROM "bina_marga_reports" WHERE (status = $1 OR status = $2) AND LOWER("bina_marga_reports"."district") = LOWER($3) AND "bina_marga_reports"."deleted_at" IS NULL`,
+			wantVars: []interface{}{"PENDING", "VERIFIED", "Bandung"},
+		},
+		{
+			name:    "no scope assigned",
+			scope:   entity.DataScope{None: true},
+			wantSQL: `SELECT * FROM "bina_marga_reports" WHERE 1 = 0 AND "bina_marga_reports"."deleted_at" IS NULL`,
+		},
+	}
+
+	db := dryRunDB(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := entity.WithDataScope(context.Background(), tt.scope)
+			query := db.WithContext(ctx)
+			if tt.query != nil {
+				query = tt.query(query)
+			}
+			var reports []entity.BinaMargaReport
+			stmt := query.Find(&reports).Statement
+
+			if got := stmt.SQL.String(); got != tt.wantSQL {
+				t.Errorf("SQL =\n  %s\nwant\n  %s", got, tt.wantSQL)
+			}
+			if len(stmt.Vars) != len(tt.wantVars) {
+				t.Fatalf("vars = %v, want %v", stmt.Vars, tt.wantVars)
+			}
+			for i := range tt.wantVars {
+				if stmt.Vars[i] != tt.wantVars[i] {
+					t.Errorf("var %d = %v, want %v", i, stmt.Vars[i], tt.wantVars[i])
+				}
+			}
+		})
+	}
+}
+
+func TestScopeConditionsSkipUnscopedTables(t *testing.T) {
+	ctx := entity.WithDataScope(context.Background(), entity.DataScope{District: "Bandung"})
+	var keys []entity.APIKey
+	stmt := dryRunDB(t).WithContext(ctx).Find(&keys).Statement
+
+	if sql := stmt.SQL.String(); strings.Contains(sql, "district") {
+		t.Errorf("unscoped table filtered by district: %s", sql)
+	}
+}
+
+func TestActiveTable(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope entity.DataScope
+		want  string
+	}{
+		{
+			name:  "global scope",
+			scope: entity.DataScope{},
+			want:  "(SELECT * FROM water_resources_reports WHERE deleted_at IS NULL) AS water_resources_reports",
+		},
+		{
+			name:  "district",
+			scope: entity.DataScope{District: "Bandung"},
+			want:  "(SELECT * FROM water_resources_reports WHERE deleted_at IS NULL AND LOWER(district) = LOWER('Bandung')) AS water_resources_reports",
+		},
+		{
+			name:  "district and unit",
+			scope: entity.DataScope{District: "Bandung", Unit: "UPTD-1"},
+			want:  "(SELECT * FROM water_resources_reports WHERE deleted_at IS NULL AND LOWER(district) = LOWER('Bandung') AND unit = 'UPTD-1') AS water_resources_reports",
+		},
+		{
+			name:  "no scope assigned",
+			scope: entity.DataScope{None: true},
+			want:  "(SELECT * FROM water_resources_reports WHERE deleted_at IS NULL AND FALSE) AS water_resources_reports",
+		},
+		{
+			name:  "quote in scope value",
+			scope: entity.DataScope{District: "O'Brien' OR '1'='1"},
+			want:  "(SELECT * FROM water_resources_reports WHERE deleted_at IS NULL AND LOWER(district) = LOWER('O''Brien'' OR ''1''=''1')) AS water_resources_reports",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := activeTable(tt.scope, "water_resources_reports"); got != tt.want {
+				t.Errorf("activeTable =\n  %s\nwant\n  %s", got, tt.want)
+			}
+		})
+	}
+}
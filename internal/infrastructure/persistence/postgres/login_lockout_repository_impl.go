@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type loginLockoutRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewLoginLockoutRepository(db *gorm.DB) repository.LoginLockoutRepository {
+	return &loginLockoutRepositoryImpl{db: db}
+}
+
+func (r *loginLockoutRepositoryImpl) Create(ctx context.Context, lockout *entity.LoginLockout) error {
+	return r.db.WithContext(ctx).Create(lockout).Error
+}
+
+func (r *loginLockoutRepositoryImpl) FindAll(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*entity.LoginLockout, int64, error) {
+	var lockouts []*entity.LoginLockout
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.LoginLockout{})
+
+	if v, ok := filters["user_id"].(string); ok && v != "" {
+		query = query.Where("user_id = ?", v)
+	}
+	if v, ok := filters["scope"].(string); ok && v != "" {
+		query = query.Where("scope = ?", v)
+	}
+	if v, ok := filters["ip_address"].(string); ok && v != "" {
+		query = query.Where("ip_address = ?", v)
+	}
+	if active, ok := filters["active"].(bool); ok && active {
+		query = query.Where("unlocked_at IS NULL AND locked_until > ?", time.Now())
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&lockouts).Error
+
+	return lockouts, total, err
+}
+
+// MarkUserUnlocked closes every lockout of the user that has not yet expired.
+func (r *loginLockoutRepositoryImpl) MarkUserUnlocked(ctx context.Context, userID, unlockedBy string, unlockedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.LoginLockout{}).
+		Where("user_id = ? AND unlocked_at IS NULL AND locked_until > ?", userID, unlockedAt).
+		Updates(map[string]interface{}{
+			"unlocked_at": unlockedAt,
+			"unlocked_by": unlockedBy,
+		}).Error
+}
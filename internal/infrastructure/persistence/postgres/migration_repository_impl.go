@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"building-report-backend/internal/domain/repository"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type migrationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMigrationRepository(db *gorm.DB) repository.MigrationRepository {
+	return &migrationRepositoryImpl{db: db}
+}
+
+type gooseVersion struct {
+	VersionID int64
+	IsApplied bool
+}
+
+// CurrentVersion reads goose_db_version the way goose does: walking from the
+// newest row, the first version seen applied is current, and a version whose
+// newest row is a rollback is skipped.
+func (r *migrationRepositoryImpl) CurrentVersion(ctx context.Context) (int64, error) {
+	var rows []gooseVersion
+	err := r.db.WithContext(ctx).
+		Table("goose_db_version").
+		Select("version_id, is_applied").
+		Order("id DESC").
+		Find(&rows).Error
+	if err != nil {
+		return 0, err
+	}
+
+	rolledBack := map[int64]bool{}
+	for _, row := range rows {
+		if rolledBack[row.VersionID] {
+			continue
+		}
+		if row.IsApplied {
+			return row.VersionID, nil
+		}
+		rolledBack[row.VersionID] = true
+	}
+	return 0, nil
+}
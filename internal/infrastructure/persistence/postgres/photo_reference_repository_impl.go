@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"building-report-backend/internal/domain/repository"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// photoTables are the tables whose rows refer to stored photos. The report
+// tables are left out on purpose: photo rows of reports in the trash still
+// exist and keep their objects until the purge.
+var photoTables = []string{
+	"report_photos",
+	"spatial_planning_photos",
+	"water_resources_photos",
+	"bina_marga_photos",
+	"agriculture_photos",
+	"upload_session_files",
+}
+
+type photoReferenceRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPhotoReferenceRepository(db *gorm.DB) repository.PhotoReferenceRepository {
+	return &photoReferenceRepositoryImpl{db: db}
+}
+
+func (r *photoReferenceRepositoryImpl) FindAll(ctx context.Context) ([]repository.PhotoReference, error) {
+	var refs []repository.PhotoReference
+	for _, table := range photoTables {
+		var rows []struct {
+			ID           string
+			PhotoURL     string
+			MediumURL    string
+			ThumbnailURL string
+		}
+		err := conn(ctx, r.db).
+			Table(table).
+			Select("id, photo_url, medium_url, thumbnail_url").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			ref := repository.PhotoReference{Table: table, ID: row.ID}
+			for _, key := range []string{row.PhotoURL, row.MediumURL, row.ThumbnailURL} {
+				if key != "" {
+					ref.Keys = append(ref.Keys, key)
+				}
+			}
+			if len(ref.Keys) > 0 {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs, nil
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"building-report-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// The sector photo tables share their layout, so photo management lives here
+// and each repository calls it with its own report and photo types, like the
+// trash queries in soft_delete.go.
+
+// orderPhotos is the order photos are preloaded in: as arranged on the
+// report, then as uploaded.
+func orderPhotos(db *gorm.DB) *gorm.DB {
+	return db.Order("position, created_at")
+}
+
+// addPhotos adds photos to a report as long as it ends up with at most max
+// photos. The report row is locked while counting, so two uploads cannot both
+// pass the check. The report must be visible in ctx's data scope.
+func addPhotos[R, P any](ctx context.Context, db *gorm.DB, reportID string, photos []P, max int) error {
+	return conn(ctx, db).Transaction(func(tx *gorm.DB) error {
+		var report R
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", reportID).
+			First(&report).Error
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(new(P)).Where("report_id = ?", reportID).Count(&count).Error; err != nil {
+			return err
+		}
+		if int(count)+len(photos) > max {
+			return fmt.Errorf("%w: the report has %d of %d photos", repository.ErrPhotoLimit, count, max)
+		}
+
+		return tx.Create(&photos).Error
+	})
+}
+
+// updatePhoto saves every column of an existing photo. Unlike Save it never
+// inserts, so a photo deleted in the meantime stays deleted.
+func updatePhoto[P any](ctx context.Context, db *gorm.DB, photo *P) error {
+	result := conn(ctx, db).
+		Model(photo).
+		Select("*").
+		Omit("id", "report_id", "created_at").
+		Updates(photo)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func deletePhoto[P any](ctx context.Context, db *gorm.DB, reportID, photoID string) error {
+	result := conn(ctx, db).
+		Where("id = ? AND report_id = ?", photoID, reportID).
+		Delete(new(P))
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// reorderPhotos numbers the photos of a report in the order of photoIDs.
+func reorderPhotos[P any](ctx context.Context, db *gorm.DB, reportID string, photoIDs []string) error {
+	return conn(ctx, db).Transaction(func(tx *gorm.DB) error {
+		for i, id := range photoIDs {
+			result := tx.Model(new(P)).
+				Where("id = ? AND report_id = ?", id, reportID).
+				Update("position", i)
+
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type reportLookupRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReportLookupRepository(db *gorm.DB) repository.ReportLookupRepository {
+	return &reportLookupRepositoryImpl{db: db}
+}
+
+func (r *reportLookupRepositoryImpl) Exists(ctx context.Context, sector entity.Sector, id string, includeDeleted bool) (bool, error) {
+	var model interface{}
+	switch sector {
+	case entity.SectorReports:
+		model = &entity.Report{}
+	case entity.SectorSpatialPlanning:
+		model = &entity.SpatialPlanningReport{}
+	case entity.SectorWaterResources:
+		model = &entity.WaterResourcesReport{}
+	case entity.SectorBinaMarga:
+		model = &entity.BinaMargaReport{}
+	case entity.SectorAgriculture:
+		model = &entity.AgricultureReport{}
+	default:
+		return false, fmt.Errorf("unknown report sector %q", sector)
+	}
+
+	// The data scope callbacks filter the count like any other query.
+	query := conn(ctx, r.db).Model(model).Where("id = ?", id)
+	if includeDeleted {
+		query = query.Unscoped()
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type reportMetricsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReportMetricsRepository(db *gorm.DB) repository.ReportMetricsRepository {
+	return &reportMetricsRepositoryImpl{db: db}
+}
+
+func (r *reportMetricsRepositoryImpl) CountReports(ctx context.Context) (map[entity.Sector]int64, error) {
+	models := map[entity.Sector]interface{}{
+		entity.SectorReports:         &entity.Report{},
+		entity.SectorSpatialPlanning: &entity.SpatialPlanningReport{},
+		entity.SectorWaterResources:  &entity.WaterResourcesReport{},
+		entity.SectorBinaMarga:       &entity.BinaMargaReport{},
+		entity.SectorAgriculture:     &entity.AgricultureReport{},
+	}
+
+	counts := make(map[entity.Sector]int64, len(models))
+	for sector, model := range models {
+		var count int64
+		if err := r.db.WithContext(ctx).Model(model).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		counts[sector] = count
+	}
+	return counts, nil
+}
+
+func (r *reportMetricsRepositoryImpl) CountOpenUrgentReports(ctx context.Context) (map[entity.Sector]int64, error) {
+	queries := []struct {
+		sector entity.Sector
+		query  *gorm.DB
+	}{
+		{entity.SectorSpatialPlanning, r.db.Model(&entity.SpatialPlanningReport{}).
+			Where("urgency_level = ?", entity.UrgencyMendesak).
+			Where("status NOT IN ?", []entity.SpatialReportStatus{entity.SpatialStatusResolved, entity.SpatialStatusRejected})},
+		{entity.SectorWaterResources, r.db.Model(&entity.WaterResourcesReport{}).
+			Where("urgency_category = ?", entity.UrgencyCategoryMendesak).
+			Where("status NOT IN ?", []entity.WaterResourceStatus{entity.WaterResourceStatusCompleted, entity.WaterResourceStatusRejected})},
+		{entity.SectorBinaMarga, r.db.Model(&entity.BinaMargaReport{}).
+			Where("urgency_level = ?", entity.RoadUrgencyEmergency).
+			Where("status NOT IN ?", []entity.BinaMargaStatus{entity.BinaMargaStatusCompleted, entity.BinaMargaStatusRejected})},
+	}
+
+	counts := make(map[entity.Sector]int64, len(queries))
+	for _, q := range queries {
+		var count int64
+		if err := q.query.WithContext(ctx).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		counts[q.sector] = count
+	}
+	return counts, nil
+}
@@ -4,11 +4,19 @@ import (
 	"building-report-backend/internal/domain/entity"
 	"building-report-backend/internal/domain/repository"
 	"context"
+	"time"
 	"fmt"
 
 	"gorm.io/gorm"
 )
 
+// activeReportsTable is used in place of the bare table name in raw SQL so that
+// soft-deleted rows never reach statistics or listings, and scoped users only
+// count the reports of their own district and unit.
+func activeReportsTable(ctx context.Context) string {
+	return activeTable(entity.DataScopeFromContext(ctx), "reports")
+}
+
 type reportRepositoryImpl struct {
 	db *gorm.DB
 }
@@ -18,22 +26,23 @@ func NewReportRepository(db *gorm.DB) repository.ReportRepository {
 }
 
 func (r *reportRepositoryImpl) Create(ctx context.Context, report *entity.Report) error {
-	return r.db.WithContext(ctx).Create(report).Error
+	return conn(ctx, r.db).Create(report).Error
 }
 
 func (r *reportRepositoryImpl) Update(ctx context.Context, report *entity.Report) error {
-	return r.db.WithContext(ctx).Save(report).Error
+	return conn(ctx, r.db).Save(report).Error
 }
 
 func (r *reportRepositoryImpl) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM reports WHERE id = $1`
-	return r.db.WithContext(ctx).Exec(query, id).Error
+	return conn(ctx, r.db).
+		Where("id = ?", id).
+		Delete(&entity.Report{}).Error
 }
 
 func (r *reportRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.Report, error) {
 	var report entity.Report
-	err := r.db.WithContext(ctx).
-		Preload("Photos").
+	err := conn(ctx, r.db).
+		Preload("Photos", orderPhotos).
 		Where("id = ?", id).
 		First(&report).Error
 
@@ -47,7 +56,7 @@ func (r *reportRepositoryImpl) FindAll(ctx context.Context, limit, offset int, f
 	var reports []*entity.Report
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.Report{})
+	query := conn(ctx, r.db).Model(&entity.Report{})
 
 	if village, ok := filters["village"].(string); ok && village != "" {
 		query = query.Where("village ILIKE ?", "%"+village+"%")
@@ -65,7 +74,7 @@ func (r *reportRepositoryImpl) FindAll(ctx context.Context, limit, offset int, f
 	query.Count(&total)
 
 	err := query.
-		Preload("Photos").
+		Preload("Photos", orderPhotos).
 		Limit(limit).
 		Offset(offset).
 		Order("created_at DESC").
@@ -78,13 +87,14 @@ func (r *reportRepositoryImpl) FindByUserID(ctx context.Context, userID string,
 	var reports []*entity.Report
 	var total int64
 
-	query := r.db.WithContext(ctx).
-		Model(&entity.Report{})
+	query := conn(ctx, r.db).
+		Model(&entity.Report{}).
+		Where("created_by = ?", userID)
 
 	query.Count(&total)
 
 	err := query.
-		Preload("Photos").
+		Preload("Photos", orderPhotos).
 		Limit(limit).
 		Offset(offset).
 		Order("created_at DESC").
@@ -106,9 +116,9 @@ func (r *reportRepositoryImpl) GetStatistics(ctx context.Context, buildingType s
 		argIndex++
 	}
 
-	query := fmt.Sprintf(`SELECT COUNT(*) FROM reports %s`, baseWhere)
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM ` + activeReportsTable(ctx) + ` %s`, baseWhere)
 	var totalReports int64
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&totalReports).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&totalReports).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count total reports: %w", err)
 	}
@@ -121,17 +131,17 @@ func (r *reportRepositoryImpl) GetStatistics(ctx context.Context, buildingType s
 		return stats, nil
 	}
 
-	query = fmt.Sprintf(`SELECT COALESCE(AVG(floor_area), 0) FROM reports %s`, baseWhere)
+	query = fmt.Sprintf(`SELECT COALESCE(AVG(floor_area), 0) FROM ` + activeReportsTable(ctx) + ` %s`, baseWhere)
 	var avgFloorArea float64
-	err = r.db.WithContext(ctx).Raw(query, args...).Scan(&avgFloorArea).Error
+	err = conn(ctx, r.db).Raw(query, args...).Scan(&avgFloorArea).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate average floor area: %w", err)
 	}
 	stats["average_floor_area"] = avgFloorArea
 
-	query = fmt.Sprintf(`SELECT COALESCE(AVG(floor_count), 0) FROM reports %s`, baseWhere)
+	query = fmt.Sprintf(`SELECT COALESCE(AVG(floor_count), 0) FROM ` + activeReportsTable(ctx) + ` %s`, baseWhere)
 	var avgFloorCount float64
-	err = r.db.WithContext(ctx).Raw(query, args...).Scan(&avgFloorCount).Error
+	err = conn(ctx, r.db).Raw(query, args...).Scan(&avgFloorCount).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate average floor count: %w", err)
 	}
@@ -140,15 +150,15 @@ func (r *reportRepositoryImpl) GetStatistics(ctx context.Context, buildingType s
 	var damagedQuery string
 	var damagedArgs []interface{}
 	if buildingType != "" && buildingType != "all" {
-		damagedQuery = `SELECT COUNT(*) FROM reports WHERE report_status = $1 AND building_type = $2`
+		damagedQuery = `SELECT COUNT(*) FROM ` + activeReportsTable(ctx) + ` WHERE report_status = $1 AND building_type = $2`
 		damagedArgs = []interface{}{"REHABILITASI", buildingType}
 	} else {
-		damagedQuery = `SELECT COUNT(*) FROM reports WHERE report_status = $1`
+		damagedQuery = `SELECT COUNT(*) FROM ` + activeReportsTable(ctx) + ` WHERE report_status = $1`
 		damagedArgs = []interface{}{"REHABILITASI"}
 	}
 
 	var damagedCount int64
-	err = r.db.WithContext(ctx).Raw(damagedQuery, damagedArgs...).Scan(&damagedCount).Error
+	err = conn(ctx, r.db).Raw(damagedQuery, damagedArgs...).Scan(&damagedCount).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count damaged buildings: %w", err)
 	}
@@ -168,7 +178,7 @@ func (r *reportRepositoryImpl) GetLocationStatistics(ctx context.Context, buildi
             COALESCE(AVG(latitude), 0) as avg_latitude,
             COALESCE(AVG(longitude), 0) as avg_longitude,
             COUNT(CASE WHEN report_status = 'REHABILITASI' THEN 1 END) as damaged_count
-        FROM reports
+        FROM ` + activeReportsTable(ctx) + `
     `
 
 	args := []interface{}{}
@@ -182,7 +192,7 @@ func (r *reportRepositoryImpl) GetLocationStatistics(ctx context.Context, buildi
         ORDER BY building_count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get location statistics: %w", err)
 	}
@@ -201,7 +211,7 @@ func (r *reportRepositoryImpl) GetWorkTypeStatistics(ctx context.Context, buildi
                 ELSE work_type 
             END as work_type,
             COUNT(*) as count
-        FROM reports
+        FROM ` + activeReportsTable(ctx) + `
     `
 
 	args := []interface{}{}
@@ -220,7 +230,7 @@ func (r *reportRepositoryImpl) GetWorkTypeStatistics(ctx context.Context, buildi
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get work type statistics: %w", err)
 	}
@@ -239,7 +249,7 @@ func (r *reportRepositoryImpl) GetConditionAfterRehabStatistics(ctx context.Cont
                 ELSE condition_after_rehab 
             END as condition_after_rehab,
             COUNT(*) as count
-        FROM reports
+        FROM ` + activeReportsTable(ctx) + `
     `
 
 	args := []interface{}{}
@@ -258,7 +268,7 @@ func (r *reportRepositoryImpl) GetConditionAfterRehabStatistics(ctx context.Cont
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get condition statistics: %w", err)
 	}
@@ -277,7 +287,7 @@ func (r *reportRepositoryImpl) GetStatusStatistics(ctx context.Context, building
                 ELSE report_status 
             END as report_status,
             COUNT(*) as count
-        FROM reports
+        FROM ` + activeReportsTable(ctx) + `
     `
 
 	args := []interface{}{}
@@ -296,7 +306,7 @@ func (r *reportRepositoryImpl) GetStatusStatistics(ctx context.Context, building
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get status statistics: %w", err)
 	}
@@ -315,7 +325,7 @@ func (r *reportRepositoryImpl) CountByBuildingType(ctx context.Context) ([]map[s
                 ELSE building_type 
             END as building_type,
             COUNT(*) as count
-        FROM reports
+        FROM ` + activeReportsTable(ctx) + `
         GROUP BY 
             CASE 
                 WHEN building_type IS NULL THEN 'NOT_SET'
@@ -325,10 +335,42 @@ func (r *reportRepositoryImpl) CountByBuildingType(ctx context.Context) ([]map[s
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count by building type: %w", err)
 	}
 
 	return results, nil
 }
+
+func (r *reportRepositoryImpl) FindDeleted(ctx context.Context, limit, offset int) ([]*entity.Report, int64, error) {
+	return findDeleted[entity.Report](ctx, r.db, limit, offset)
+}
+
+func (r *reportRepositoryImpl) FindDeletedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entity.Report, error) {
+	return findDeletedBefore[entity.Report](ctx, r.db, cutoff, limit)
+}
+
+func (r *reportRepositoryImpl) Restore(ctx context.Context, id string) error {
+	return restoreDeleted[entity.Report](ctx, r.db, id)
+}
+
+func (r *reportRepositoryImpl) HardDelete(ctx context.Context, id string) error {
+	return hardDelete[entity.Report](ctx, r.db, id)
+}
+
+func (r *reportRepositoryImpl) AddPhotos(ctx context.Context, reportID string, photos []entity.ReportPhoto, max int) error {
+	return addPhotos[entity.Report](ctx, r.db, reportID, photos, max)
+}
+
+func (r *reportRepositoryImpl) UpdatePhoto(ctx context.Context, photo *entity.ReportPhoto) error {
+	return updatePhoto(ctx, r.db, photo)
+}
+
+func (r *reportRepositoryImpl) DeletePhoto(ctx context.Context, reportID, photoID string) error {
+	return deletePhoto[entity.ReportPhoto](ctx, r.db, reportID, photoID)
+}
+
+func (r *reportRepositoryImpl) ReorderPhotos(ctx context.Context, reportID string, photoIDs []string) error {
+	return reorderPhotos[entity.ReportPhoto](ctx, r.db, reportID, photoIDs)
+}
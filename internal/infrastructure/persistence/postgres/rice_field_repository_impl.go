@@ -14,6 +14,11 @@ type riceFieldRepositoryImpl struct {
 	db *gorm.DB
 }
 
+type riceFieldAreaTotals struct {
+	TotalRainfedArea   float64 `gorm:"column:total_rainfed_area"`
+	TotalIrrigatedArea float64 `gorm:"column:total_irrigated_area"`
+}
+
 func NewRiceFieldRepository(db *gorm.DB) repository.RiceFieldRepository {
 	return &riceFieldRepositoryImpl{db: db}
 }
@@ -113,21 +118,19 @@ func (r *riceFieldRepositoryImpl) FindByDateRange(ctx context.Context, startDate
 func (r *riceFieldRepositoryImpl) GetRiceFieldStatistics(ctx context.Context, startDate, endDate time.Time) (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
 
-	var totalRainfedArea, totalIrrigatedArea float64
 	var totalRecords int64
 
 	// Calculate total areas
+	var current riceFieldAreaTotals
 	err := r.db.WithContext(ctx).Model(&entity.RiceField{}).
 		Where("date BETWEEN ? AND ?", startDate, endDate).
 		Select("COALESCE(SUM(rainfed_rice_fields), 0) as total_rainfed_area, COALESCE(SUM(irrigated_rice_fields), 0) as total_irrigated_area").
-		Scan(&struct {
-			TotalRainfedArea   float64 `gorm:"column:total_rainfed_area"`
-			TotalIrrigatedArea float64 `gorm:"column:total_irrigated_area"`
-		}{totalRainfedArea, totalIrrigatedArea}).Error
+		Scan(&current).Error
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate total areas: %w", err)
 	}
+	totalRainfedArea, totalIrrigatedArea := current.TotalRainfedArea, current.TotalIrrigatedArea
 
 	// Count total records
 	r.db.WithContext(ctx).Model(&entity.RiceField{}).
@@ -143,18 +146,16 @@ func (r *riceFieldRepositoryImpl) GetRiceFieldStatistics(ctx context.Context, st
 	prevStartDate := startDate.AddDate(0, -1, 0)
 	prevEndDate := endDate.AddDate(0, -1, 0)
 
-	var prevTotalRainfedArea, prevTotalIrrigatedArea float64
+	var previous riceFieldAreaTotals
 	err = r.db.WithContext(ctx).Model(&entity.RiceField{}).
 		Where("date BETWEEN ? AND ?", prevStartDate, prevEndDate).
 		Select("COALESCE(SUM(rainfed_rice_fields), 0) as total_rainfed_area, COALESCE(SUM(irrigated_rice_fields), 0) as total_irrigated_area").
-		Scan(&struct {
-			TotalRainfedArea   float64 `gorm:"column:total_rainfed_area"`
-			TotalIrrigatedArea float64 `gorm:"column:total_irrigated_area"`
-		}{prevTotalRainfedArea, prevTotalIrrigatedArea}).Error
+		Scan(&previous).Error
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate previous period areas: %w", err)
 	}
+	prevTotalRainfedArea, prevTotalIrrigatedArea := previous.TotalRainfedArea, previous.TotalIrrigatedArea
 
 	prevTotalArea := prevTotalRainfedArea + prevTotalIrrigatedArea
 	currentTotalArea := totalRainfedArea + totalIrrigatedArea
@@ -194,7 +195,9 @@ func (r *riceFieldRepositoryImpl) GetRiceFieldDistributionByDistrict(ctx context
 			COALESCE(SUM(rainfed_rice_fields), 0) as total_rainfed_area,
 			COALESCE(SUM(irrigated_rice_fields), 0) as total_irrigated_area,
 			COALESCE(SUM(rainfed_rice_fields), 0) + COALESCE(SUM(irrigated_rice_fields), 0) as total_area,
-			COUNT(*) as count
+			COUNT(*) as count,
+			COUNT(*) FILTER (WHERE rainfed_rice_fields > 0) as rainfed_count,
+			COUNT(*) FILTER (WHERE irrigated_rice_fields > 0) as irrigated_count
 		FROM rice_fields
 		WHERE date BETWEEN $1 AND $2
 		GROUP BY district
@@ -244,7 +247,7 @@ func (r *riceFieldRepositoryImpl) GetRiceFieldTrends(ctx context.Context, distri
 
 		query := `
 			SELECT 
-				$1 as year,
+				$1::int as year,
 				COALESCE(SUM(rainfed_rice_fields), 0) as total_rainfed_area,
 				COALESCE(SUM(irrigated_rice_fields), 0) as total_irrigated_area,
 				COALESCE(SUM(rainfed_rice_fields), 0) + COALESCE(SUM(irrigated_rice_fields), 0) as total_area,
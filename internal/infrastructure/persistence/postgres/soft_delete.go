@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The report repositories share the same trash behaviour, so the queries live
+// here and each repository calls them with its own entity type.
+
+func findDeleted[T any](ctx context.Context, db *gorm.DB, limit, offset int) ([]*T, int64, error) {
+	var reports []*T
+	var total int64
+
+	query := conn(ctx, db).
+		Unscoped().
+		Model(new(T)).
+		Where("deleted_at IS NOT NULL")
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Photos", orderPhotos).
+		Limit(limit).
+		Offset(offset).
+		Order("deleted_at DESC").
+		Find(&reports).Error
+
+	return reports, total, err
+}
+
+func findDeletedBefore[T any](ctx context.Context, db *gorm.DB, cutoff time.Time, limit int) ([]*T, error) {
+	var reports []*T
+
+	err := conn(ctx, db).
+		Unscoped().
+		Preload("Photos", orderPhotos).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&reports).Error
+
+	return reports, err
+}
+
+func restoreDeleted[T any](ctx context.Context, db *gorm.DB, id string) error {
+	result := conn(ctx, db).
+		Unscoped().
+		Model(new(T)).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// hardDelete removes the row for good. Photo rows go with it through the
+// ON DELETE CASCADE foreign key; the stored files are the caller's job.
+func hardDelete[T any](ctx context.Context, db *gorm.DB, id string) error {
+	return conn(ctx, db).
+		Unscoped().
+		Where("id = ?", id).
+		Delete(new(T)).Error
+}
@@ -4,10 +4,19 @@ import (
 	"building-report-backend/internal/domain/entity"
 	"building-report-backend/internal/domain/repository"
 	"context"
+	"time"
+	"fmt"
 
 	"gorm.io/gorm"
 )
 
+// activeSpatialPlanningReportsTable is used in place of the bare table name in raw SQL so that
+// soft-deleted rows never reach statistics or listings, and scoped users only
+// count the reports of their own district and unit.
+func activeSpatialPlanningReportsTable(ctx context.Context) string {
+	return activeTable(entity.DataScopeFromContext(ctx), "spatial_planning_reports")
+}
+
 type spatialPlanningRepositoryImpl struct {
 	db *gorm.DB
 }
@@ -17,23 +26,25 @@ func NewSpatialPlanningRepository(db *gorm.DB) repository.SpatialPlanningReposit
 }
 
 func (r *spatialPlanningRepositoryImpl) Create(ctx context.Context, report *entity.SpatialPlanningReport) error {
-	return r.db.WithContext(ctx).Create(report).Error
+	report.PriorityScore = report.CalculatePriority()
+	return conn(ctx, r.db).Create(report).Error
 }
 
 func (r *spatialPlanningRepositoryImpl) Update(ctx context.Context, report *entity.SpatialPlanningReport) error {
-	return r.db.WithContext(ctx).Save(report).Error
+	report.PriorityScore = report.CalculatePriority()
+	return conn(ctx, r.db).Save(report).Error
 }
 
 func (r *spatialPlanningRepositoryImpl) Delete(ctx context.Context, id string) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Where("id = ?", id).
 		Delete(&entity.SpatialPlanningReport{}).Error
 }
 
 func (r *spatialPlanningRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.SpatialPlanningReport, error) {
 	var report entity.SpatialPlanningReport
-	err := r.db.WithContext(ctx).
-		Preload("Photos").
+	err := conn(ctx, r.db).
+		Preload("Photos", orderPhotos).
 		Where("id = ?", id).
 		First(&report).Error
 
@@ -47,7 +58,7 @@ func (r *spatialPlanningRepositoryImpl) FindAll(ctx context.Context, limit, offs
 	var reports []*entity.SpatialPlanningReport
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.SpatialPlanningReport{})
+	query := conn(ctx, r.db).Model(&entity.SpatialPlanningReport{})
 
 	if institution, ok := filters["institution"].(string); ok && institution != "" {
 		query = query.Where("institution = ?", institution)
@@ -78,7 +89,7 @@ func (r *spatialPlanningRepositoryImpl) FindAll(ctx context.Context, limit, offs
 	query.Count(&total)
 
 	err := query.
-		Preload("Photos").
+		Preload("Photos", orderPhotos).
 		Limit(limit).
 		Offset(offset).
 		Order("urgency_level DESC, created_at DESC").
@@ -91,13 +102,14 @@ func (r *spatialPlanningRepositoryImpl) FindByUserID(ctx context.Context, userID
 	var reports []*entity.SpatialPlanningReport
 	var total int64
 
-	query := r.db.WithContext(ctx).
-		Model(&entity.SpatialPlanningReport{})
+	query := conn(ctx, r.db).
+		Model(&entity.SpatialPlanningReport{}).
+		Where("created_by = ?", userID)
 
 	query.Count(&total)
 
 	err := query.
-		Preload("Photos").
+		Preload("Photos", orderPhotos).
 		Limit(limit).
 		Offset(offset).
 		Order("created_at DESC").
@@ -106,16 +118,60 @@ func (r *spatialPlanningRepositoryImpl) FindByUserID(ctx context.Context, userID
 	return reports, total, err
 }
 
-func (r *spatialPlanningRepositoryImpl) UpdateStatus(ctx context.Context, id string, status entity.SpatialReportStatus) error {
-	return r.db.WithContext(ctx).
+// FindByPriority returns the open work queue ranked by the stored priority score,
+// most urgent first.
+func (r *spatialPlanningRepositoryImpl) FindByPriority(ctx context.Context, limit, offset int) ([]*entity.SpatialPlanningReport, int64, error) {
+	var reports []*entity.SpatialPlanningReport
+	var total int64
+
+	query := conn(ctx, r.db).
 		Model(&entity.SpatialPlanningReport{}).
-		Where("id = ?", id).
-		Update("status", status).Error
+		Where("status NOT IN ?", []entity.SpatialReportStatus{entity.SpatialStatusResolved, entity.SpatialStatusRejected})
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count priority reports: %w", err)
+	}
+
+	err := query.
+		Preload("Photos", orderPhotos).
+		Order("priority_score DESC, created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&reports).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get priority reports: %w", err)
+	}
+
+	return reports, total, nil
+}
+
+// UpdateStatus moves the report from one status to another. It returns
+// repository.ErrStatusChanged when the report is no longer in status from, so
+// of two concurrent changes only the first is applied.
+func (r *spatialPlanningRepositoryImpl) UpdateStatus(ctx context.Context, id string, from, to entity.SpatialReportStatus, notes, updatedBy string) error {
+	updates := map[string]interface{}{
+		"status":     to,
+		"updated_by": updatedBy,
+	}
+	if notes != "" {
+		updates["notes"] = notes
+	}
+	result := conn(ctx, r.db).
+		Model(&entity.SpatialPlanningReport{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrStatusChanged
+	}
+	return nil
 }
 
 func (r *spatialPlanningRepositoryImpl) CountByStatus(ctx context.Context, status entity.SpatialReportStatus) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Model(&entity.SpatialPlanningReport{}).
 		Where("status = ?", status).
 		Count(&count).Error
@@ -126,11 +182,11 @@ func (r *spatialPlanningRepositoryImpl) GetStatistics(ctx context.Context) (map[
 	stats := make(map[string]interface{})
 
 	var total int64
-	r.db.Model(&entity.SpatialPlanningReport{}).Count(&total)
+	conn(ctx, r.db).Model(&entity.SpatialPlanningReport{}).Count(&total)
 	stats["total_reports"] = total
 
 	var urgentCount int64
-	r.db.Model(&entity.SpatialPlanningReport{}).
+	conn(ctx, r.db).Model(&entity.SpatialPlanningReport{}).
 		Where("urgency_level = ?", entity.UrgencyMendesak).
 		Count(&urgentCount)
 	stats["urgent_reports"] = urgentCount
@@ -140,7 +196,7 @@ func (r *spatialPlanningRepositoryImpl) GetStatistics(ctx context.Context) (map[
 		Count int64
 	}
 	var violationCounts []ViolationCount
-	r.db.Model(&entity.SpatialPlanningReport{}).
+	conn(ctx, r.db).Model(&entity.SpatialPlanningReport{}).
 		Select("violation_level as level, count(*) as count").
 		Group("violation_level").
 		Scan(&violationCounts)
@@ -151,7 +207,7 @@ func (r *spatialPlanningRepositoryImpl) GetStatistics(ctx context.Context) (map[
 		Count  int64
 	}
 	var statusCounts []StatusCount
-	r.db.Model(&entity.SpatialPlanningReport{}).
+	conn(ctx, r.db).Model(&entity.SpatialPlanningReport{}).
 		Select("status, count(*) as count").
 		Group("status").
 		Scan(&statusCounts)
@@ -163,7 +219,7 @@ func (r *spatialPlanningRepositoryImpl) GetStatistics(ctx context.Context) (map[
 func (r *spatialPlanningRepositoryImpl) GetTataRuangStatistics(ctx context.Context, areaCategory string) (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
 
-	query := r.db.WithContext(ctx).Model(&entity.SpatialPlanningReport{})
+	query := conn(ctx, r.db).Model(&entity.SpatialPlanningReport{})
 	if areaCategory != "" && areaCategory != "all" {
 		query = query.Where("area_category = ?", areaCategory)
 	}
@@ -227,7 +283,7 @@ func (r *spatialPlanningRepositoryImpl) GetLocationDistribution(ctx context.Cont
             AVG(longitude) as avg_longitude,
             COUNT(CASE WHEN urgency_level = 'MENDESAK' THEN 1 END) as urgent_count,
             COUNT(CASE WHEN violation_level = 'BERAT' THEN 1 END) as severe_count
-        FROM spatial_planning_reports
+        FROM ` + activeSpatialPlanningReportsTable(ctx) + `
         WHERE latitude IS NOT NULL AND longitude IS NOT NULL
     `
 
@@ -243,7 +299,7 @@ func (r *spatialPlanningRepositoryImpl) GetLocationDistribution(ctx context.Cont
         ORDER BY violation_count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -255,7 +311,7 @@ func (r *spatialPlanningRepositoryImpl) GetUrgencyLevelStatistics(ctx context.Co
             urgency_level,
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage
-        FROM spatial_planning_reports
+        FROM ` + activeSpatialPlanningReportsTable(ctx) + `
     `
 
 	args := []interface{}{}
@@ -269,7 +325,7 @@ func (r *spatialPlanningRepositoryImpl) GetUrgencyLevelStatistics(ctx context.Co
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -283,7 +339,7 @@ func (r *spatialPlanningRepositoryImpl) GetViolationTypeStatistics(ctx context.C
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage,
             COUNT(CASE WHEN violation_level = 'BERAT' THEN 1 END) as severe_count,
             COUNT(CASE WHEN urgency_level = 'MENDESAK' THEN 1 END) as urgent_count
-        FROM spatial_planning_reports
+        FROM ` + activeSpatialPlanningReportsTable(ctx) + `
     `
 
 	args := []interface{}{}
@@ -297,7 +353,7 @@ func (r *spatialPlanningRepositoryImpl) GetViolationTypeStatistics(ctx context.C
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -310,7 +366,7 @@ func (r *spatialPlanningRepositoryImpl) GetViolationLevelStatistics(ctx context.
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage,
             COUNT(CASE WHEN urgency_level = 'MENDESAK' THEN 1 END) as urgent_count
-        FROM spatial_planning_reports
+        FROM ` + activeSpatialPlanningReportsTable(ctx) + `
     `
 
 	args := []interface{}{}
@@ -330,7 +386,7 @@ func (r *spatialPlanningRepositoryImpl) GetViolationLevelStatistics(ctx context.
             END
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
 
@@ -344,12 +400,12 @@ func (r *spatialPlanningRepositoryImpl) GetAreaCategoryDistribution(ctx context.
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage,
             COUNT(CASE WHEN urgency_level = 'MENDESAK' THEN 1 END) as urgent_count,
             COUNT(CASE WHEN violation_level = 'BERAT' THEN 1 END) as severe_count
-        FROM spatial_planning_reports
+        FROM ` + activeSpatialPlanningReportsTable(ctx) + `
         GROUP BY area_category
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query).Scan(&results).Error
 	return results, err
 }
 
@@ -362,7 +418,7 @@ func (r *spatialPlanningRepositoryImpl) GetEnvironmentalImpactStatistics(ctx con
             COUNT(*) as count,
             ROUND(COUNT(*) * 100.0 / SUM(COUNT(*)) OVER(), 2) as percentage,
             COUNT(CASE WHEN violation_level = 'BERAT' THEN 1 END) as severe_count
-        FROM spatial_planning_reports
+        FROM ` + activeSpatialPlanningReportsTable(ctx) + `
     `
 
 	args := []interface{}{}
@@ -376,6 +432,38 @@ func (r *spatialPlanningRepositoryImpl) GetEnvironmentalImpactStatistics(ctx con
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	return results, err
 }
+
+func (r *spatialPlanningRepositoryImpl) FindDeleted(ctx context.Context, limit, offset int) ([]*entity.SpatialPlanningReport, int64, error) {
+	return findDeleted[entity.SpatialPlanningReport](ctx, r.db, limit, offset)
+}
+
+func (r *spatialPlanningRepositoryImpl) FindDeletedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entity.SpatialPlanningReport, error) {
+	return findDeletedBefore[entity.SpatialPlanningReport](ctx, r.db, cutoff, limit)
+}
+
+func (r *spatialPlanningRepositoryImpl) Restore(ctx context.Context, id string) error {
+	return restoreDeleted[entity.SpatialPlanningReport](ctx, r.db, id)
+}
+
+func (r *spatialPlanningRepositoryImpl) HardDelete(ctx context.Context, id string) error {
+	return hardDelete[entity.SpatialPlanningReport](ctx, r.db, id)
+}
+
+func (r *spatialPlanningRepositoryImpl) AddPhotos(ctx context.Context, reportID string, photos []entity.SpatialPlanningPhoto, max int) error {
+	return addPhotos[entity.SpatialPlanningReport](ctx, r.db, reportID, photos, max)
+}
+
+func (r *spatialPlanningRepositoryImpl) UpdatePhoto(ctx context.Context, photo *entity.SpatialPlanningPhoto) error {
+	return updatePhoto(ctx, r.db, photo)
+}
+
+func (r *spatialPlanningRepositoryImpl) DeletePhoto(ctx context.Context, reportID, photoID string) error {
+	return deletePhoto[entity.SpatialPlanningPhoto](ctx, r.db, reportID, photoID)
+}
+
+func (r *spatialPlanningRepositoryImpl) ReorderPhotos(ctx context.Context, reportID string, photoIDs []string) error {
+	return reorderPhotos[entity.SpatialPlanningPhoto](ctx, r.db, reportID, photoIDs)
+}
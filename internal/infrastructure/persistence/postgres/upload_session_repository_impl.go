@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type uploadSessionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUploadSessionRepository(db *gorm.DB) repository.UploadSessionRepository {
+	return &uploadSessionRepositoryImpl{db: db}
+}
+
+func (r *uploadSessionRepositoryImpl) Create(ctx context.Context, session *entity.UploadSession) error {
+	return conn(ctx, r.db).Create(session).Error
+}
+
+func (r *uploadSessionRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.UploadSession, error) {
+	var session entity.UploadSession
+	err := conn(ctx, r.db).
+		Preload("Files", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Where("id = ?", id).
+		First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *uploadSessionRepositoryImpl) Update(ctx context.Context, session *entity.UploadSession, from entity.UploadSessionStatus) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.UploadSession{}).
+			Where("id = ? AND status = ?", session.ID, from).
+			Updates(map[string]interface{}{
+				"status":     session.Status,
+				"report_id":  session.ReportID,
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		for i := range session.Files {
+			file := &session.Files[i]
+			err := tx.Model(file).
+				Select("photo_url", "thumbnail_url", "medium_url", "width", "height", "taken_at", "gps_latitude", "gps_longitude").
+				Updates(file).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *uploadSessionRepositoryImpl) FindExpired(ctx context.Context, now time.Time, limit int) ([]*entity.UploadSession, error) {
+	var sessions []*entity.UploadSession
+	err := conn(ctx, r.db).
+		Preload("Files").
+		Where("expires_at < ?", now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *uploadSessionRepositoryImpl) Delete(ctx context.Context, id string, status entity.UploadSessionStatus) error {
+	result := conn(ctx, r.db).Delete(&entity.UploadSession{}, "id = ? AND status = ?", id, status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -2,6 +2,9 @@ package postgres
 
 import (
     "context"
+    "strings"
+    "time"
+
     "building-report-backend/internal/domain/entity"
     "building-report-backend/internal/domain/repository"
     
@@ -66,24 +69,87 @@ func (r *userRepositoryImpl) FindByEmail(ctx context.Context, email string) (*en
     return &user, nil
 }
 
-func (r *userRepositoryImpl) FindAll(ctx context.Context, limit, offset int) ([]*entity.User, int64, error) {
+func (r *userRepositoryImpl) FindByExternalSubject(ctx context.Context, provider, subject string) (*entity.User, error) {
+    var user entity.User
+    err := r.db.WithContext(ctx).
+        Where("auth_provider = ? AND external_subject = ?", provider, subject).
+        First(&user).Error
+    if err != nil {
+        return nil, err
+    }
+    return &user, nil
+}
+
+// userSortColumns lists the columns users may be sorted by.
+var userSortColumns = map[string]string{
+    "username":   "username",
+    "email":      "email",
+    "role":       "role",
+    "is_active":  "is_active",
+    "district":   "district",
+    "created_at": "created_at",
+    "updated_at": "updated_at",
+}
+
+// likeEscaper makes a search term match literally inside a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *userRepositoryImpl) FindAll(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*entity.User, int64, error) {
     var users []*entity.User
     var total int64
 
     query := r.db.WithContext(ctx).Model(&entity.User{})
-    
-    // Count total
+
+    if v, ok := filters["q"].(string); ok && v != "" {
+        pattern := "%" + likeEscaper.Replace(strings.ToLower(v)) + "%"
+        query = query.Where(`LOWER(username) LIKE ? ESCAPE '\' OR LOWER(email) LIKE ? ESCAPE '\'`, pattern, pattern)
+    }
+    if v, ok := filters["role"].(string); ok && v != "" {
+        query = query.Where("role = ?", v)
+    }
+    if v, ok := filters["is_active"].(bool); ok {
+        query = query.Where("is_active = ?", v)
+    }
+    if v, ok := filters["district"].(string); ok && v != "" {
+        query = query.Where("LOWER(district) = LOWER(?)", v)
+    }
+    if v, ok := filters["unit"].(string); ok && v != "" {
+        query = query.Where("unit = ?", v)
+    }
+
     if err := query.Count(&total).Error; err != nil {
         return nil, 0, err
     }
 
-    // Get users with optional pagination
-    findQuery := r.db.WithContext(ctx).Model(&entity.User{})
-    
+    order := "created_at DESC"
+    if v, ok := filters["sort_by"].(string); ok {
+        if column, ok := userSortColumns[v]; ok {
+            direction := "ASC"
+            if sortOrder, _ := filters["sort_order"].(string); strings.EqualFold(sortOrder, "desc") {
+                direction = "DESC"
+            }
+            order = column + " " + direction + ", id"
+        }
+    }
+
     if limit > 0 {
-        findQuery = findQuery.Limit(limit).Offset(offset)
+        query = query.Limit(limit).Offset(offset)
     }
-    
-    err := findQuery.Order("created_at DESC").Find(&users).Error
+
+    err := query.Order(order).Find(&users).Error
     return users, total, err
-}
\ No newline at end of file
+}
+
+func (r *userRepositoryImpl) FindByIDs(ctx context.Context, ids []string) ([]*entity.User, error) {
+    var users []*entity.User
+    err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error
+    return users, err
+}
+
+func (r *userRepositoryImpl) SetActive(ctx context.Context, ids []string, active bool) (int64, error) {
+    result := r.db.WithContext(ctx).
+        Model(&entity.User{}).
+        Where("id IN ?", ids).
+        Updates(map[string]interface{}{"is_active": active, "updated_at": time.Now()})
+    return result.RowsAffected, result.Error
+}
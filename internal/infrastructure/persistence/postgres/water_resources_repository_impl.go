@@ -10,6 +10,13 @@ import (
 	"gorm.io/gorm"
 )
 
+// activeWaterResourcesReportsTable is used in place of the bare table name in raw SQL so that
+// soft-deleted rows never reach statistics or listings, and scoped users only
+// count the reports of their own district and unit.
+func activeWaterResourcesReportsTable(ctx context.Context) string {
+	return activeTable(entity.DataScopeFromContext(ctx), "water_resources_reports")
+}
+
 type waterResourcesRepositoryImpl struct {
 	db *gorm.DB
 }
@@ -19,23 +26,26 @@ func NewWaterResourcesRepository(db *gorm.DB) repository.WaterResourcesRepositor
 }
 
 func (r *waterResourcesRepositoryImpl) Create(ctx context.Context, report *entity.WaterResourcesReport) error {
-	return r.db.WithContext(ctx).Create(report).Error
+	report.PriorityScore = report.CalculatePriority()
+	return conn(ctx, r.db).Create(report).Error
 }
 
 func (r *waterResourcesRepositoryImpl) Update(ctx context.Context, report *entity.WaterResourcesReport) error {
+	report.PriorityScore = report.CalculatePriority()
 	report.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(report).Error
+	return conn(ctx, r.db).Save(report).Error
 }
 
 func (r *waterResourcesRepositoryImpl) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM water_resources_reports WHERE id = $1`
-	return r.db.WithContext(ctx).Exec(query, id).Error
+	return conn(ctx, r.db).
+		Where("id = ?", id).
+		Delete(&entity.WaterResourcesReport{}).Error
 }
 
 func (r *waterResourcesRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.WaterResourcesReport, error) {
 	var report entity.WaterResourcesReport
-	err := r.db.WithContext(ctx).
-		Preload("Photos").
+	err := conn(ctx, r.db).
+		Preload("Photos", orderPhotos).
 		Where("id = ?", id).
 		First(&report).Error
 
@@ -49,7 +59,7 @@ func (r *waterResourcesRepositoryImpl) FindAll(ctx context.Context, limit, offse
 	var reports []*entity.WaterResourcesReport
 	var total int64
 
-	baseQuery := "FROM water_resources_reports"
+	baseQuery := "FROM " + activeWaterResourcesReportsTable(ctx)
 	whereClause := ""
 	args := []interface{}{}
 	argIndex := 1
@@ -110,12 +120,12 @@ func (r *waterResourcesRepositoryImpl) FindAll(ctx context.Context, limit, offse
 	}
 
 	countQuery := "SELECT COUNT(*) " + baseQuery + whereClause
-	err := r.db.WithContext(ctx).Raw(countQuery, args...).Scan(&total).Error
+	err := conn(ctx, r.db).Raw(countQuery, args...).Scan(&total).Error
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count records: %w", err)
 	}
 
-	query := r.db.WithContext(ctx).Model(&entity.WaterResourcesReport{})
+	query := conn(ctx, r.db).Model(&entity.WaterResourcesReport{})
 	for i, condition := range conditions {
 		if i == 0 {
 			query = query.Where(condition, args[i])
@@ -125,7 +135,7 @@ func (r *waterResourcesRepositoryImpl) FindAll(ctx context.Context, limit, offse
 	}
 
 	err = query.
-		Preload("Photos").
+		Preload("Photos", orderPhotos).
 		Limit(limit).
 		Offset(offset).
 		Order("CASE WHEN urgency_category = 'MENDESAK' THEN 0 ELSE 1 END, " +
@@ -140,13 +150,16 @@ func (r *waterResourcesRepositoryImpl) FindByUserID(ctx context.Context, userID
 	var reports []*entity.WaterResourcesReport
 	var total int64
 
-	err := r.db.WithContext(ctx).Model(&entity.WaterResourcesReport{}).Count(&total).Error
+	err := conn(ctx, r.db).Model(&entity.WaterResourcesReport{}).
+		Where("created_by = ?", userID).
+		Count(&total).Error
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count reports: %w", err)
 	}
 
-	err = r.db.WithContext(ctx).
-		Preload("Photos").
+	err = conn(ctx, r.db).
+		Where("created_by = ?", userID).
+		Preload("Photos", orderPhotos).
 		Limit(limit).
 		Offset(offset).
 		Order("created_at DESC").
@@ -155,56 +168,57 @@ func (r *waterResourcesRepositoryImpl) FindByUserID(ctx context.Context, userID
 	return reports, total, err
 }
 
+// FindByPriority returns the open work queue ranked by the stored priority score,
+// most urgent first.
 func (r *waterResourcesRepositoryImpl) FindByPriority(ctx context.Context, limit, offset int) ([]*entity.WaterResourcesReport, int64, error) {
 	var reports []*entity.WaterResourcesReport
 	var total int64
 
-	countQuery := `
-        SELECT COUNT(*) 
-        FROM water_resources_reports 
-        WHERE status NOT IN ('COMPLETED', 'REJECTED')
-    `
-	err := r.db.WithContext(ctx).Raw(countQuery).Scan(&total).Error
-	if err != nil {
+	query := conn(ctx, r.db).
+		Model(&entity.WaterResourcesReport{}).
+		Where("status NOT IN ?", []entity.WaterResourceStatus{entity.WaterResourceStatusCompleted, entity.WaterResourceStatusRejected})
+
+	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to count priority reports: %w", err)
 	}
 
-	priorityQuery := `
-        SELECT *, 
-            (CASE WHEN urgency_category = 'MENDESAK' THEN 100 ELSE 0 END +
-             CASE WHEN damage_level = 'BERAT' THEN 50 
-                  WHEN damage_level = 'SEDANG' THEN 25 
-                  ELSE 10 END +
-             CASE WHEN affected_rice_field_area > 10 THEN 30 ELSE 0 END +
-             CASE WHEN affected_farmers_count > 50 THEN 20 ELSE 0 END) as priority_score
-        FROM water_resources_reports
-        WHERE status NOT IN ('COMPLETED', 'REJECTED')
-        ORDER BY priority_score DESC, created_at DESC
-        LIMIT $1 OFFSET $2
-    `
-
-	err = r.db.WithContext(ctx).Raw(priorityQuery, limit, offset).Scan(&reports).Error
+	err := query.
+		Preload("Photos", orderPhotos).
+		Order("priority_score DESC, created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&reports).Error
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get priority reports: %w", err)
 	}
 
-	return reports, total, err
+	return reports, total, nil
 }
 
-func (r *waterResourcesRepositoryImpl) UpdateStatus(ctx context.Context, id string, status entity.WaterResourceStatus, notes string) error {
+// UpdateStatus moves the report from one status to another. It returns
+// repository.ErrStatusChanged when the report is no longer in status from, so
+// of two concurrent changes only the first is applied.
+func (r *waterResourcesRepositoryImpl) UpdateStatus(ctx context.Context, id string, from, to entity.WaterResourceStatus, notes, updatedBy string) error {
 	query := `
         UPDATE water_resources_reports 
-        SET status = $1, updated_at = $2, notes = CASE WHEN $3 != '' THEN $3 ELSE notes END
-        WHERE id = $4
+        SET status = $1, updated_at = $2, notes = CASE WHEN $3 != '' THEN $3 ELSE notes END, updated_by = $4
+        WHERE id = $5 AND status = $6 AND deleted_at IS NULL
     `
-	return r.db.WithContext(ctx).Exec(query, status, time.Now(), notes, id).Error
+	result := conn(ctx, r.db).Exec(query, to, time.Now(), notes, updatedBy, id, from)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrStatusChanged
+	}
+	return nil
 }
 
 func (r *waterResourcesRepositoryImpl) GetStatistics(ctx context.Context) (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
 
 	var totalReports int64
-	err := r.db.WithContext(ctx).Raw(`SELECT COUNT(*) FROM water_resources_reports`).Scan(&totalReports).Error
+	err := conn(ctx, r.db).Raw(`SELECT COUNT(*) FROM ` + activeWaterResourcesReportsTable(ctx)).Scan(&totalReports).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count total reports: %w", err)
 	}
@@ -213,24 +227,24 @@ func (r *waterResourcesRepositoryImpl) GetStatistics(ctx context.Context) (map[s
 	var urgentCount int64
 	query := `
         SELECT COUNT(*) 
-        FROM water_resources_reports 
+        FROM ` + activeWaterResourcesReportsTable(ctx) + ` 
         WHERE urgency_category = $1 AND status NOT IN ('COMPLETED', 'REJECTED')
     `
-	err = r.db.WithContext(ctx).Raw(query, "MENDESAK").Scan(&urgentCount).Error
+	err = conn(ctx, r.db).Raw(query, "MENDESAK").Scan(&urgentCount).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count urgent reports: %w", err)
 	}
 	stats["urgent_pending"] = urgentCount
 
 	var totalArea float64
-	err = r.db.WithContext(ctx).Raw(`SELECT COALESCE(SUM(affected_rice_field_area), 0) FROM water_resources_reports`).Scan(&totalArea).Error
+	err = conn(ctx, r.db).Raw(`SELECT COALESCE(SUM(affected_rice_field_area), 0) FROM ` + activeWaterResourcesReportsTable(ctx)).Scan(&totalArea).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to sum affected area: %w", err)
 	}
 	stats["total_affected_area_ha"] = totalArea
 
 	var totalFarmers int64
-	err = r.db.WithContext(ctx).Raw(`SELECT COALESCE(SUM(affected_farmers_count), 0) FROM water_resources_reports`).Scan(&totalFarmers).Error
+	err = conn(ctx, r.db).Raw(`SELECT COALESCE(SUM(affected_farmers_count), 0) FROM ` + activeWaterResourcesReportsTable(ctx)).Scan(&totalFarmers).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to sum affected farmers: %w", err)
 	}
@@ -239,11 +253,11 @@ func (r *waterResourcesRepositoryImpl) GetStatistics(ctx context.Context) (map[s
 	var damageTypes []map[string]interface{}
 	query = `
         SELECT damage_type, COUNT(*) as count 
-        FROM water_resources_reports 
+        FROM ` + activeWaterResourcesReportsTable(ctx) + ` 
         GROUP BY damage_type 
         ORDER BY count DESC
     `
-	err = r.db.WithContext(ctx).Raw(query).Scan(&damageTypes).Error
+	err = conn(ctx, r.db).Raw(query).Scan(&damageTypes).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get damage types: %w", err)
 	}
@@ -252,11 +266,11 @@ func (r *waterResourcesRepositoryImpl) GetStatistics(ctx context.Context) (map[s
 	var irrigationTypes []map[string]interface{}
 	query = `
         SELECT irrigation_type, COUNT(*) as count 
-        FROM water_resources_reports 
+        FROM ` + activeWaterResourcesReportsTable(ctx) + ` 
         GROUP BY irrigation_type 
         ORDER BY count DESC
     `
-	err = r.db.WithContext(ctx).Raw(query).Scan(&irrigationTypes).Error
+	err = conn(ctx, r.db).Raw(query).Scan(&irrigationTypes).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get irrigation types: %w", err)
 	}
@@ -265,11 +279,11 @@ func (r *waterResourcesRepositoryImpl) GetStatistics(ctx context.Context) (map[s
 	var statusDist []map[string]interface{}
 	query = `
         SELECT status, COUNT(*) as count 
-        FROM water_resources_reports 
+        FROM ` + activeWaterResourcesReportsTable(ctx) + ` 
         GROUP BY status 
         ORDER BY count DESC
     `
-	err = r.db.WithContext(ctx).Raw(query).Scan(&statusDist).Error
+	err = conn(ctx, r.db).Raw(query).Scan(&statusDist).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get status distribution: %w", err)
 	}
@@ -278,10 +292,10 @@ func (r *waterResourcesRepositoryImpl) GetStatistics(ctx context.Context) (map[s
 	var totalBudget float64
 	query = `
         SELECT COALESCE(SUM(estimated_budget), 0) 
-        FROM water_resources_reports 
+        FROM ` + activeWaterResourcesReportsTable(ctx) + ` 
         WHERE status NOT IN ('COMPLETED', 'REJECTED')
     `
-	err = r.db.WithContext(ctx).Raw(query).Scan(&totalBudget).Error
+	err = conn(ctx, r.db).Raw(query).Scan(&totalBudget).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to sum budget: %w", err)
 	}
@@ -301,14 +315,14 @@ func (r *waterResourcesRepositoryImpl) GetDamageStatisticsByArea(ctx context.Con
             COALESCE(SUM(affected_farmers_count), 0) as total_affected_farmers,
             COALESCE(SUM(estimated_budget), 0) as total_estimated_budget,
             COALESCE(AVG(estimated_length * estimated_width), 0) as avg_damage_area
-        FROM water_resources_reports
+        FROM ` + activeWaterResourcesReportsTable(ctx) + `
         WHERE report_datetime BETWEEN $1 AND $2
         GROUP BY irrigation_area_name
         HAVING COUNT(*) > 0
         ORDER BY total_affected_area DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, startDate, endDate).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, startDate, endDate).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get damage statistics by area: %w", err)
 	}
@@ -319,8 +333,8 @@ func (r *waterResourcesRepositoryImpl) GetDamageStatisticsByArea(ctx context.Con
 func (r *waterResourcesRepositoryImpl) GetUrgentReports(ctx context.Context, limit int) ([]*entity.WaterResourcesReport, error) {
 	var reports []*entity.WaterResourcesReport
 
-	err := r.db.WithContext(ctx).
-		Preload("Photos").
+	err := conn(ctx, r.db).
+		Preload("Photos", orderPhotos).
 		Where("urgency_category = ?", "MENDESAK").
 		Where("status NOT IN ('COMPLETED', 'REJECTED')").
 		Order("created_at DESC").
@@ -336,8 +350,8 @@ func (r *waterResourcesRepositoryImpl) GetUrgentReports(ctx context.Context, lim
 
 func (r *waterResourcesRepositoryImpl) CalculateTotalDamageArea(ctx context.Context) (float64, error) {
 	var total float64
-	query := `SELECT COALESCE(SUM(estimated_length * estimated_width), 0) FROM water_resources_reports`
-	err := r.db.WithContext(ctx).Raw(query).Scan(&total).Error
+	query := `SELECT COALESCE(SUM(estimated_length * estimated_width), 0) FROM ` + activeWaterResourcesReportsTable(ctx)
+	err := conn(ctx, r.db).Raw(query).Scan(&total).Error
 	if err != nil {
 		return 0, fmt.Errorf("failed to calculate total damage area: %w", err)
 	}
@@ -346,8 +360,8 @@ func (r *waterResourcesRepositoryImpl) CalculateTotalDamageArea(ctx context.Cont
 
 func (r *waterResourcesRepositoryImpl) CountAffectedFarmers(ctx context.Context) (int64, error) {
 	var count int64
-	query := `SELECT COALESCE(SUM(affected_farmers_count), 0) FROM water_resources_reports`
-	err := r.db.WithContext(ctx).Raw(query).Scan(&count).Error
+	query := `SELECT COALESCE(SUM(affected_farmers_count), 0) FROM ` + activeWaterResourcesReportsTable(ctx)
+	err := conn(ctx, r.db).Raw(query).Scan(&count).Error
 	if err != nil {
 		return 0, fmt.Errorf("failed to count affected farmers: %w", err)
 	}
@@ -365,22 +379,22 @@ func (r *waterResourcesRepositoryImpl) GetSummaryKPIs(ctx context.Context, irrig
 	}
 
 	var totalArea float64
-	query := fmt.Sprintf(`SELECT COALESCE(SUM(estimated_length * estimated_width), 0) FROM water_resources_reports %s`, baseWhere)
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&totalArea).Error
+	query := fmt.Sprintf(`SELECT COALESCE(SUM(estimated_length * estimated_width), 0) FROM ` + activeWaterResourcesReportsTable(ctx) + ` %s`, baseWhere)
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&totalArea).Error
 	if err != nil {
 		return 0, 0, 0, fmt.Errorf("failed to get total area: %w", err)
 	}
 
 	var totalRice float64
-	query = fmt.Sprintf(`SELECT COALESCE(SUM(affected_rice_field_area), 0) FROM water_resources_reports %s`, baseWhere)
-	err = r.db.WithContext(ctx).Raw(query, args...).Scan(&totalRice).Error
+	query = fmt.Sprintf(`SELECT COALESCE(SUM(affected_rice_field_area), 0) FROM ` + activeWaterResourcesReportsTable(ctx) + ` %s`, baseWhere)
+	err = conn(ctx, r.db).Raw(query, args...).Scan(&totalRice).Error
 	if err != nil {
 		return 0, 0, 0, fmt.Errorf("failed to get total rice area: %w", err)
 	}
 
 	var totalReports int64
-	query = fmt.Sprintf(`SELECT COUNT(*) FROM water_resources_reports %s`, baseWhere)
-	err = r.db.WithContext(ctx).Raw(query, args...).Scan(&totalReports).Error
+	query = fmt.Sprintf(`SELECT COUNT(*) FROM ` + activeWaterResourcesReportsTable(ctx) + ` %s`, baseWhere)
+	err = conn(ctx, r.db).Raw(query, args...).Scan(&totalReports).Error
 	if err != nil {
 		return 0, 0, 0, fmt.Errorf("failed to count total reports: %w", err)
 	}
@@ -403,7 +417,7 @@ func (r *waterResourcesRepositoryImpl) GroupCountBy(ctx context.Context, field,
 
 	query := fmt.Sprintf(`
         SELECT %s as key, COUNT(*) as count 
-        FROM water_resources_reports %s 
+        FROM ` + activeWaterResourcesReportsTable(ctx) + ` %s 
         GROUP BY %s 
         ORDER BY count DESC
     `, field, baseWhere, field)
@@ -413,7 +427,7 @@ func (r *waterResourcesRepositoryImpl) GroupCountBy(ctx context.Context, field,
 		Count int64
 	}
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to group count by %s: %w", field, err)
 	}
@@ -446,7 +460,7 @@ func (r *waterResourcesRepositoryImpl) GetMapPoints(ctx context.Context, irrigat
             damage_type, 
             damage_level, 
             urgency_category
-        FROM water_resources_reports %s
+        FROM ` + activeWaterResourcesReportsTable(ctx) + ` %s
     `, baseWhere)
 
 	var results []struct {
@@ -458,7 +472,7 @@ func (r *waterResourcesRepositoryImpl) GetMapPoints(ctx context.Context, irrigat
 		UrgencyCategory string
 	}
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get map points: %w", err)
 	}
@@ -477,25 +491,25 @@ func (r *waterResourcesRepositoryImpl) GetWaterResourcesOverviewStats(ctx contex
 		args = append(args, irrigationType)
 	}
 
-	query := fmt.Sprintf(`SELECT COALESCE(SUM(estimated_length * estimated_width), 0) FROM water_resources_reports %s`, baseWhere)
+	query := fmt.Sprintf(`SELECT COALESCE(SUM(estimated_length * estimated_width), 0) FROM ` + activeWaterResourcesReportsTable(ctx) + ` %s`, baseWhere)
 	var totalDamageVolume float64
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&totalDamageVolume).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&totalDamageVolume).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate total damage volume: %w", err)
 	}
 	stats["total_damage_volume_m2"] = totalDamageVolume
 
-	query = fmt.Sprintf(`SELECT COALESCE(SUM(affected_rice_field_area), 0) FROM water_resources_reports %s`, baseWhere)
+	query = fmt.Sprintf(`SELECT COALESCE(SUM(affected_rice_field_area), 0) FROM ` + activeWaterResourcesReportsTable(ctx) + ` %s`, baseWhere)
 	var totalRiceFieldArea float64
-	err = r.db.WithContext(ctx).Raw(query, args...).Scan(&totalRiceFieldArea).Error
+	err = conn(ctx, r.db).Raw(query, args...).Scan(&totalRiceFieldArea).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate total rice field area: %w", err)
 	}
 	stats["total_rice_field_area_ha"] = totalRiceFieldArea
 
-	query = fmt.Sprintf(`SELECT COUNT(*) FROM water_resources_reports %s`, baseWhere)
+	query = fmt.Sprintf(`SELECT COUNT(*) FROM ` + activeWaterResourcesReportsTable(ctx) + ` %s`, baseWhere)
 	var totalReports int64
-	err = r.db.WithContext(ctx).Raw(query, args...).Scan(&totalReports).Error
+	err = conn(ctx, r.db).Raw(query, args...).Scan(&totalReports).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count total reports: %w", err)
 	}
@@ -515,7 +529,7 @@ func (r *waterResourcesRepositoryImpl) GetWaterLocationStats(ctx context.Context
             COALESCE(AVG(longitude), 0) as avg_longitude,
             COALESCE(SUM(affected_rice_field_area), 0) as total_affected_area,
             COALESCE(SUM(affected_farmers_count), 0) as total_affected_farmers
-        FROM water_resources_reports
+        FROM ` + activeWaterResourcesReportsTable(ctx) + `
     `
 
 	args := []interface{}{}
@@ -530,7 +544,7 @@ func (r *waterResourcesRepositoryImpl) GetWaterLocationStats(ctx context.Context
         ORDER BY report_count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get location statistics: %w", err)
 	}
@@ -549,7 +563,7 @@ func (r *waterResourcesRepositoryImpl) GetWaterUrgencyStats(ctx context.Context,
                 ELSE urgency_category 
             END as urgency_category,
             COUNT(*) as count
-        FROM water_resources_reports
+        FROM ` + activeWaterResourcesReportsTable(ctx) + `
     `
 
 	args := []interface{}{}
@@ -568,7 +582,7 @@ func (r *waterResourcesRepositoryImpl) GetWaterUrgencyStats(ctx context.Context,
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get urgency statistics: %w", err)
 	}
@@ -587,7 +601,7 @@ func (r *waterResourcesRepositoryImpl) GetWaterDamageTypeStats(ctx context.Conte
                 ELSE damage_type 
             END as damage_type,
             COUNT(*) as count
-        FROM water_resources_reports
+        FROM ` + activeWaterResourcesReportsTable(ctx) + `
     `
 
 	args := []interface{}{}
@@ -606,7 +620,7 @@ func (r *waterResourcesRepositoryImpl) GetWaterDamageTypeStats(ctx context.Conte
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get damage type statistics: %w", err)
 	}
@@ -625,7 +639,7 @@ func (r *waterResourcesRepositoryImpl) GetWaterDamageLevelStats(ctx context.Cont
                 ELSE damage_level 
             END as damage_level,
             COUNT(*) as count
-        FROM water_resources_reports
+        FROM ` + activeWaterResourcesReportsTable(ctx) + `
     `
 
 	args := []interface{}{}
@@ -644,10 +658,42 @@ func (r *waterResourcesRepositoryImpl) GetWaterDamageLevelStats(ctx context.Cont
         ORDER BY count DESC
     `
 
-	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	err := conn(ctx, r.db).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get damage level statistics: %w", err)
 	}
 
 	return results, nil
 }
+
+func (r *waterResourcesRepositoryImpl) FindDeleted(ctx context.Context, limit, offset int) ([]*entity.WaterResourcesReport, int64, error) {
+	return findDeleted[entity.WaterResourcesReport](ctx, r.db, limit, offset)
+}
+
+func (r *waterResourcesRepositoryImpl) FindDeletedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entity.WaterResourcesReport, error) {
+	return findDeletedBefore[entity.WaterResourcesReport](ctx, r.db, cutoff, limit)
+}
+
+func (r *waterResourcesRepositoryImpl) Restore(ctx context.Context, id string) error {
+	return restoreDeleted[entity.WaterResourcesReport](ctx, r.db, id)
+}
+
+func (r *waterResourcesRepositoryImpl) HardDelete(ctx context.Context, id string) error {
+	return hardDelete[entity.WaterResourcesReport](ctx, r.db, id)
+}
+
+func (r *waterResourcesRepositoryImpl) AddPhotos(ctx context.Context, reportID string, photos []entity.WaterResourcesPhoto, max int) error {
+	return addPhotos[entity.WaterResourcesReport](ctx, r.db, reportID, photos, max)
+}
+
+func (r *waterResourcesRepositoryImpl) UpdatePhoto(ctx context.Context, photo *entity.WaterResourcesPhoto) error {
+	return updatePhoto(ctx, r.db, photo)
+}
+
+func (r *waterResourcesRepositoryImpl) DeletePhoto(ctx context.Context, reportID, photoID string) error {
+	return deletePhoto[entity.WaterResourcesPhoto](ctx, r.db, reportID, photoID)
+}
+
+func (r *waterResourcesRepositoryImpl) ReorderPhotos(ctx context.Context, reportID string, photoIDs []string) error {
+	return reorderPhotos[entity.WaterResourcesPhoto](ctx, r.db, reportID, photoIDs)
+}
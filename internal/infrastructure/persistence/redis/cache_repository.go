@@ -4,19 +4,52 @@ package redis
 import (
     "context"
     "encoding/json"
-    "log"
+    "log/slog"
+    "strings"
     "time"
     
     "building-report-backend/internal/domain/repository"
+    "building-report-backend/pkg/metrics"
+    "github.com/prometheus/client_golang/prometheus"
     "github.com/redis/go-redis/v9"
 )
 
+var cacheOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+    Name: "cache_operations_total",
+    Help: "Cache operations by operation, key prefix and result (hit, miss, ok or error).",
+}, []string{"operation", "prefix", "result"})
+
+func init() {
+    metrics.Default.MustRegister(cacheOperations)
+}
+
+// keyPrefix is the part of a key before the first colon, which names what is
+// cached without the unbounded IDs that follow it.
+func keyPrefix(key string) string {
+    if i := strings.IndexByte(key, ':'); i >= 0 {
+        return key[:i]
+    }
+    return key
+}
+
+func countCacheOp(operation, key, result string) {
+    cacheOperations.WithLabelValues(operation, keyPrefix(key), result).Inc()
+}
+
+func errResult(err error) string {
+    if err != nil {
+        return "error"
+    }
+    return "ok"
+}
+
 type cacheRepositoryImpl struct {
     client *redis.Client
+    log    *slog.Logger
 }
 
-func NewCacheRepository(client *redis.Client) repository.CacheRepository {
-    return &cacheRepositoryImpl{client: client}
+func NewCacheRepository(client *redis.Client, log *slog.Logger) repository.CacheRepository {
+    return &cacheRepositoryImpl{client: client, log: log}
 }
 
 func (r *cacheRepositoryImpl) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
@@ -25,8 +58,10 @@ func (r *cacheRepositoryImpl) Set(ctx context.Context, key string, value interfa
         return err
     }
     
-    if err := r.client.Set(ctx, key, data, expiration).Err(); err != nil {
-        log.Printf("Warning: Failed to set cache key %s: %v", key, err)
+    err = r.client.Set(ctx, key, data, expiration).Err()
+    countCacheOp("set", key, errResult(err))
+    if err != nil {
+        r.log.WarnContext(ctx, "cache set failed", slog.String("key", key), slog.String("error", err.Error()))
         // Don't return error, just log it - caching should be optional
     }
     return nil
@@ -36,20 +71,58 @@ func (r *cacheRepositoryImpl) Get(ctx context.Context, key string, dest interfac
     data, err := r.client.Get(ctx, key).Result()
     if err != nil {
         if err == redis.Nil {
+            countCacheOp("get", key, "miss")
             // Key does not exist, return the error so the caller can handle it appropriately
-            return err
+            return repository.ErrCacheMiss
         }
-        log.Printf("Warning: Failed to get cache key %s: %v", key, err)
+        countCacheOp("get", key, "error")
+        r.log.WarnContext(ctx, "cache get failed", slog.String("key", key), slog.String("error", err.Error()))
         // Return error so that the caller knows to fetch from the database
         return err
     }
     
+    countCacheOp("get", key, "hit")
+    return json.Unmarshal([]byte(data), dest)
+}
+
+func (r *cacheRepositoryImpl) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+    data, err := json.Marshal(value)
+    if err != nil {
+        return false, err
+    }
+
+    ok, err := r.client.SetNX(ctx, key, data, expiration).Result()
+    countCacheOp("setnx", key, errResult(err))
+    if err != nil {
+        r.log.WarnContext(ctx, "cache setnx failed", slog.String("key", key), slog.String("error", err.Error()))
+        return false, err
+    }
+    return ok, nil
+}
+
+func (r *cacheRepositoryImpl) GetDel(ctx context.Context, key string, dest interface{}) error {
+    data, err := r.client.GetDel(ctx, key).Result()
+    if err != nil {
+        if err == redis.Nil {
+            countCacheOp("getdel", key, "miss")
+            return repository.ErrCacheMiss
+        }
+        countCacheOp("getdel", key, "error")
+        r.log.WarnContext(ctx, "cache getdel failed", slog.String("key", key), slog.String("error", err.Error()))
+        return err
+    }
+
+    countCacheOp("getdel", key, "hit")
     return json.Unmarshal([]byte(data), dest)
 }
 
 func (r *cacheRepositoryImpl) Delete(ctx context.Context, keys ...string) error {
-    if err := r.client.Del(ctx, keys...).Err(); err != nil {
-        log.Printf("Warning: Failed to delete cache keys %v: %v", keys, err)
+    err := r.client.Del(ctx, keys...).Err()
+    for _, key := range keys {
+        countCacheOp("delete", key, errResult(err))
+    }
+    if err != nil {
+        r.log.WarnContext(ctx, "cache delete failed", slog.Any("keys", keys), slog.String("error", err.Error()))
         // Don't return error, just log it - deletion failure shouldn't break functionality
     }
     return nil
@@ -57,17 +130,33 @@ func (r *cacheRepositoryImpl) Delete(ctx context.Context, keys ...string) error
 
 func (r *cacheRepositoryImpl) Exists(ctx context.Context, key string) (bool, error) {
     result, err := r.client.Exists(ctx, key).Result()
+    countCacheOp("exists", key, errResult(err))
     if err != nil {
-        log.Printf("Warning: Failed to check existence of cache key %s: %v", key, err)
-        // Return false and nil error - assume key doesn't exist if Redis is unavailable
-        return false, nil
+        r.log.WarnContext(ctx, "cache exists check failed", slog.String("key", key), slog.String("error", err.Error()))
+        // Callers use this for revocation checks, so a failure must not read
+        // as a missing key.
+        return false, err
     }
     return result > 0, nil
 }
 
+func (r *cacheRepositoryImpl) Increment(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+    count, err := r.client.Incr(ctx, key).Result()
+    countCacheOp("increment", key, errResult(err))
+    if err != nil {
+        // Unlike the other methods this one returns the error: callers use the
+        // counter for security decisions and must not mistake failure for zero.
+        return 0, err
+    }
+    if count == 1 && expiration > 0 {
+        r.client.Expire(ctx, key, expiration)
+    }
+    return count, nil
+}
+
 func (r *cacheRepositoryImpl) Flush(ctx context.Context) error {
     if err := r.client.FlushAll(ctx).Err(); err != nil {
-        log.Printf("Warning: Failed to flush Redis: %v", err)
+        r.log.WarnContext(ctx, "cache flush failed", slog.String("error", err.Error()))
         // Don't return error, just log it - flushing should be optional
     }
     return nil
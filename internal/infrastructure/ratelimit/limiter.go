@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Result describes the state of a bucket after a request was counted.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the current window ends.
+	Reset time.Duration
+}
+
+// ErrInvalidWindow is returned for a window shorter than a millisecond.
+var ErrInvalidWindow = errors.New("rate limit window must be at least one millisecond")
+
+// Limiter counts weighted requests against a limit per key and window.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration, cost int) (*Result, error)
+}
+
+// slidingWindowScript implements a sliding window counter: the previous fixed
+// window's count is weighted by how much of it still overlaps the sliding window.
+// A rejected request is not counted.
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local elapsed = tonumber(ARGV[3])
+local cost = tonumber(ARGV[4])
+
+local previous = tonumber(redis.call("GET", KEYS[2]) or "0")
+local current = tonumber(redis.call("GET", KEYS[1]) or "0")
+local used = math.floor(previous * (window - elapsed) / window) + current
+
+if used + cost > limit then
+	return {0, used}
+end
+
+redis.call("INCRBY", KEYS[1], cost)
+redis.call("PEXPIRE", KEYS[1], window * 2)
+return {1, used + cost}
+`)
+
+type redisLimiter struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisLimiter(client *redis.Client) Limiter {
+	return &redisLimiter{
+		client: client,
+		prefix: "ratelimit:",
+	}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration, cost int) (*Result, error) {
+	windowMs := window.Milliseconds()
+	if windowMs <= 0 {
+		return nil, ErrInvalidWindow
+	}
+	nowMs := time.Now().UnixMilli()
+	index := nowMs / windowMs
+	elapsed := nowMs - index*windowMs
+
+	keys := []string{
+		l.prefix + key + ":" + strconv.FormatInt(index, 10),
+		l.prefix + key + ":" + strconv.FormatInt(index-1, 10),
+	}
+
+	values, err := slidingWindowScript.Run(ctx, l.client, keys, limit, windowMs, elapsed, cost).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := limit - int(values[1])
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return &Result{
+		Allowed:   values[0] == 1,
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Duration(windowMs-elapsed) * time.Millisecond,
+	}, nil
+}
@@ -3,34 +3,125 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/url"
 	"path"
 	"strings"
 	"time"
 
+	"building-report-backend/pkg/metrics"
+	"building-report-backend/pkg/tracing"
+
 	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	storageUploadBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "storage_upload_bytes_total",
+		Help: "Bytes uploaded to object storage by folder.",
+	}, []string{"folder"})
+	storageOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "storage_operations_total",
+		Help: "Object storage operations by operation and result (ok or error).",
+	}, []string{"operation", "result"})
+	storageDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "storage_operation_duration_seconds",
+		Help: "Duration of object storage operations.",
+	}, []string{"operation"})
 )
 
+func init() {
+	metrics.Default.MustRegister(storageUploadBytes, storageOperations, storageDuration)
+}
+
+func startStorageSpan(ctx context.Context, operation, bucket, object string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "minio."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("storage.system", "minio"),
+			attribute.String("storage.bucket", bucket),
+			attribute.String("storage.object", object),
+		),
+	)
+}
+
+func observeStorageOp(operation string, start time.Time, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	storageOperations.WithLabelValues(operation, result).Inc()
+	storageDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+// StorageService keeps files in a private bucket. Stored files are referred to
+// by their object name, e.g. "reports/<id>.jpg", which is what the database
+// keeps; clients get URLs from GetFileURL when a response is built.
 type StorageService interface {
     UploadFile(ctx context.Context, file *multipart.FileHeader, folder string) (string, error)
-    DeleteFile(ctx context.Context, fileURL string) error
-    GetFileURL(ctx context.Context, objectName string) (string, error)
+    // PutObject stores data under objectName and returns objectName.
+    PutObject(ctx context.Context, objectName string, data io.Reader, size int64, contentType string) (string, error)
+    // DeleteFile removes a stored file. It also accepts the full URLs that
+    // were stored before object names were.
+    DeleteFile(ctx context.Context, objectName string) error
+    // GetFileURL returns a URL that lets anyone holding it read objectName
+    // until expiry.
+    GetFileURL(ctx context.Context, objectName string, expiry time.Duration) (string, error)
+    // PresignPost returns a form the client can POST objectName with until
+    // expiry. Storage refuses the upload unless it is exactly size bytes of
+    // contentType.
+    PresignPost(ctx context.Context, objectName, contentType string, size int64, expiry time.Duration) (PresignedPost, error)
+    // StatObject returns ErrObjectNotFound when nothing was uploaded to objectName.
+    StatObject(ctx context.Context, objectName string) (ObjectInfo, error)
+    GetObject(ctx context.Context, objectName string) (io.ReadCloser, error)
+    // DeleteObject removes objectName; a missing object is not an error.
+    DeleteObject(ctx context.Context, objectName string) error
+    // ListObjects calls fn for every object whose name starts with prefix,
+    // stopping at the first error fn returns.
+    ListObjects(ctx context.Context, prefix string, fn func(ObjectSummary) error) error
+}
+
+var ErrObjectNotFound = errors.New("object not found")
+
+// PresignedPost is a browser style upload: the client sends a multipart POST
+// to URL carrying Fields followed by the file as the "file" field.
+type PresignedPost struct {
+    URL    string
+    Fields map[string]string
+}
+
+type ObjectInfo struct {
+    Size        int64
+    ContentType string
+}
+
+// ObjectSummary is an object as ListObjects returns it.
+type ObjectSummary struct {
+    Name         string
+    Size         int64
+    LastModified time.Time
 }
 
 type minioStorage struct {
-    client     *minio.Client
-    bucketName string
-    publicURL  string
+    client        *minio.Client
+    presignClient *minio.Client
+    bucketName    string
 }
 
-func NewMinioStorage(client *minio.Client, bucketName, publicURL string) StorageService {
+// NewMinioStorage uses client for every request to MinIO and presignClient,
+// which points at the public URL, to sign URLs handed to clients.
+func NewMinioStorage(client, presignClient *minio.Client, bucketName string) StorageService {
     return &minioStorage{
-        client:     client,
-        bucketName: bucketName,
-        publicURL:  publicURL,
+        client:        client,
+        presignClient: presignClient,
+        bucketName:    bucketName,
     }
 }
 
@@ -45,34 +136,151 @@ func (s *minioStorage) UploadFile(ctx context.Context, file *multipart.FileHeade
     ext := path.Ext(file.Filename)
     objectName := fmt.Sprintf("%s/%s%s", folder, uuid.New().String(), ext)
 
-    
-    _, err = s.client.PutObject(ctx, s.bucketName, objectName, src, file.Size, minio.PutObjectOptions{
-        ContentType: file.Header.Get("Content-Type"),
+    return s.PutObject(ctx, objectName, src, file.Size, file.Header.Get("Content-Type"))
+}
+
+func (s *minioStorage) PutObject(ctx context.Context, objectName string, data io.Reader, size int64, contentType string) (string, error) {
+    ctx, span := startStorageSpan(ctx, "upload", s.bucketName, objectName)
+    defer span.End()
+
+    start := time.Now()
+    info, err := s.client.PutObject(ctx, s.bucketName, objectName, data, size, minio.PutObjectOptions{
+        ContentType: contentType,
     })
+    observeStorageOp("upload", start, err)
     if err != nil {
+        tracing.RecordError(span, err)
         return "", err
     }
+    span.SetAttributes(attribute.Int64("storage.size_bytes", info.Size))
+    storageUploadBytes.WithLabelValues(path.Dir(objectName)).Add(float64(info.Size))
 
-    
-    return fmt.Sprintf("%s/%s/%s", s.publicURL, s.bucketName, objectName), nil
+    return objectName, nil
 }
 
-func (s *minioStorage) DeleteFile(ctx context.Context, fileURL string) error {
-    
-    objectName := extractObjectName(fileURL)
-    
-    return s.client.RemoveObject(ctx, s.bucketName, objectName, minio.RemoveObjectOptions{})
+func (s *minioStorage) DeleteFile(ctx context.Context, objectName string) error {
+    return s.DeleteObject(ctx, ObjectKey(objectName))
 }
 
-func (s *minioStorage) GetFileURL(ctx context.Context, objectName string) (string, error) {
-    
-    url, err := s.client.PresignedGetObject(ctx, s.bucketName, objectName, 7*24*time.Hour, nil)
+func (s *minioStorage) GetFileURL(ctx context.Context, objectName string, expiry time.Duration) (string, error) {
+    objectName = ObjectKey(objectName)
+    ctx, span := startStorageSpan(ctx, "presign_get", s.bucketName, objectName)
+    defer span.End()
+
+    url, err := s.presignClient.PresignedGetObject(ctx, s.bucketName, objectName, expiry, nil)
     if err != nil {
+        tracing.RecordError(span, err)
         return "", err
     }
     return url.String(), nil
 }
 
+func (s *minioStorage) PresignPost(ctx context.Context, objectName, contentType string, size int64, expiry time.Duration) (PresignedPost, error) {
+    ctx, span := startStorageSpan(ctx, "presign_post", s.bucketName, objectName)
+    defer span.End()
+
+    policy := minio.NewPostPolicy()
+    for _, err := range []error{
+        policy.SetBucket(s.bucketName),
+        policy.SetKey(objectName),
+        policy.SetExpires(time.Now().UTC().Add(expiry)),
+        policy.SetContentType(contentType),
+        policy.SetContentLengthRange(size, size),
+    } {
+        if err != nil {
+            tracing.RecordError(span, err)
+            return PresignedPost{}, err
+        }
+    }
+
+    url, fields, err := s.presignClient.PresignedPostPolicy(ctx, policy)
+    if err != nil {
+        tracing.RecordError(span, err)
+        return PresignedPost{}, err
+    }
+    return PresignedPost{URL: url.String(), Fields: fields}, nil
+}
+
+func (s *minioStorage) StatObject(ctx context.Context, objectName string) (ObjectInfo, error) {
+    ctx, span := startStorageSpan(ctx, "stat", s.bucketName, objectName)
+    defer span.End()
+
+    start := time.Now()
+    info, err := s.client.StatObject(ctx, s.bucketName, objectName, minio.StatObjectOptions{})
+    if isNoSuchKey(err) {
+        observeStorageOp("stat", start, nil)
+        return ObjectInfo{}, ErrObjectNotFound
+    }
+    observeStorageOp("stat", start, err)
+    if err != nil {
+        tracing.RecordError(span, err)
+        return ObjectInfo{}, err
+    }
+    return ObjectInfo{Size: info.Size, ContentType: info.ContentType}, nil
+}
+
+func (s *minioStorage) GetObject(ctx context.Context, objectName string) (io.ReadCloser, error) {
+    ctx, span := startStorageSpan(ctx, "get", s.bucketName, objectName)
+    defer span.End()
+
+    start := time.Now()
+    object, err := s.client.GetObject(ctx, s.bucketName, objectName, minio.GetObjectOptions{})
+    observeStorageOp("get", start, err)
+    if err != nil {
+        tracing.RecordError(span, err)
+        return nil, err
+    }
+    return object, nil
+}
+
+func (s *minioStorage) DeleteObject(ctx context.Context, objectName string) error {
+    ctx, span := startStorageSpan(ctx, "delete", s.bucketName, objectName)
+    defer span.End()
+
+    start := time.Now()
+    err := s.client.RemoveObject(ctx, s.bucketName, objectName, minio.RemoveObjectOptions{})
+    observeStorageOp("delete", start, err)
+    tracing.RecordError(span, err)
+    return err
+}
+
+func (s *minioStorage) ListObjects(ctx context.Context, prefix string, fn func(ObjectSummary) error) error {
+    ctx, span := startStorageSpan(ctx, "list", s.bucketName, prefix)
+    defer span.End()
+
+    // Cancelling stops the listing when fn returns early.
+    ctx, cancel := context.WithCancel(ctx)
+    defer cancel()
+
+    start := time.Now()
+    for object := range s.client.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+        if object.Err != nil {
+            observeStorageOp("list", start, object.Err)
+            tracing.RecordError(span, object.Err)
+            return object.Err
+        }
+        if err := fn(ObjectSummary{Name: object.Key, Size: object.Size, LastModified: object.LastModified}); err != nil {
+            return err
+        }
+    }
+    observeStorageOp("list", start, nil)
+    return nil
+}
+
+func isNoSuchKey(err error) bool {
+    return err != nil && minio.ToErrorResponse(err).Code == "NoSuchKey"
+}
+
+// ObjectKey returns the object name of a stored file reference, which is
+// either an object name already or a URL of the form
+// <public url>/<bucket>/<object name> stored by earlier versions.
+func ObjectKey(ref string) string {
+    if !strings.Contains(ref, "://") {
+        return ref
+    }
+    return extractObjectName(ref)
+}
+
 func extractObjectName(fileURL string) string {
     
     parsedURL, err := url.Parse(fileURL)
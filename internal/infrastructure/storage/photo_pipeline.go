@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"runtime"
+	"time"
+
+	"building-report-backend/internal/domain/constants"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/infrastructure/imaging"
+	"building-report-backend/pkg/metrics"
+	"building-report-backend/pkg/tracing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	photoProcessingDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "photo_processing_duration_seconds",
+		Help:    "Time spent validating, decoding and re-encoding uploaded photos.",
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2, 4, 8},
+	})
+	photosRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "photo_uploads_rejected_total",
+		Help: "Uploaded photos rejected by the image pipeline by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	metrics.Default.MustRegister(photoProcessingDuration, photosRejected)
+}
+
+const (
+	variantMedium    = "medium"
+	variantThumbnail = "thumb"
+)
+
+// UploadedPhoto is a photo stored by the pipeline, ready to be copied onto a
+// sector's photo entity. Key and the variant fields of PhotoMeta are object
+// names.
+type UploadedPhoto struct {
+	Key string
+	entity.PhotoMeta
+}
+
+// PhotoPipeline sits in front of StorageService for report photos. It rejects
+// anything that is not an allowed image within the size limits, strips EXIF
+// data, keeping the capture time and GPS position, and stores the original
+// with a medium and a thumbnail variant.
+type PhotoPipeline interface {
+	UploadPhoto(ctx context.Context, file *multipart.FileHeader, folder string) (*UploadedPhoto, error)
+	// StorePhoto is UploadPhoto for a photo read from elsewhere, such as a
+	// staging object; size is its length as far as it is known.
+	StorePhoto(ctx context.Context, r io.Reader, size int64, folder string) (*UploadedPhoto, error)
+}
+
+type photoPipeline struct {
+	storage   StorageService
+	processor *imaging.Processor
+	// slots bounds how many photos are decoded at once; a full size image
+	// takes tens of megabytes of memory.
+	slots chan struct{}
+}
+
+func NewPhotoPipeline(storage StorageService) PhotoPipeline {
+	return &photoPipeline{
+		storage: storage,
+		processor: imaging.NewProcessor(imaging.Options{
+			AllowedFormats: constants.AllowedImageFormats,
+			MaxBytes:       constants.MaxFileSize,
+			MaxPixels:      constants.MaxImagePixels,
+			VariantSizes: map[string]int{
+				variantMedium:    constants.MediumMaxDimension,
+				variantThumbnail: constants.ThumbnailMaxDimension,
+			},
+			JPEGQuality: constants.JPEGQuality,
+		}),
+		slots: make(chan struct{}, runtime.NumCPU()),
+	}
+}
+
+func (p *photoPipeline) UploadPhoto(ctx context.Context, file *multipart.FileHeader, folder string) (*UploadedPhoto, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	return p.StorePhoto(ctx, src, file.Size, folder)
+}
+
+func (p *photoPipeline) StorePhoto(ctx context.Context, r io.Reader, size int64, folder string) (*UploadedPhoto, error) {
+	ctx, span := tracing.Start(ctx, "PhotoPipeline.StorePhoto",
+		attribute.String("photo.folder", folder),
+		attribute.Int64("photo.upload_bytes", size),
+	)
+	defer span.End()
+
+	result, err := p.process(ctx, r, size)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	span.SetAttributes(
+		attribute.Int("photo.width", result.Original.Width),
+		attribute.Int("photo.height", result.Original.Height),
+	)
+
+	base := fmt.Sprintf("%s/%s", folder, uuid.New().String())
+	photo := &UploadedPhoto{PhotoMeta: entity.PhotoMeta{
+		Width:        result.Original.Width,
+		Height:       result.Original.Height,
+		TakenAt:      result.Exif.TakenAt,
+		GPSLatitude:  result.Exif.Latitude,
+		GPSLongitude: result.Exif.Longitude,
+	}}
+
+	uploads := []struct {
+		name    string
+		encoded imaging.Encoded
+		key     *string
+	}{
+		{base, result.Original, &photo.Key},
+		{base + "_" + variantMedium, result.Variants[variantMedium], &photo.MediumURL},
+		{base + "_" + variantThumbnail, result.Variants[variantThumbnail], &photo.ThumbnailURL},
+	}
+
+	var stored []string
+	for _, u := range uploads {
+		key, err := p.storage.PutObject(ctx, u.name+u.encoded.Extension, bytes.NewReader(u.encoded.Data), int64(len(u.encoded.Data)), u.encoded.ContentType)
+		if err != nil {
+			// Do not leave a photo behind with only some of its variants.
+			for _, storedKey := range stored {
+				_ = p.storage.DeleteFile(context.WithoutCancel(ctx), storedKey)
+			}
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		*u.key = key
+		stored = append(stored, key)
+	}
+
+	return photo, nil
+}
+
+func (p *photoPipeline) process(ctx context.Context, r io.Reader, size int64) (*imaging.Result, error) {
+	// The declared size is checked before reading; the processor checks the
+	// bytes actually read as well.
+	if size > constants.MaxFileSize {
+		photosRejected.WithLabelValues(rejectReason(imaging.ErrTooLarge)).Inc()
+		return nil, imaging.ErrTooLarge
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+		defer func() { <-p.slots }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	start := time.Now()
+	result, err := p.processor.Process(r)
+	photoProcessingDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		if errors.Is(err, imaging.ErrInvalidImage) {
+			photosRejected.WithLabelValues(rejectReason(err)).Inc()
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func rejectReason(err error) string {
+	switch {
+	case errors.Is(err, imaging.ErrNotImage):
+		return "not_image"
+	case errors.Is(err, imaging.ErrUnsupportedFormat):
+		return "format"
+	case errors.Is(err, imaging.ErrTooLarge):
+		return "too_large"
+	case errors.Is(err, imaging.ErrTooManyPixels):
+		return "dimensions"
+	default:
+		return "corrupt"
+	}
+}
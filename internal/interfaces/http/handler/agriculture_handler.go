@@ -1,14 +1,18 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"mime/multipart"
 	"strconv"
 	"time"
 
 	"building-report-backend/internal/application/dto"
 	"building-report-backend/internal/application/usecase"
+	"building-report-backend/internal/domain/entity"
 	"building-report-backend/internal/interfaces/response"
+	"building-report-backend/pkg/logger"
 	"building-report-backend/pkg/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -127,6 +131,7 @@ func (h *AgricultureHandler) CreateReport(c *fiber.Ctx) error {
     req.UrgentNeeds = c.FormValue("urgent_needs")
     req.WaterAccess = c.FormValue("water_access")
     req.Suggestions = c.FormValue("suggestions")
+    req.UploadSessionID = c.FormValue("upload_session_id")
 
     if req.FoodCommodity == "" && req.HortiCommodity == "" && req.PlantationCommodity == "" {
         return response.BadRequest(c, "At least one commodity (food/horticulture/plantation) must be specified", nil)
@@ -139,26 +144,30 @@ func (h *AgricultureHandler) CreateReport(c *fiber.Ctx) error {
         return response.ValidationError(c, err)
     }
 
-    form, err := c.MultipartForm()
-    if err != nil {
-        return response.BadRequest(c, "Failed to parse multipart form", err)
-    }
-    photos := form.File["photos"]
-    if len(photos) < 1 {
-        return response.BadRequest(c, "At least 1 photo required", nil)
-    }
-    for _, photo := range photos {
-        if photo.Size > 10*1024*1024 {
-            return response.BadRequest(c, "Photo file size too large (max 10MB)", nil)
+    // Photos uploaded through an upload session take the place of multipart
+    // files; the use case checks how many the session holds.
+    var photos []*multipart.FileHeader
+    if req.UploadSessionID == "" {
+        form, err := c.MultipartForm()
+        if err != nil {
+            return response.BadRequest(c, "Failed to parse multipart form", err)
         }
-        contentType := photo.Header.Get("Content-Type")
-        if contentType != "image/jpeg" && contentType != "image/png" && contentType != "image/jpg" {
-            return response.BadRequest(c, "Only JPEG and PNG images are allowed", nil)
+        photos = form.File["photos"]
+        if len(photos) < 1 {
+            return response.BadRequest(c, "At least 1 photo required", nil)
         }
     }
 
-    report, err := h.agricultureUseCase.CreateReport(c.Context(), &req, photos)
+    userID := c.Locals("userID").(string)
+
+    report, err := h.agricultureUseCase.CreateReport(c.UserContext(), &req, photos, userID)
     if err != nil {
+        if resp, ok := photoErrorResponse(c, err); ok {
+            return resp
+        }
+        if errors.Is(err, entity.ErrNoDataScope) {
+            return response.Forbidden(c, "No district or unit is assigned to your account", err)
+        }
         return response.InternalError(c, "Failed to create agriculture report", err)
     }
 
@@ -169,7 +178,7 @@ func (h *AgricultureHandler) CreateReport(c *fiber.Ctx) error {
 func (h *AgricultureHandler) GetReport(c *fiber.Ctx) error {
     idStr := c.Params("id")
 
-    report, err := h.agricultureUseCase.GetReport(c.Context(), idStr)
+    report, err := h.agricultureUseCase.GetReport(c.UserContext(), idStr)
     if err != nil {
         return response.NotFound(c, "Report not found", err)
     }
@@ -214,7 +223,29 @@ func (h *AgricultureHandler) ListReports(c *fiber.Ctx) error {
     
     normalizeFilters(filters)
 
-    result, err := h.agricultureUseCase.ListReports(c.Context(), page, limit, filters)
+    result, err := h.agricultureUseCase.ListReports(c.UserContext(), page, limit, filters)
+    if err != nil {
+        return response.InternalError(c, "Failed to retrieve reports", err)
+    }
+
+    return response.Success(c, "Reports retrieved successfully", result)
+}
+
+// ListMyReports returns the reports created by the authenticated user
+func (h *AgricultureHandler) ListMyReports(c *fiber.Ctx) error {
+    page, _ := strconv.Atoi(c.Query("page", "1"))
+    limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+    if page < 1 {
+        page = 1
+    }
+    if limit < 1 || limit > 100 {
+        limit = 10
+    }
+
+    userID := c.Locals("userID").(string)
+
+    result, err := h.agricultureUseCase.ListMyReports(c.UserContext(), userID, page, limit)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve reports", err)
     }
@@ -238,8 +269,9 @@ func (h *AgricultureHandler) UpdateReport(c *fiber.Ctx) error {
     }
 
     userID := c.Locals("userID").(string)
+    role := entity.UserRole(c.Locals("role").(string))
 
-    report, err := h.agricultureUseCase.UpdateReport(c.Context(), idStr, &req, userID)
+    report, err := h.agricultureUseCase.UpdateReport(c.UserContext(), idStr, &req, userID, role)
     if err != nil {
         if err == usecase.ErrUnauthorized {
             return response.Forbidden(c, "You don't have permission to update this report", err)
@@ -255,8 +287,9 @@ func (h *AgricultureHandler) DeleteReport(c *fiber.Ctx) error {
     idStr := c.Params("id")
 
     userID := c.Locals("userID").(string)
+    role := entity.UserRole(c.Locals("role").(string))
 
-    if err := h.agricultureUseCase.DeleteReport(c.Context(), idStr, userID); err != nil {
+    if err := h.agricultureUseCase.DeleteReport(c.UserContext(), idStr, userID, role); err != nil {
         if err == usecase.ErrUnauthorized {
             return response.Forbidden(c, "You don't have permission to delete this report", err)
         }
@@ -269,7 +302,7 @@ func (h *AgricultureHandler) DeleteReport(c *fiber.Ctx) error {
 func (h *AgricultureHandler) GetExecutiveDashboard(c *fiber.Ctx) error {
     commodityType := c.Query("commodity_type", "") 
     
-    summary, err := h.agricultureUseCase.GetExecutiveSummary(c.Context(), commodityType)
+    summary, err := h.agricultureUseCase.GetExecutiveSummary(c.UserContext(), commodityType)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve executive summary", err)
     }
@@ -299,7 +332,7 @@ func (h *AgricultureHandler) GetCommodityAnalysis(c *fiber.Ctx) error {
         return response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD", err)
     }
 
-    analysis, err := h.agricultureUseCase.GetCommodityAnalysis(c.Context(), startDate, endDate, commodityName)
+    analysis, err := h.agricultureUseCase.GetCommodityAnalysis(c.UserContext(), startDate, endDate, commodityName)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve commodity analysis", err)
     }
@@ -316,7 +349,7 @@ func (h *AgricultureHandler) GetCommodityAnalysis(c *fiber.Ctx) error {
 func (h *AgricultureHandler) GetFoodCropStats(c *fiber.Ctx) error {
     commodityName := utils.NormalizeEnum(c.Query("commodity_name", ""))
 
-    stats, err := h.agricultureUseCase.GetFoodCropStats(c.Context(), commodityName)
+    stats, err := h.agricultureUseCase.GetFoodCropStats(c.UserContext(), commodityName)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve food crop statistics", err)
     }
@@ -327,7 +360,7 @@ func (h *AgricultureHandler) GetFoodCropStats(c *fiber.Ctx) error {
 func (h *AgricultureHandler) GetHorticultureStats(c *fiber.Ctx) error {
     commodityName := utils.NormalizeEnum(c.Query("commodity_name", ""))
 
-    stats, err := h.agricultureUseCase.GetHorticultureStats(c.Context(), commodityName)
+    stats, err := h.agricultureUseCase.GetHorticultureStats(c.UserContext(), commodityName)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve horticulture statistics", err)
     }
@@ -339,7 +372,7 @@ func (h *AgricultureHandler) GetHorticultureStats(c *fiber.Ctx) error {
 func (h *AgricultureHandler) GetPlantationStats(c *fiber.Ctx) error {
     commodityName := utils.NormalizeEnum(c.Query("commodity_name", ""))
 
-    stats, err := h.agricultureUseCase.GetPlantationStats(c.Context(), commodityName)
+    stats, err := h.agricultureUseCase.GetPlantationStats(c.UserContext(), commodityName)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve plantation statistics", err)
     }
@@ -366,7 +399,7 @@ func (h *AgricultureHandler) GetAgriculturalEquipmentStats(c *fiber.Ctx) error {
         return response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD", err)
     }
 
-    stats, err := h.agricultureUseCase.GetAgriculturalEquipmentStats(c.Context(), startDate, endDate)
+    stats, err := h.agricultureUseCase.GetAgriculturalEquipmentStats(c.UserContext(), startDate, endDate)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve agricultural equipment statistics", err)
     }
@@ -394,7 +427,7 @@ func (h *AgricultureHandler) GetLandAndIrrigationStats(c *fiber.Ctx) error {
         return response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD", err)
     }
 
-    stats, err := h.agricultureUseCase.GetLandAndIrrigationStats(c.Context(), startDate, endDate)
+    stats, err := h.agricultureUseCase.GetLandAndIrrigationStats(c.UserContext(), startDate, endDate)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve land and irrigation statistics", err)
     }
@@ -404,7 +437,7 @@ func (h *AgricultureHandler) GetLandAndIrrigationStats(c *fiber.Ctx) error {
 
 func (h *AgricultureHandler) HandlePanic(c *fiber.Ctx) {
     if r := recover(); r != nil {
-        log.Printf("[PANIC] Agriculture handler panic: %v", r)
+        logger.FromContext(c.UserContext()).ErrorContext(c.UserContext(), "agriculture handler panic", slog.Any("panic", r))
         response.InternalError(c, "Internal server error occurred", fmt.Errorf("%v", r))
     }
 }
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"errors"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/application/usecase"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/interfaces/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type APIKeyHandler struct {
+	apiKeyUseCase *usecase.APIKeyUseCase
+}
+
+func NewAPIKeyHandler(apiKeyUseCase *usecase.APIKeyUseCase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUseCase: apiKeyUseCase,
+	}
+}
+
+func (h *APIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+	role := entity.UserRole(c.Locals("role").(string))
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return response.ValidationError(c, err)
+	}
+
+	result, err := h.apiKeyUseCase.CreateAPIKey(c.UserContext(), userID, role, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return response.NotFound(c, "Owner not found", err)
+		case errors.Is(err, usecase.ErrForbidden):
+			return response.Forbidden(c, "Only superadmin can create keys owned by another user", err)
+		case errors.Is(err, usecase.ErrAPIKeyExpiry):
+			return response.BadRequest(c, "Invalid expiry", err)
+		}
+		return response.InternalError(c, "Failed to create API key", err)
+	}
+
+	return response.Created(c, "API key created successfully. Store the key now; it cannot be shown again", result)
+}
+
+func (h *APIKeyHandler) ListAPIKeys(c *fiber.Ctx) error {
+	page, limit := parseAuditPagination(c)
+
+	filters := map[string]interface{}{
+		"owner_id":        c.Query("owner_id"),
+		"include_revoked": c.QueryBool("include_revoked", false),
+	}
+
+	result, err := h.apiKeyUseCase.ListAPIKeys(c.UserContext(), filters, page, limit)
+	if err != nil {
+		return response.InternalError(c, "Failed to retrieve API keys", err)
+	}
+
+	return response.Success(c, "API keys retrieved successfully", result)
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+	id := c.Params("id")
+
+	if err := h.apiKeyUseCase.RevokeAPIKey(c.UserContext(), id, userID); err != nil {
+		if errors.Is(err, usecase.ErrAPIKeyNotFound) {
+			return response.NotFound(c, "API key not found or already revoked", err)
+		}
+		return response.InternalError(c, "Failed to revoke API key", err)
+	}
+
+	return response.Success(c, "API key revoked successfully", nil)
+}
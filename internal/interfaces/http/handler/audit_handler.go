@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"building-report-backend/internal/application/usecase"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/interfaces/response"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type AuditHandler struct {
+	auditUseCase *usecase.AuditUseCase
+}
+
+func NewAuditHandler(auditUseCase *usecase.AuditUseCase) *AuditHandler {
+	return &AuditHandler{
+		auditUseCase: auditUseCase,
+	}
+}
+
+// GetReportHistory returns the handler for GET /{sector}/:id/history
+func (h *AuditHandler) GetReportHistory(sector entity.Sector) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Params("id")
+		page, limit := parseAuditPagination(c)
+
+		role := entity.UserRole(c.Locals("role").(string))
+
+		result, err := h.auditUseCase.GetReportHistory(c.UserContext(), sector, id, role, page, limit)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return response.NotFound(c, "Report not found", err)
+			}
+			return response.InternalError(c, "Failed to retrieve report history", err)
+		}
+
+		return response.Success(c, "Report history retrieved successfully", result)
+	}
+}
+
+func (h *AuditHandler) SearchAuditLogs(c *fiber.Ctx) error {
+	page, limit := parseAuditPagination(c)
+
+	filters := map[string]interface{}{
+		"sector":    c.Query("sector"),
+		"report_id": c.Query("report_id"),
+		"actor_id":  c.Query("actor_id"),
+		"action":    c.Query("action"),
+		"q":         c.Query("q"),
+	}
+
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		startDate, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			return response.BadRequest(c, "Invalid start_date format, use YYYY-MM-DD", err)
+		}
+		filters["start_date"] = startDate
+	}
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		endDate, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			return response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD", err)
+		}
+		filters["end_date"] = endDate
+	}
+
+	result, err := h.auditUseCase.SearchAuditLogs(c.UserContext(), page, limit, filters)
+	if err != nil {
+		return response.InternalError(c, "Failed to retrieve audit logs", err)
+	}
+
+	return response.Success(c, "Audit logs retrieved successfully", result)
+}
+
+func parseAuditPagination(c *fiber.Ctx) (int, int) {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	return page, limit
+}
@@ -1,9 +1,17 @@
 package handler
 
 import (
+    "errors"
+    "math"
+    "strconv"
+    "strings"
+    "time"
+
     "building-report-backend/internal/application/dto"
     "building-report-backend/internal/application/usecase"
+    "building-report-backend/internal/infrastructure/auth"
     "building-report-backend/internal/interfaces/response"
+    "building-report-backend/pkg/validation"
     
     "github.com/gofiber/fiber/v2"
 )
@@ -28,7 +36,7 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
         return response.ValidationError(c, err)
     }
 
-    result, err := h.authUseCase.Register(c.Context(), &req)
+    result, err := h.authUseCase.Register(c.UserContext(), &req)
     if err != nil {
         if err == usecase.ErrUserExists {
             return response.Conflict(c, "User already exists", err)
@@ -49,8 +57,11 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
         return response.ValidationError(c, err)
     }
 
-    result, err := h.authUseCase.Login(c.Context(), &req)
+    result, challenge, err := h.authUseCase.Login(c.UserContext(), &req, c.IP())
     if err != nil {
+        if loginLocked(c, err) {
+            return response.TooManyRequests(c, "Too many failed login attempts", err)
+        }
         if err == usecase.ErrInvalidCredentials {
             return response.Unauthorized(c, "Invalid credentials", err)
         }
@@ -60,13 +71,154 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
         return response.InternalError(c, "Failed to login", err)
     }
 
+    if challenge != nil {
+        return response.Success(c, "Two-factor authentication required", challenge)
+    }
+
     return response.Success(c, "Login successful", result)
 }
 
+// loginLocked sets Retry-After and reports true when err is a login lockout.
+func loginLocked(c *fiber.Ctx, err error) bool {
+    var locked *usecase.LoginLockedError
+    if !errors.As(err, &locked) {
+        return false
+    }
+    c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+    return true
+}
+
+func (h *AuthHandler) VerifyTwoFactor(c *fiber.Ctx) error {
+    var req dto.VerifyTwoFactorRequest
+    if err := c.BodyParser(&req); err != nil {
+        return response.BadRequest(c, "Invalid request body", err)
+    }
+
+    if err := req.Validate(); err != nil {
+        return response.ValidationError(c, err)
+    }
+
+    result, err := h.authUseCase.VerifyTwoFactor(c.UserContext(), &req, c.IP())
+    if err != nil {
+        if loginLocked(c, err) {
+            return response.TooManyRequests(c, "Too many failed login attempts", err)
+        }
+        if err == usecase.ErrInvalidChallenge {
+            return response.Unauthorized(c, "Invalid or expired two-factor challenge", err)
+        }
+        if err == usecase.ErrInvalidTwoFactorCode {
+            return response.Unauthorized(c, "Invalid two-factor code", err)
+        }
+        if err == usecase.ErrInactiveUser {
+            return response.Forbidden(c, "User account is inactive", err)
+        }
+        return response.InternalError(c, "Failed to verify two-factor code", err)
+    }
+
+    return response.Success(c, "Login successful", result)
+}
+
+func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
+    var req dto.RefreshTokenRequest
+    if err := c.BodyParser(&req); err != nil {
+        return response.BadRequest(c, "Invalid request body", err)
+    }
+
+    if err := req.Validate(); err != nil {
+        return response.ValidationError(c, err)
+    }
+
+    result, err := h.authUseCase.RefreshToken(c.UserContext(), &req)
+    if err != nil {
+        if errors.Is(err, auth.ErrInvalidRefreshToken) {
+            return response.Unauthorized(c, "Invalid or expired refresh token", err)
+        }
+        if err == usecase.ErrInactiveUser {
+            return response.Forbidden(c, "User account is inactive", err)
+        }
+        return response.InternalError(c, "Failed to refresh token", err)
+    }
+
+    return response.Success(c, "Token refreshed successfully", result)
+}
+
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+    tokenID, _ := c.Locals("tokenID").(string)
+    tokenExpiresAt, _ := c.Locals("tokenExpiresAt").(time.Time)
+
+    var req dto.LogoutRequest
+    if len(c.Body()) > 0 {
+        if err := c.BodyParser(&req); err != nil {
+            return response.BadRequest(c, "Invalid request body", err)
+        }
+    }
+
+    if err := h.authUseCase.Logout(c.UserContext(), tokenID, tokenExpiresAt, &req); err != nil {
+        return response.InternalError(c, "Failed to logout", err)
+    }
+
+    return response.Success(c, "Logout successful", nil)
+}
+
+func (h *AuthHandler) ChangePassword(c *fiber.Ctx) error {
+    userID := c.Locals("userID").(string)
+
+    var req dto.ChangePasswordRequest
+    if err := c.BodyParser(&req); err != nil {
+        return response.BadRequest(c, "Invalid request body", err)
+    }
+
+    if err := req.Validate(); err != nil {
+        return response.ValidationError(c, err)
+    }
+
+    result, err := h.authUseCase.ChangePassword(c.UserContext(), userID, &req)
+    if err != nil {
+        if err == usecase.ErrInvalidCredentials {
+            return response.BadRequest(c, "Current password is incorrect", err)
+        }
+        if err == usecase.ErrSamePassword || err == validation.ErrPasswordTooWeak {
+            return response.BadRequest(c, "Invalid new password", err)
+        }
+        if err == usecase.ErrUserNotFound {
+            return response.NotFound(c, "User not found", err)
+        }
+        return response.InternalError(c, "Failed to change password", err)
+    }
+
+    return response.Success(c, "Password changed successfully", result)
+}
+
+func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
+    var req dto.ResetPasswordRequest
+    if err := c.BodyParser(&req); err != nil {
+        return response.BadRequest(c, "Invalid request body", err)
+    }
+
+    if err := req.Validate(); err != nil {
+        return response.ValidationError(c, err)
+    }
+
+    if err := h.authUseCase.ResetPassword(c.UserContext(), &req); err != nil {
+        if errors.Is(err, auth.ErrInvalidResetToken) {
+            return response.BadRequest(c, "Invalid or expired reset token", err)
+        }
+        if err == validation.ErrPasswordTooWeak {
+            return response.BadRequest(c, "Invalid new password", err)
+        }
+        if err == usecase.ErrInactiveUser {
+            return response.Forbidden(c, "User account is inactive", err)
+        }
+        return response.InternalError(c, "Failed to reset password", err)
+    }
+
+    return response.Success(c, "Password reset successfully", nil)
+}
+
 func (h *AuthHandler) GetProfile(c *fiber.Ctx) error {
     userID := c.Locals("userID").(string)
     
-    user, err := h.authUseCase.GetUserByID(c.Context(), userID)
+    user, err := h.authUseCase.GetUserByID(c.UserContext(), userID)
     if err != nil {
         return response.NotFound(c, "User not found", err)
     }
@@ -79,8 +231,21 @@ func (h *AuthHandler) GetProfile(c *fiber.Ctx) error {
 
 func (h *AuthHandler) GetAllUsers(c *fiber.Ctx) error {
     requesterID := c.Locals("userID").(string)
+    page, limit := parseAuditPagination(c)
+
+    filters := map[string]interface{}{
+        "q":          strings.TrimSpace(c.Query("q")),
+        "role":       strings.ToUpper(c.Query("role")),
+        "district":   c.Query("district"),
+        "unit":       c.Query("unit"),
+        "sort_by":    c.Query("sort_by"),
+        "sort_order": c.Query("sort_order"),
+    }
+    if c.Query("is_active") != "" {
+        filters["is_active"] = c.QueryBool("is_active")
+    }
 
-    result, err := h.authUseCase.GetAllUsers(c.Context(), requesterID)
+    result, err := h.authUseCase.GetAllUsers(c.UserContext(), requesterID, filters, page, limit)
     if err != nil {
         if err == usecase.ErrForbidden {
             return response.Forbidden(c, "Only superadmin can access this resource", err)
@@ -91,12 +256,70 @@ func (h *AuthHandler) GetAllUsers(c *fiber.Ctx) error {
     return response.Success(c, "Users retrieved successfully", result)
 }
 
+// BulkUpdateUserStatus activates or deactivates a list of users.
+func (h *AuthHandler) BulkUpdateUserStatus(c *fiber.Ctx) error {
+    requesterID := c.Locals("userID").(string)
+
+    var req dto.BulkUserStatusRequest
+    if err := c.BodyParser(&req); err != nil {
+        return response.BadRequest(c, "Invalid request body", err)
+    }
+
+    if err := req.Validate(); err != nil {
+        return response.ValidationError(c, err)
+    }
+
+    result, err := h.authUseCase.BulkSetUserStatus(c.UserContext(), requesterID, &req)
+    if err != nil {
+        if err == usecase.ErrForbidden {
+            return response.Forbidden(c, "Only superadmin can update users", err)
+        }
+        return response.InternalError(c, "Failed to update users", err)
+    }
+
+    return response.Success(c, "User status updated successfully", result)
+}
+
+// ImportUsers creates users from an uploaded CSV file (form field "file").
+// With ?dry_run=true the rows are only validated.
+func (h *AuthHandler) ImportUsers(c *fiber.Ctx) error {
+    requesterID := c.Locals("userID").(string)
+
+    fileHeader, err := c.FormFile("file")
+    if err != nil {
+        return response.BadRequest(c, "A CSV file is required in the file field", err)
+    }
+
+    file, err := fileHeader.Open()
+    if err != nil {
+        return response.BadRequest(c, "Failed to read uploaded file", err)
+    }
+    defer file.Close()
+
+    result, err := h.authUseCase.ImportUsers(c.UserContext(), requesterID, file, c.QueryBool("dry_run", false))
+    if err != nil {
+        switch {
+        case err == usecase.ErrForbidden:
+            return response.Forbidden(c, "Only superadmin can import users", err)
+        case errors.Is(err, usecase.ErrInvalidImportFile), errors.Is(err, usecase.ErrImportTooLarge):
+            return response.BadRequest(c, err.Error(), err)
+        }
+        return response.InternalError(c, "Failed to import users", err)
+    }
+
+    message := "User import processed"
+    if result.DryRun {
+        message = "User import validated"
+    }
+    return response.Success(c, message, result)
+}
+
 
 func (h *AuthHandler) GetUserByID(c *fiber.Ctx) error {
     requesterID := c.Locals("userID").(string)
     targetUserID := c.Params("id")
 
-    result, err := h.authUseCase.GetUserDetailByID(c.Context(), requesterID, targetUserID)
+    result, err := h.authUseCase.GetUserDetailByID(c.UserContext(), requesterID, targetUserID)
     if err != nil {
         if err == usecase.ErrForbidden {
             return response.Forbidden(c, "Only superadmin can access this resource", err)
@@ -123,7 +346,7 @@ func (h *AuthHandler) CreateUser(c *fiber.Ctx) error {
         return response.ValidationError(c, err)
     }
 
-    result, err := h.authUseCase.CreateUser(c.Context(), requesterID, &req)
+    result, err := h.authUseCase.CreateUser(c.UserContext(), requesterID, &req)
     if err != nil {
         if err == usecase.ErrForbidden {
             return response.Forbidden(c, "Only superadmin can create users", err)
@@ -151,7 +374,7 @@ func (h *AuthHandler) UpdateUser(c *fiber.Ctx) error {
         return response.ValidationError(c, err)
     }
 
-    result, err := h.authUseCase.UpdateUserRole(c.Context(), requesterID, targetUserID, &req)
+    result, err := h.authUseCase.UpdateUser(c.UserContext(), requesterID, targetUserID, &req)
     if err != nil {
         if err == usecase.ErrForbidden {
             return response.Forbidden(c, "Only superadmin can update users", err)
@@ -170,7 +393,7 @@ func (h *AuthHandler) DeleteUser(c *fiber.Ctx) error {
     requesterID := c.Locals("userID").(string)
     targetUserID := c.Params("id")
 
-    err := h.authUseCase.DeleteUser(c.Context(), requesterID, targetUserID)
+    err := h.authUseCase.DeleteUser(c.UserContext(), requesterID, targetUserID)
     if err != nil {
         if err == usecase.ErrForbidden {
             return response.Forbidden(c, "Only superadmin can delete users", err)
@@ -185,4 +408,201 @@ func (h *AuthHandler) DeleteUser(c *fiber.Ctx) error {
     }
 
     return response.Success(c, "User deleted successfully", nil)
-}
\ No newline at end of file
+}
+
+func (h *AuthHandler) IssuePasswordReset(c *fiber.Ctx) error {
+    requesterID := c.Locals("userID").(string)
+    targetUserID := c.Params("id")
+
+    result, err := h.authUseCase.IssuePasswordReset(c.UserContext(), requesterID, targetUserID)
+    if err != nil {
+        if err == usecase.ErrForbidden {
+            return response.Forbidden(c, "Only superadmin can reset passwords", err)
+        }
+        if err == usecase.ErrUserNotFound {
+            return response.NotFound(c, "User not found", err)
+        }
+        return response.InternalError(c, "Failed to issue password reset", err)
+    }
+
+    return response.Success(c, "Password reset issued successfully", result)
+}
+
+func (h *AuthHandler) ListLoginLockouts(c *fiber.Ctx) error {
+    requesterID := c.Locals("userID").(string)
+    page, limit := parseAuditPagination(c)
+
+    filters := map[string]interface{}{
+        "user_id":    c.Query("user_id"),
+        "scope":      c.Query("scope"),
+        "ip_address": c.Query("ip_address"),
+        "active":     c.QueryBool("active", false),
+    }
+
+    result, err := h.authUseCase.ListLoginLockouts(c.UserContext(), requesterID, filters, page, limit)
+    if err != nil {
+        if err == usecase.ErrForbidden {
+            return response.Forbidden(c, "Only superadmin can access this resource", err)
+        }
+        return response.InternalError(c, "Failed to get login lockouts", err)
+    }
+
+    return response.Success(c, "Login lockouts retrieved successfully", result)
+}
+
+func (h *AuthHandler) UnlockUser(c *fiber.Ctx) error {
+    requesterID := c.Locals("userID").(string)
+    targetUserID := c.Params("id")
+
+    if err := h.authUseCase.UnlockUser(c.UserContext(), requesterID, targetUserID); err != nil {
+        if err == usecase.ErrForbidden {
+            return response.Forbidden(c, "Only superadmin can unlock users", err)
+        }
+        if err == usecase.ErrUserNotFound {
+            return response.NotFound(c, "User not found", err)
+        }
+        return response.InternalError(c, "Failed to unlock user", err)
+    }
+
+    return response.Success(c, "User unlocked successfully", nil)
+}
+
+func (h *AuthHandler) GetTwoFactorStatus(c *fiber.Ctx) error {
+    userID := c.Locals("userID").(string)
+
+    result, err := h.authUseCase.GetTwoFactorStatus(c.UserContext(), userID)
+    if err != nil {
+        if err == usecase.ErrUserNotFound {
+            return response.NotFound(c, "User not found", err)
+        }
+        return response.InternalError(c, "Failed to get two-factor status", err)
+    }
+
+    return response.Success(c, "Two-factor status retrieved", result)
+}
+
+func (h *AuthHandler) SetupTwoFactor(c *fiber.Ctx) error {
+    userID := c.Locals("userID").(string)
+
+    result, err := h.authUseCase.SetupTwoFactor(c.UserContext(), userID)
+    if err != nil {
+        if err == usecase.ErrTwoFactorAlreadyEnabled {
+            return response.Conflict(c, "Two-factor authentication is already enabled", err)
+        }
+        if err == usecase.ErrUserNotFound {
+            return response.NotFound(c, "User not found", err)
+        }
+        return response.InternalError(c, "Failed to start two-factor setup", err)
+    }
+
+    return response.Success(c, "Scan the otpauth URI with an authenticator app, then confirm a code", result)
+}
+
+func (h *AuthHandler) EnableTwoFactor(c *fiber.Ctx) error {
+    userID := c.Locals("userID").(string)
+
+    var req dto.TwoFactorCodeRequest
+    if err := c.BodyParser(&req); err != nil {
+        return response.BadRequest(c, "Invalid request body", err)
+    }
+
+    if err := req.Validate(); err != nil {
+        return response.ValidationError(c, err)
+    }
+
+    result, err := h.authUseCase.EnableTwoFactor(c.UserContext(), userID, &req)
+    if err != nil {
+        if err == usecase.ErrTwoFactorAlreadyEnabled {
+            return response.Conflict(c, "Two-factor authentication is already enabled", err)
+        }
+        if err == usecase.ErrTwoFactorSetupExpired || err == usecase.ErrInvalidTwoFactorCode {
+            return response.BadRequest(c, "Invalid two-factor code", err)
+        }
+        if err == usecase.ErrUserNotFound {
+            return response.NotFound(c, "User not found", err)
+        }
+        return response.InternalError(c, "Failed to enable two-factor authentication", err)
+    }
+
+    return response.Success(c, "Two-factor authentication enabled", result)
+}
+
+func (h *AuthHandler) DisableTwoFactor(c *fiber.Ctx) error {
+    userID := c.Locals("userID").(string)
+
+    var req dto.DisableTwoFactorRequest
+    if err := c.BodyParser(&req); err != nil {
+        return response.BadRequest(c, "Invalid request body", err)
+    }
+
+    if err := req.Validate(); err != nil {
+        return response.ValidationError(c, err)
+    }
+
+    if err := h.authUseCase.DisableTwoFactor(c.UserContext(), userID, &req); err != nil {
+        if err == usecase.ErrTwoFactorRequired {
+            return response.Forbidden(c, "Two-factor authentication is required for your role", err)
+        }
+        if err == usecase.ErrTwoFactorNotEnabled {
+            return response.BadRequest(c, "Two-factor authentication is not enabled", err)
+        }
+        if err == usecase.ErrInvalidCredentials {
+            return response.BadRequest(c, "Password is incorrect", err)
+        }
+        if err == usecase.ErrInvalidTwoFactorCode {
+            return response.BadRequest(c, "Invalid two-factor code", err)
+        }
+        if err == usecase.ErrUserNotFound {
+            return response.NotFound(c, "User not found", err)
+        }
+        return response.InternalError(c, "Failed to disable two-factor authentication", err)
+    }
+
+    return response.Success(c, "Two-factor authentication disabled", nil)
+}
+
+func (h *AuthHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+    userID := c.Locals("userID").(string)
+
+    var req dto.TwoFactorCodeRequest
+    if err := c.BodyParser(&req); err != nil {
+        return response.BadRequest(c, "Invalid request body", err)
+    }
+
+    if err := req.Validate(); err != nil {
+        return response.ValidationError(c, err)
+    }
+
+    result, err := h.authUseCase.RegenerateRecoveryCodes(c.UserContext(), userID, &req)
+    if err != nil {
+        if err == usecase.ErrTwoFactorNotEnabled {
+            return response.BadRequest(c, "Two-factor authentication is not enabled", err)
+        }
+        if err == usecase.ErrInvalidTwoFactorCode {
+            return response.BadRequest(c, "Invalid two-factor code", err)
+        }
+        if err == usecase.ErrUserNotFound {
+            return response.NotFound(c, "User not found", err)
+        }
+        return response.InternalError(c, "Failed to regenerate recovery codes", err)
+    }
+
+    return response.Success(c, "Recovery codes regenerated", result)
+}
+
+func (h *AuthHandler) ResetTwoFactor(c *fiber.Ctx) error {
+    requesterID := c.Locals("userID").(string)
+    targetUserID := c.Params("id")
+
+    if err := h.authUseCase.ResetTwoFactor(c.UserContext(), requesterID, targetUserID); err != nil {
+        if err == usecase.ErrForbidden {
+            return response.Forbidden(c, "Only superadmin can reset two-factor authentication", err)
+        }
+        if err == usecase.ErrUserNotFound {
+            return response.NotFound(c, "User not found", err)
+        }
+        return response.InternalError(c, "Failed to reset two-factor authentication", err)
+    }
+
+    return response.Success(c, "Two-factor authentication reset successfully", nil)
+}
@@ -1,14 +1,19 @@
 package handler
 
 import (
+    "errors"
     "strconv"
     "time"
     "fmt"
+    "mime/multipart"
     "building-report-backend/internal/application/dto"
     "building-report-backend/internal/application/usecase"
+    "building-report-backend/internal/domain/entity"
+    "building-report-backend/internal/domain/repository"
     "building-report-backend/internal/interfaces/response"
     
     "github.com/gofiber/fiber/v2"
+    "gorm.io/gorm"
 )
 
 type BinaMargaHandler struct {
@@ -96,6 +101,7 @@ func (h *BinaMargaHandler) CreateReport(c *fiber.Ctx) error {
     // Parse optional fields
     req.CauseOfDamage = c.FormValue("cause_of_damage")
     req.Notes = c.FormValue("notes")
+    req.UploadSessionID = c.FormValue("upload_session_id")
 
     // Validate the request
     if err := req.Validate(); err != nil {
@@ -103,33 +109,32 @@ func (h *BinaMargaHandler) CreateReport(c *fiber.Ctx) error {
     }
 
     // Get user ID from context
-    // userID := c.Locals("userID").(string)
+    userID := c.Locals("userID").(string)
 
     // Parse multipart form for photos
-    form, err := c.MultipartForm()
-    if err != nil {
-        return response.BadRequest(c, "Failed to parse multipart form", err)
-    }
-
-    photos := form.File["photos"]
-    if len(photos) < 2 {
-        return response.BadRequest(c, "Minimum 2 photos required (before and damage detail)", nil)
-    }
-
-    // Validate photo files
-    for _, photo := range photos {
-        if photo.Size > 10*1024*1024 { // 10MB limit
-            return response.BadRequest(c, "Photo file size too large (max 10MB)", nil)
+    // Photos uploaded through an upload session take the place of multipart
+    // files; the use case checks how many the session holds.
+    var photos []*multipart.FileHeader
+    if req.UploadSessionID == "" {
+        form, err := c.MultipartForm()
+        if err != nil {
+            return response.BadRequest(c, "Failed to parse multipart form", err)
         }
-        
-        contentType := photo.Header.Get("Content-Type")
-        if contentType != "image/jpeg" && contentType != "image/png" && contentType != "image/jpg" {
-            return response.BadRequest(c, "Only JPEG and PNG images are allowed", nil)
+
+        photos = form.File["photos"]
+        if len(photos) < 2 {
+            return response.BadRequest(c, "Minimum 2 photos required (before and damage detail)", nil)
         }
     }
 
-    report, err := h.binaMargaUseCase.CreateReport(c.Context(), &req, photos)
+    report, err := h.binaMargaUseCase.CreateReport(c.UserContext(), &req, photos, userID)
     if err != nil {
+        if resp, ok := photoErrorResponse(c, err); ok {
+            return resp
+        }
+        if errors.Is(err, entity.ErrNoDataScope) {
+            return response.Forbidden(c, "No district or unit is assigned to your account", err)
+        }
         return response.InternalError(c, "Failed to create bina marga report", err)
     }
 
@@ -139,7 +144,7 @@ func (h *BinaMargaHandler) CreateReport(c *fiber.Ctx) error {
 func (h *BinaMargaHandler) GetReport(c *fiber.Ctx) error {
     id := c.Params("id")
    
-    report, err := h.binaMargaUseCase.GetReport(c.Context(), id)
+    report, err := h.binaMargaUseCase.GetReport(c.UserContext(), id)
     if err != nil {
         return response.NotFound(c, "Report not found", err)
     }
@@ -178,7 +183,29 @@ func (h *BinaMargaHandler) ListReports(c *fiber.Ctx) error {
         "end_date":            c.Query("end_date"),
     }
 
-    result, err := h.binaMargaUseCase.ListReports(c.Context(), page, limit, filters)
+    result, err := h.binaMargaUseCase.ListReports(c.UserContext(), page, limit, filters)
+    if err != nil {
+        return response.InternalError(c, "Failed to retrieve reports", err)
+    }
+
+    return response.Success(c, "Reports retrieved successfully", result)
+}
+
+// ListMyReports returns the reports created by the authenticated user
+func (h *BinaMargaHandler) ListMyReports(c *fiber.Ctx) error {
+    page, _ := strconv.Atoi(c.Query("page", "1"))
+    limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+    if page < 1 {
+        page = 1
+    }
+    if limit < 1 || limit > 100 {
+        limit = 10
+    }
+
+    userID := c.Locals("userID").(string)
+
+    result, err := h.binaMargaUseCase.ListMyReports(c.UserContext(), userID, page, limit)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve reports", err)
     }
@@ -190,7 +217,14 @@ func (h *BinaMargaHandler) ListByPriority(c *fiber.Ctx) error {
     page, _ := strconv.Atoi(c.Query("page", "1"))
     limit, _ := strconv.Atoi(c.Query("limit", "10"))
 
-    result, err := h.binaMargaUseCase.ListByPriority(c.Context(), page, limit)
+    if page < 1 {
+        page = 1
+    }
+    if limit < 1 || limit > 100 {
+        limit = 10
+    }
+
+    result, err := h.binaMargaUseCase.ListByPriority(c.UserContext(), page, limit)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve priority reports", err)
     }
@@ -211,8 +245,9 @@ func (h *BinaMargaHandler) UpdateReport(c *fiber.Ctx) error {
     }
 
     userID := c.Locals("userID").(string)
+    role := entity.UserRole(c.Locals("role").(string))
 
-    report, err := h.binaMargaUseCase.UpdateReport(c.Context(), idStr, &req, userID)
+    report, err := h.binaMargaUseCase.UpdateReport(c.UserContext(), idStr, &req, userID, role)
     if err != nil {
         if err == usecase.ErrUnauthorized {
             return response.Forbidden(c, "You don't have permission to update this report", err)
@@ -236,7 +271,24 @@ func (h *BinaMargaHandler) UpdateStatus(c *fiber.Ctx) error {
         return response.ValidationError(c, err)
     }
 
-    if err := h.binaMargaUseCase.UpdateStatus(c.Context(), id, &req); err != nil {
+    userID := c.Locals("userID").(string)
+
+    if err := h.binaMargaUseCase.UpdateStatus(c.UserContext(), id, &req, userID); err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return response.NotFound(c, "Report not found", err)
+        }
+        if errors.Is(err, usecase.ErrInvalidStatusTransition) {
+            return response.Conflict(c, "Invalid status transition", err)
+        }
+        if errors.Is(err, repository.ErrStatusChanged) {
+            return response.Conflict(c, "Report status was changed by someone else, reload and try again", err)
+        }
+        if errors.Is(err, usecase.ErrRepairPhotosNotAllowed) {
+            return response.BadRequest(c, "Photos cannot be attached with this status", err)
+        }
+        if resp, ok := photoErrorResponse(c, err); ok {
+            return resp
+        }
         return response.InternalError(c, "Failed to update report status", err)
     }
 
@@ -248,8 +300,9 @@ func (h *BinaMargaHandler) DeleteReport(c *fiber.Ctx) error {
     
 
     userID := c.Locals("userID").(string)
+    role := entity.UserRole(c.Locals("role").(string))
 
-    if err := h.binaMargaUseCase.DeleteReport(c.Context(), id, userID); err != nil {
+    if err := h.binaMargaUseCase.DeleteReport(c.UserContext(), id, userID, role); err != nil {
         if err == usecase.ErrUnauthorized {
             return response.Forbidden(c, "You don't have permission to delete this report", err)
         }
@@ -283,7 +336,7 @@ func (h *BinaMargaHandler) GetBinaMargaOverview(c *fiber.Ctx) error {
         queryRoadType = ""
     }
 
-    overview, err := h.binaMargaUseCase.GetBinaMargaOverview(c.Context(), queryRoadType)
+    overview, err := h.binaMargaUseCase.GetBinaMargaOverview(c.UserContext(), queryRoadType)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve bina marga overview", err)
     }
@@ -34,7 +34,7 @@ func (h *ExecutiveHandler) GetEkonomiOverview(c *fiber.Ctx) error {
         return response.BadRequest(c, "Tahun tidak valid", nil)
     }
 
-    result, err := h.executiveUseCase.GetEkonomiOverview(c.Context(), tahun)
+    result, err := h.executiveUseCase.GetEkonomiOverview(c.UserContext(), tahun)
     if err != nil {
         return response.InternalError(c, "Gagal mengambil data ekonomi", err)
     }
@@ -57,7 +57,7 @@ func (h *ExecutiveHandler) GetPopulationOverview(c *fiber.Ctx) error {
         return response.BadRequest(c, "Year tidak valid", nil)
     }
 
-    result, err := h.executiveUseCase.GetPopulationOverview(c.Context(), tahun)
+    result, err := h.executiveUseCase.GetPopulationOverview(c.UserContext(), tahun)
     if err != nil {
         return response.InternalError(c, "Gagal mengambil data demografi", err)
     }
@@ -80,7 +80,7 @@ func (h *ExecutiveHandler) GetPovertyOverview(c *fiber.Ctx) error {
         return response.BadRequest(c, "Year tidak valid", nil)
     }
 
-    result, err := h.executiveUseCase.GetPovertyOverview(c.Context(), tahun)
+    result, err := h.executiveUseCase.GetPovertyOverview(c.UserContext(), tahun)
     if err != nil {
         return response.InternalError(c, "Gagal mengambil data kemiskinan", err)
     }
@@ -104,7 +104,7 @@ func (h *ExecutiveHandler) GetEmploymentOverview(c *fiber.Ctx) error {
         return response.BadRequest(c, "Year tidak valid", nil)
     }
 
-    result, err := h.executiveUseCase.GetEmploymentOverview(c.Context(), tahun)
+    result, err := h.executiveUseCase.GetEmploymentOverview(c.UserContext(), tahun)
     if err != nil {
         return response.InternalError(c, "Gagal mengambil data ketenagakerjaan", err)
     }
@@ -127,7 +127,7 @@ func (h *ExecutiveHandler) GetEducationOverview(c *fiber.Ctx) error {
         return response.BadRequest(c, "Year tidak valid", nil)
     }
 
-    result, err := h.executiveUseCase.GetEducationOverview(c.Context(), tahun)
+    result, err := h.executiveUseCase.GetEducationOverview(c.UserContext(), tahun)
     if err != nil {
         return response.InternalError(c, "Gagal mengambil data pendidikan", err)
     }
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/application/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type HealthHandler struct {
+	healthUseCase *usecase.HealthUseCase
+}
+
+func NewHealthHandler(healthUseCase *usecase.HealthUseCase) *HealthHandler {
+	return &HealthHandler{
+		healthUseCase: healthUseCase,
+	}
+}
+
+// Live reports that the process is up and serving requests. It checks no
+// dependencies, so an orchestrator does not restart the app over an outage
+// elsewhere.
+func (h *HealthHandler) Live(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"status":  dto.HealthStatusOK,
+		"message": "Service is running",
+	})
+}
+
+// Ready reports whether the dependencies needed to serve traffic are
+// reachable, answering 503 when a critical one is not.
+func (h *HealthHandler) Ready(c *fiber.Ctx) error {
+	result := h.healthUseCase.Readiness(c.UserContext())
+
+	status := fiber.StatusOK
+	if result.Status == dto.HealthStatusDown {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(result)
+}
+
+func (h *HealthHandler) Version(c *fiber.Ctx) error {
+	return c.JSON(h.healthUseCase.BuildInfo(c.UserContext()))
+}
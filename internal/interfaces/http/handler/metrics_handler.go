@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"building-report-backend/internal/interfaces/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type MetricsHandler struct {
+	serve fiber.Handler
+	token string
+}
+
+// NewMetricsHandler serves registry on /metrics. A non-empty token must be sent
+// as a bearer token, for deployments where the endpoint is reachable publicly.
+func NewMetricsHandler(registry *prometheus.Registry, token string) *MetricsHandler {
+	return &MetricsHandler{
+		serve: adaptor.HTTPHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{})),
+		token: token,
+	}
+}
+
+func (h *MetricsHandler) Serve(c *fiber.Ctx) error {
+	if h.token != "" {
+		token := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+			return response.Unauthorized(c, "Invalid metrics token", nil)
+		}
+	}
+
+	return h.serve(c)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/application/usecase"
+	"building-report-backend/internal/infrastructure/oidc"
+	"building-report-backend/internal/interfaces/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OIDCHandler struct {
+	oidcUseCase *usecase.OIDCUseCase
+}
+
+func NewOIDCHandler(oidcUseCase *usecase.OIDCUseCase) *OIDCHandler {
+	return &OIDCHandler{
+		oidcUseCase: oidcUseCase,
+	}
+}
+
+// Login redirects the browser to the identity provider. With ?redirect=false the
+// authorization URL is returned as JSON for clients that navigate themselves.
+func (h *OIDCHandler) Login(c *fiber.Ctx) error {
+	result, err := h.oidcUseCase.BeginLogin(c.UserContext())
+	if err != nil {
+		return response.InternalError(c, "Failed to start SSO login", err)
+	}
+
+	if !c.QueryBool("redirect", true) {
+		return response.Success(c, "SSO login started", result)
+	}
+	return c.Redirect(result.AuthorizationURL, fiber.StatusFound)
+}
+
+// Callback accepts the provider's redirect (GET with query parameters) or a
+// client posting the code and state it received.
+func (h *OIDCHandler) Callback(c *fiber.Ctx) error {
+	if idpErr := c.Query("error"); idpErr != "" {
+		return response.Unauthorized(c, "SSO login was rejected",
+			fmt.Errorf("%s: %s", idpErr, c.Query("error_description")))
+	}
+
+	var req dto.OIDCCallbackRequest
+	if c.Method() == fiber.MethodPost {
+		if err := c.BodyParser(&req); err != nil {
+			return response.BadRequest(c, "Invalid request body", err)
+		}
+	} else if err := c.QueryParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid query parameters", err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return response.ValidationError(c, err)
+	}
+
+	result, challenge, err := h.oidcUseCase.Callback(c.UserContext(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrOIDCState), errors.Is(err, usecase.ErrOIDCNonce):
+			return response.BadRequest(c, "Invalid or expired SSO login", err)
+		case errors.Is(err, oidc.ErrExchange), errors.Is(err, oidc.ErrInvalidToken):
+			return response.Unauthorized(c, "SSO login failed", err)
+		case errors.Is(err, usecase.ErrOIDCMissingEmail):
+			return response.BadRequest(c, "SSO account has no email address", err)
+		case errors.Is(err, usecase.ErrUserExists):
+			return response.Conflict(c, "A local account with this email already exists", err)
+		case errors.Is(err, usecase.ErrInactiveUser):
+			return response.Forbidden(c, "User account is inactive", err)
+		}
+		return response.InternalError(c, "Failed to complete SSO login", err)
+	}
+
+	if challenge != nil {
+		return response.Success(c, "Two-factor authentication required", challenge)
+	}
+
+	return response.Success(c, "Login successful", result)
+}
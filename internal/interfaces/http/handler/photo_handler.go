@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"errors"
+	"mime/multipart"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/application/usecase"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/interfaces/response"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type PhotoHandler struct {
+	photoUseCase *usecase.PhotoUseCase
+}
+
+func NewPhotoHandler(photoUseCase *usecase.PhotoUseCase) *PhotoHandler {
+	return &PhotoHandler{
+		photoUseCase: photoUseCase,
+	}
+}
+
+// ListPhotos returns the handler for GET /{sector}/:id/photos
+func (h *PhotoHandler) ListPhotos(sector entity.Sector) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		photos, err := h.photoUseCase.ListPhotos(c.UserContext(), sector, c.Params("id"))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return response.NotFound(c, "Report not found", err)
+			}
+			return response.InternalError(c, "Failed to retrieve photos", err)
+		}
+
+		return response.Success(c, "Photos retrieved successfully", photos)
+	}
+}
+
+// AddPhotos returns the handler for POST /{sector}/:id/photos, which takes
+// multipart "photos" files or an upload_session_id.
+func (h *PhotoHandler) AddPhotos(sector entity.Sector) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req dto.AddPhotosRequest
+		if err := c.BodyParser(&req); err != nil {
+			return response.BadRequest(c, "Invalid request body", err)
+		}
+		if err := req.Validate(); err != nil {
+			return response.ValidationError(c, err)
+		}
+
+		var files []*multipart.FileHeader
+		if req.UploadSessionID == "" {
+			form, err := c.MultipartForm()
+			if err != nil {
+				return response.BadRequest(c, "Failed to parse multipart form", err)
+			}
+			files = form.File["photos"]
+		}
+
+		userID := c.Locals("userID").(string)
+		role := entity.UserRole(c.Locals("role").(string))
+
+		photos, err := h.photoUseCase.AddPhotos(c.UserContext(), sector, c.Params("id"), &req, files, userID, role)
+		if err != nil {
+			if resp, ok := photoManagementErrorResponse(c, err); ok {
+				return resp
+			}
+			if resp, ok := photoErrorResponse(c, err); ok {
+				return resp
+			}
+			return response.InternalError(c, "Failed to add photos", err)
+		}
+
+		return response.Created(c, "Photos added successfully", photos)
+	}
+}
+
+// UpdatePhoto returns the handler for PATCH /{sector}/:id/photos/:photoId
+func (h *PhotoHandler) UpdatePhoto(sector entity.Sector) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req dto.UpdatePhotoRequest
+		if err := c.BodyParser(&req); err != nil {
+			return response.BadRequest(c, "Invalid request body", err)
+		}
+		if err := req.Validate(); err != nil {
+			return response.ValidationError(c, err)
+		}
+
+		userID := c.Locals("userID").(string)
+		role := entity.UserRole(c.Locals("role").(string))
+
+		photo, err := h.photoUseCase.UpdatePhoto(c.UserContext(), sector, c.Params("id"), c.Params("photoId"), &req, userID, role)
+		if err != nil {
+			if resp, ok := photoManagementErrorResponse(c, err); ok {
+				return resp
+			}
+			return response.InternalError(c, "Failed to update photo", err)
+		}
+
+		return response.Success(c, "Photo updated successfully", photo)
+	}
+}
+
+// DeletePhoto returns the handler for DELETE /{sector}/:id/photos/:photoId
+func (h *PhotoHandler) DeletePhoto(sector entity.Sector) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID := c.Locals("userID").(string)
+		role := entity.UserRole(c.Locals("role").(string))
+
+		if err := h.photoUseCase.DeletePhoto(c.UserContext(), sector, c.Params("id"), c.Params("photoId"), userID, role); err != nil {
+			if resp, ok := photoManagementErrorResponse(c, err); ok {
+				return resp
+			}
+			return response.InternalError(c, "Failed to delete photo", err)
+		}
+
+		return response.Success(c, "Photo deleted successfully", nil)
+	}
+}
+
+// ReorderPhotos returns the handler for PUT /{sector}/:id/photos/order
+func (h *PhotoHandler) ReorderPhotos(sector entity.Sector) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req dto.ReorderPhotosRequest
+		if err := c.BodyParser(&req); err != nil {
+			return response.BadRequest(c, "Invalid request body", err)
+		}
+		if err := req.Validate(); err != nil {
+			return response.ValidationError(c, err)
+		}
+
+		userID := c.Locals("userID").(string)
+		role := entity.UserRole(c.Locals("role").(string))
+
+		photos, err := h.photoUseCase.ReorderPhotos(c.UserContext(), sector, c.Params("id"), &req, userID, role)
+		if err != nil {
+			if resp, ok := photoManagementErrorResponse(c, err); ok {
+				return resp
+			}
+			return response.InternalError(c, "Failed to reorder photos", err)
+		}
+
+		return response.Success(c, "Photos reordered successfully", photos)
+	}
+}
+
+// GetPhoto returns the handler for GET /{sector}/:id/photos/:photoId, which
+// streams a photo, or with ?variant=medium|thumbnail one of its variants.
+func (h *PhotoHandler) GetPhoto(sector entity.Sector) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		variant := c.Query("variant")
+		switch variant {
+		case "", usecase.PhotoVariantMedium, usecase.PhotoVariantThumbnail:
+		default:
+			return response.BadRequest(c, "Invalid photo variant", nil)
+		}
+
+		photo, err := h.photoUseCase.OpenPhoto(c.UserContext(), sector, c.Params("id"), c.Params("photoId"), variant)
+		if err != nil {
+			if errors.Is(err, usecase.ErrPhotoNotFound) {
+				return response.NotFound(c, "Photo not found", err)
+			}
+			return response.InternalError(c, "Failed to retrieve photo", err)
+		}
+
+		c.Set(fiber.HeaderContentType, photo.ContentType)
+		// Photos show people's homes and land: browsers may keep them, shared
+		// caches may not.
+		c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+		return c.SendStream(photo.Body, int(photo.Size))
+	}
+}
+
+// photoManagementErrorResponse answers the errors shared by the photo
+// management endpoints. ok is false for any other error.
+func photoManagementErrorResponse(c *fiber.Ctx, err error) (resp error, ok bool) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return response.NotFound(c, "Report not found", err), true
+	case errors.Is(err, usecase.ErrPhotoNotFound):
+		return response.NotFound(c, "Photo not found", err), true
+	case errors.Is(err, usecase.ErrUnauthorized):
+		return response.Forbidden(c, "You don't have permission to change the photos of this report", err), true
+	case errors.Is(err, usecase.ErrPhotoOrder):
+		return response.BadRequest(c, "Invalid photo order", err), true
+	}
+	return nil, false
+}
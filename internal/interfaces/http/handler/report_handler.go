@@ -3,8 +3,11 @@ package handler
 import (
 	"building-report-backend/internal/application/dto"
 	"building-report-backend/internal/application/usecase"
+	"building-report-backend/internal/domain/entity"
 	"building-report-backend/internal/interfaces/response"
+	"errors"
 	"fmt"
+	"mime/multipart"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -33,24 +36,35 @@ func (h *ReportHandler) CreateReport(c *fiber.Ctx) error {
     }
 
     
-    // userID := c.Locals("userID").(string)
+    userID := c.Locals("userID").(string)
 
 
-    form, err := c.MultipartForm()
-    if err != nil {
-        return response.BadRequest(c, "Failed to parse multipart form", err)
-    }
+    // Photos uploaded through an upload session take the place of multipart
+    // files; the use case checks how many the session holds.
+    var photos []*multipart.FileHeader
+    if req.UploadSessionID == "" {
+        form, err := c.MultipartForm()
+        if err != nil {
+            return response.BadRequest(c, "Failed to parse multipart form", err)
+        }
 
-    photos := form.File["photos"]
+        photos = form.File["photos"]
 
-    // Photos are required only for rehabilitation reports, not for new construction
-    isPembangunanBaru := req.ReportStatus == "PEMBANGUNAN_BARU"
-    if !isPembangunanBaru && len(photos) < 2 {
-        return response.BadRequest(c, "Minimum 2 photos required for rehabilitation reports", nil)
+        // Photos are required only for rehabilitation reports, not for new construction
+        isPembangunanBaru := req.ReportStatus == "PEMBANGUNAN_BARU"
+        if !isPembangunanBaru && len(photos) < 2 {
+            return response.BadRequest(c, "Minimum 2 photos required for rehabilitation reports", nil)
+        }
     }
 
-    report, err := h.reportUseCase.CreateReport(c.Context(), &req, photos)
+    report, err := h.reportUseCase.CreateReport(c.UserContext(), &req, photos, userID)
     if err != nil {
+        if resp, ok := photoErrorResponse(c, err); ok {
+            return resp
+        }
+        if errors.Is(err, entity.ErrNoDataScope) {
+            return response.Forbidden(c, "No district or unit is assigned to your account", err)
+        }
         return response.InternalError(c, "Failed to create report", err)
     }
 
@@ -61,7 +75,7 @@ func (h *ReportHandler) GetReport(c *fiber.Ctx) error {
     id := c.Params("id")
   
 
-    report, err := h.reportUseCase.GetReport(c.Context(), id)
+    report, err := h.reportUseCase.GetReport(c.UserContext(), id)
     if err != nil {
         return response.NotFound(c, "Report not found", err)
     }
@@ -80,7 +94,29 @@ func (h *ReportHandler) ListReports(c *fiber.Ctx) error {
         "report_status": c.Query("report_status"),
     }
 
-    result, err := h.reportUseCase.ListReports(c.Context(), page, limit, filters)
+    result, err := h.reportUseCase.ListReports(c.UserContext(), page, limit, filters)
+    if err != nil {
+        return response.InternalError(c, "Failed to retrieve reports", err)
+    }
+
+    return response.Success(c, "Reports retrieved successfully", result)
+}
+
+// ListMyReports returns the reports created by the authenticated user
+func (h *ReportHandler) ListMyReports(c *fiber.Ctx) error {
+    page, _ := strconv.Atoi(c.Query("page", "1"))
+    limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+    if page < 1 {
+        page = 1
+    }
+    if limit < 1 || limit > 100 {
+        limit = 10
+    }
+
+    userID := c.Locals("userID").(string)
+
+    result, err := h.reportUseCase.ListMyReports(c.UserContext(), userID, page, limit)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve reports", err)
     }
@@ -98,9 +134,13 @@ func (h *ReportHandler) UpdateReport(c *fiber.Ctx) error {
     }
 
     userID := c.Locals("userID").(string)
+    role := entity.UserRole(c.Locals("role").(string))
 
-    report, err := h.reportUseCase.UpdateReport(c.Context(), id, &req, userID)
+    report, err := h.reportUseCase.UpdateReport(c.UserContext(), id, &req, userID, role)
     if err != nil {
+        if err == usecase.ErrUnauthorized {
+            return response.Forbidden(c, "You don't have permission to update this report", err)
+        }
         return response.InternalError(c, "Failed to update report", err)
     }
 
@@ -112,8 +152,12 @@ func (h *ReportHandler) DeleteReport(c *fiber.Ctx) error {
     
 
     userID := c.Locals("userID").(string)
+    role := entity.UserRole(c.Locals("role").(string))
 
-    if err := h.reportUseCase.DeleteReport(c.Context(), id, userID); err != nil {
+    if err := h.reportUseCase.DeleteReport(c.UserContext(), id, userID, role); err != nil {
+        if err == usecase.ErrUnauthorized {
+            return response.Forbidden(c, "You don't have permission to delete this report", err)
+        }
         return response.InternalError(c, "Failed to delete report", err)
     }
 
@@ -145,7 +189,7 @@ func (h *ReportHandler) GetTataBangunanOverview(c *fiber.Ctx) error {
         queryBuildingType = ""
     }
 
-    overview, err := h.reportUseCase.GetTataBangunanOverview(c.Context(), queryBuildingType)
+    overview, err := h.reportUseCase.GetTataBangunanOverview(c.UserContext(), queryBuildingType)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve tata bangunan overview", err)
     }
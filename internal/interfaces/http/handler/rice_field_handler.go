@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/application/usecase"
+	"building-report-backend/internal/interfaces/response"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type RiceFieldHandler struct {
+	riceFieldUseCase *usecase.RiceFieldUseCase
+}
+
+func NewRiceFieldHandler(riceFieldUseCase *usecase.RiceFieldUseCase) *RiceFieldHandler {
+	return &RiceFieldHandler{
+		riceFieldUseCase: riceFieldUseCase,
+	}
+}
+
+func (h *RiceFieldHandler) CreateRiceField(c *fiber.Ctx) error {
+	var req dto.CreateRiceFieldRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return response.ValidationError(c, err)
+	}
+
+	riceField, err := h.riceFieldUseCase.CreateRiceField(c.UserContext(), &req)
+	if err != nil {
+		return response.InternalError(c, "Failed to create rice field", err)
+	}
+
+	return response.Created(c, "Rice field created successfully", riceField)
+}
+
+func (h *RiceFieldHandler) GetRiceField(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	riceField, err := h.riceFieldUseCase.GetRiceField(c.UserContext(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NotFound(c, "Rice field not found", err)
+		}
+		return response.InternalError(c, "Failed to retrieve rice field", err)
+	}
+
+	return response.Success(c, "Rice field retrieved successfully", riceField)
+}
+
+func (h *RiceFieldHandler) ListRiceFields(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filters := map[string]interface{}{
+		"district": c.Query("district"),
+	}
+
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		startDate, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			return response.BadRequest(c, "Invalid start_date format, use YYYY-MM-DD", err)
+		}
+		filters["start_date"] = startDate
+	}
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		endDate, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			return response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD", err)
+		}
+		filters["end_date"] = endDate
+	}
+
+	result, err := h.riceFieldUseCase.ListRiceFields(c.UserContext(), page, limit, filters)
+	if err != nil {
+		return response.InternalError(c, "Failed to retrieve rice fields", err)
+	}
+
+	return response.Success(c, "Rice fields retrieved successfully", result)
+}
+
+func (h *RiceFieldHandler) UpdateRiceField(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req dto.UpdateRiceFieldRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return response.ValidationError(c, err)
+	}
+
+	riceField, err := h.riceFieldUseCase.UpdateRiceField(c.UserContext(), id, &req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NotFound(c, "Rice field not found", err)
+		}
+		return response.InternalError(c, "Failed to update rice field", err)
+	}
+
+	return response.Success(c, "Rice field updated successfully", riceField)
+}
+
+func (h *RiceFieldHandler) DeleteRiceField(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.riceFieldUseCase.DeleteRiceField(c.UserContext(), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NotFound(c, "Rice field not found", err)
+		}
+		return response.InternalError(c, "Failed to delete rice field", err)
+	}
+
+	return response.Success(c, "Rice field deleted successfully", nil)
+}
+
+func (h *RiceFieldHandler) GetStatistics(c *fiber.Ctx) error {
+	startDateStr := c.Query("start_date")
+	endDateStr := c.Query("end_date")
+
+	if startDateStr == "" || endDateStr == "" {
+		return response.BadRequest(c, "start_date and end_date parameters are required", nil)
+	}
+
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		return response.BadRequest(c, "Invalid start_date format, use YYYY-MM-DD", err)
+	}
+
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		return response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD", err)
+	}
+
+	stats, err := h.riceFieldUseCase.GetStatistics(c.UserContext(), startDate, endDate)
+	if err != nil {
+		return response.InternalError(c, "Failed to retrieve rice field statistics", err)
+	}
+
+	return response.Success(c, "Rice field statistics retrieved successfully", stats)
+}
+
+func (h *RiceFieldHandler) GetDistributionByDistrict(c *fiber.Ctx) error {
+	startDateStr := c.Query("start_date")
+	endDateStr := c.Query("end_date")
+
+	if startDateStr == "" || endDateStr == "" {
+		return response.BadRequest(c, "start_date and end_date parameters are required", nil)
+	}
+
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		return response.BadRequest(c, "Invalid start_date format, use YYYY-MM-DD", err)
+	}
+
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		return response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD", err)
+	}
+
+	distribution, err := h.riceFieldUseCase.GetDistributionByDistrict(c.UserContext(), startDate, endDate)
+	if err != nil {
+		return response.InternalError(c, "Failed to retrieve rice field distribution", err)
+	}
+
+	return response.Success(c, "Rice field distribution retrieved successfully", distribution)
+}
+
+// GetTrends returns yearly totals; years defaults to the last five years when not given
+func (h *RiceFieldHandler) GetTrends(c *fiber.Ctx) error {
+	district := c.Query("district")
+
+	var years []int
+	if yearsStr := c.Query("years"); yearsStr != "" {
+		for _, part := range strings.Split(yearsStr, ",") {
+			year, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || year < 1900 || year > 2100 {
+				return response.BadRequest(c, "Invalid years parameter, use comma separated years (e.g. 2021,2022,2023)", err)
+			}
+			years = append(years, year)
+		}
+	} else {
+		currentYear := time.Now().Year()
+		for year := currentYear - 4; year <= currentYear; year++ {
+			years = append(years, year)
+		}
+	}
+
+	trends, err := h.riceFieldUseCase.GetTrends(c.UserContext(), district, years)
+	if err != nil {
+		return response.InternalError(c, "Failed to retrieve rice field trends", err)
+	}
+
+	return response.Success(c, "Rice field trends retrieved successfully", trends)
+}
@@ -1,15 +1,20 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"mime/multipart"
 	"strconv"
 	"time"
 
 	"building-report-backend/internal/application/dto"
 	"building-report-backend/internal/application/usecase"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
 	"building-report-backend/internal/interfaces/response"
 
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
 type SpatialPlanningHandler struct {
@@ -36,7 +41,9 @@ func (h *SpatialPlanningHandler) CreateReport(c *fiber.Ctx) error {
     req.EnvironmentalImpact = c.FormValue("environmental_impact")
     req.UrgencyLevel = c.FormValue("urgency_level")
     req.Address = c.FormValue("address")
+    req.District = c.FormValue("district")
     req.Notes = c.FormValue("notes")
+    req.UploadSessionID = c.FormValue("upload_session_id")
     
     
     reportDateTimeStr := c.FormValue("report_datetime")
@@ -65,21 +72,32 @@ func (h *SpatialPlanningHandler) CreateReport(c *fiber.Ctx) error {
     }
 
     
-    // userID := c.Locals("userID").(string)
+    userID := c.Locals("userID").(string)
 
     
-    form, err := c.MultipartForm()
-    if err != nil {
-        return response.BadRequest(c, "Failed to parse multipart form", err)
-    }
+    // Photos uploaded through an upload session take the place of multipart
+    // files; the use case checks how many the session holds.
+    var photos []*multipart.FileHeader
+    if req.UploadSessionID == "" {
+        form, err := c.MultipartForm()
+        if err != nil {
+            return response.BadRequest(c, "Failed to parse multipart form", err)
+        }
 
-    photos := form.File["photos"]
-    if len(photos) < 1 {
-        return response.BadRequest(c, "Minimum 1 photo required", nil)
+        photos = form.File["photos"]
+        if len(photos) < 1 {
+            return response.BadRequest(c, "Minimum 1 photo required", nil)
+        }
     }
 
-    report, err := h.spatialUseCase.CreateReport(c.Context(), &req, photos)
+    report, err := h.spatialUseCase.CreateReport(c.UserContext(), &req, photos, userID)
     if err != nil {
+        if resp, ok := photoErrorResponse(c, err); ok {
+            return resp
+        }
+        if errors.Is(err, entity.ErrNoDataScope) {
+            return response.Forbidden(c, "No district or unit is assigned to your account", err)
+        }
         return response.InternalError(c, "Failed to create spatial planning report", err)
     }
 
@@ -89,7 +107,7 @@ func (h *SpatialPlanningHandler) CreateReport(c *fiber.Ctx) error {
 func (h *SpatialPlanningHandler) GetReport(c *fiber.Ctx) error {
     id := c.Params("id")
 
-    report, err := h.spatialUseCase.GetReport(c.Context(), id)
+    report, err := h.spatialUseCase.GetReport(c.UserContext(), id)
     if err != nil {
         return response.NotFound(c, "Report not found", err)
     }
@@ -112,7 +130,29 @@ func (h *SpatialPlanningHandler) ListReports(c *fiber.Ctx) error {
         "end_date":        c.Query("end_date"),
     }
 
-    result, err := h.spatialUseCase.ListReports(c.Context(), page, limit, filters)
+    result, err := h.spatialUseCase.ListReports(c.UserContext(), page, limit, filters)
+    if err != nil {
+        return response.InternalError(c, "Failed to retrieve reports", err)
+    }
+
+    return response.Success(c, "Reports retrieved successfully", result)
+}
+
+// ListMyReports returns the reports created by the authenticated user
+func (h *SpatialPlanningHandler) ListMyReports(c *fiber.Ctx) error {
+    page, _ := strconv.Atoi(c.Query("page", "1"))
+    limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+    if page < 1 {
+        page = 1
+    }
+    if limit < 1 || limit > 100 {
+        limit = 10
+    }
+
+    userID := c.Locals("userID").(string)
+
+    result, err := h.spatialUseCase.ListMyReports(c.UserContext(), userID, page, limit)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve reports", err)
     }
@@ -120,6 +160,25 @@ func (h *SpatialPlanningHandler) ListReports(c *fiber.Ctx) error {
     return response.Success(c, "Reports retrieved successfully", result)
 }
 
+func (h *SpatialPlanningHandler) ListByPriority(c *fiber.Ctx) error {
+    page, _ := strconv.Atoi(c.Query("page", "1"))
+    limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+    if page < 1 {
+        page = 1
+    }
+    if limit < 1 || limit > 100 {
+        limit = 10
+    }
+
+    result, err := h.spatialUseCase.ListByPriority(c.UserContext(), page, limit)
+    if err != nil {
+        return response.InternalError(c, "Failed to retrieve priority reports", err)
+    }
+
+    return response.Success(c, "Priority reports retrieved successfully", result)
+}
+
 func (h *SpatialPlanningHandler) UpdateReport(c *fiber.Ctx) error {
     id:= c.Params("id")
    
@@ -133,8 +192,9 @@ func (h *SpatialPlanningHandler) UpdateReport(c *fiber.Ctx) error {
     }
 
     userID := c.Locals("userID").(string)
+    role := entity.UserRole(c.Locals("role").(string))
 
-    report, err := h.spatialUseCase.UpdateReport(c.Context(), id, &req, userID)
+    report, err := h.spatialUseCase.UpdateReport(c.UserContext(), id, &req, userID, role)
     if err != nil {
         if err == usecase.ErrUnauthorized {
             return response.Forbidden(c, "You don't have permission to update this report", err)
@@ -157,7 +217,24 @@ func (h *SpatialPlanningHandler) UpdateStatus(c *fiber.Ctx) error {
         return response.ValidationError(c, err)
     }
 
-    if err := h.spatialUseCase.UpdateStatus(c.Context(), id, &req); err != nil {
+    userID := c.Locals("userID").(string)
+
+    if err := h.spatialUseCase.UpdateStatus(c.UserContext(), id, &req, userID); err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return response.NotFound(c, "Report not found", err)
+        }
+        if errors.Is(err, usecase.ErrInvalidStatusTransition) {
+            return response.Conflict(c, "Invalid status transition", err)
+        }
+        if errors.Is(err, repository.ErrStatusChanged) {
+            return response.Conflict(c, "Report status was changed by someone else, reload and try again", err)
+        }
+        if errors.Is(err, usecase.ErrRepairPhotosNotAllowed) {
+            return response.BadRequest(c, "Photos cannot be attached with this status", err)
+        }
+        if resp, ok := photoErrorResponse(c, err); ok {
+            return resp
+        }
         return response.InternalError(c, "Failed to update report status", err)
     }
 
@@ -168,8 +245,9 @@ func (h *SpatialPlanningHandler) DeleteReport(c *fiber.Ctx) error {
     id := c.Params("id")
    
     userID := c.Locals("userID").(string)
+    role := entity.UserRole(c.Locals("role").(string))
 
-    if err := h.spatialUseCase.DeleteReport(c.Context(), id, userID); err != nil {
+    if err := h.spatialUseCase.DeleteReport(c.UserContext(), id, userID, role); err != nil {
         if err == usecase.ErrUnauthorized {
             return response.Forbidden(c, "You don't have permission to delete this report", err)
         }
@@ -180,7 +258,7 @@ func (h *SpatialPlanningHandler) DeleteReport(c *fiber.Ctx) error {
 }
 
 func (h *SpatialPlanningHandler) GetStatistics(c *fiber.Ctx) error {
-    stats, err := h.spatialUseCase.GetStatistics(c.Context())
+    stats, err := h.spatialUseCase.GetStatistics(c.UserContext())
     if err != nil {
         return response.InternalError(c, "Failed to retrieve statistics", err)
     }
@@ -218,7 +296,7 @@ func (h *SpatialPlanningHandler) GetTataRuangOverview(c *fiber.Ctx) error {
         queryAreaCategory = ""
     }
 
-    overview, err := h.spatialUseCase.GetTataRuangOverview(c.Context(), queryAreaCategory)
+    overview, err := h.spatialUseCase.GetTataRuangOverview(c.UserContext(), queryAreaCategory)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve tata ruang overview", err)
     }
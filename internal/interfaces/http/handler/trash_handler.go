@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"errors"
+
+	"building-report-backend/internal/application/usecase"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/interfaces/response"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type TrashHandler struct {
+	trashUseCase *usecase.TrashUseCase
+}
+
+func NewTrashHandler(trashUseCase *usecase.TrashUseCase) *TrashHandler {
+	return &TrashHandler{
+		trashUseCase: trashUseCase,
+	}
+}
+
+func (h *TrashHandler) ListTrash(c *fiber.Ctx) error {
+	sector := entity.Sector(c.Query("sector", string(entity.SectorReports)))
+	page, limit := parseAuditPagination(c)
+
+	result, err := h.trashUseCase.ListTrash(c.UserContext(), sector, page, limit)
+	if err != nil {
+		if errors.Is(err, usecase.ErrUnknownTrashSector) {
+			return response.BadRequest(c, "Invalid sector", err)
+		}
+		return response.InternalError(c, "Failed to retrieve deleted reports", err)
+	}
+
+	return response.Success(c, "Deleted reports retrieved successfully", result)
+}
+
+func (h *TrashHandler) Restore(c *fiber.Ctx) error {
+	sector := entity.Sector(c.Params("sector"))
+	id := c.Params("id")
+	userID := c.Locals("userID").(string)
+
+	if err := h.trashUseCase.Restore(c.UserContext(), sector, id, userID); err != nil {
+		if errors.Is(err, usecase.ErrUnknownTrashSector) {
+			return response.BadRequest(c, "Invalid sector", err)
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NotFound(c, "Deleted report not found", err)
+		}
+		return response.InternalError(c, "Failed to restore report", err)
+	}
+
+	return response.Success(c, "Report restored successfully", nil)
+}
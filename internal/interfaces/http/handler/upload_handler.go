@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"errors"
+
+	"building-report-backend/internal/application/dto"
+	"building-report-backend/internal/application/usecase"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/interfaces/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type UploadHandler struct {
+	uploadUseCase *usecase.UploadUseCase
+}
+
+func NewUploadHandler(uploadUseCase *usecase.UploadUseCase) *UploadHandler {
+	return &UploadHandler{
+		uploadUseCase: uploadUseCase,
+	}
+}
+
+// CreateSession returns the handler for POST /{sector}/uploads
+func (h *UploadHandler) CreateSession(sector entity.Sector) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req dto.CreateUploadSessionRequest
+		if err := c.BodyParser(&req); err != nil {
+			return response.BadRequest(c, "Invalid request body", err)
+		}
+		if err := req.Validate(); err != nil {
+			return response.ValidationError(c, err)
+		}
+
+		userID := c.Locals("userID").(string)
+
+		session, err := h.uploadUseCase.CreateSession(c.UserContext(), sector, &req, userID)
+		if err != nil {
+			return response.InternalError(c, "Failed to create upload session", err)
+		}
+
+		return response.Created(c, "Upload session created successfully", session)
+	}
+}
+
+// Confirm returns the handler for POST /{sector}/uploads/:uploadId/confirm
+func (h *UploadHandler) Confirm(sector entity.Sector) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID := c.Locals("userID").(string)
+
+		session, err := h.uploadUseCase.ConfirmSession(c.UserContext(), sector, c.Params("uploadId"), userID)
+		if err != nil {
+			if resp, ok := photoErrorResponse(c, err); ok {
+				return resp
+			}
+			return response.InternalError(c, "Failed to confirm upload session", err)
+		}
+
+		return response.Success(c, "Upload session confirmed successfully", session)
+	}
+}
+
+// photoErrorResponse answers the errors a report's photos can cause, whether
+// they come with the request or from an upload session. ok is false for any
+// other error.
+func photoErrorResponse(c *fiber.Ctx, err error) (resp error, ok bool) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidPhoto):
+		return response.BadRequest(c, "Invalid photo", err), true
+	case errors.Is(err, usecase.ErrUploadIncomplete):
+		return response.BadRequest(c, "Photo upload incomplete", err), true
+	case errors.Is(err, usecase.ErrUploadMismatch):
+		return response.BadRequest(c, "Uploaded photo does not match the announced file", err), true
+	case errors.Is(err, usecase.ErrPhotoCount):
+		return response.BadRequest(c, "Wrong number of photos", err), true
+	case errors.Is(err, usecase.ErrUploadSessionExpired):
+		return response.BadRequest(c, "Upload session expired", err), true
+	case errors.Is(err, usecase.ErrUploadSessionNotFound):
+		return response.NotFound(c, "Upload session not found", err), true
+	case errors.Is(err, usecase.ErrUploadSessionUsed):
+		return response.Conflict(c, "Upload session already used", err), true
+	}
+	return nil, false
+}
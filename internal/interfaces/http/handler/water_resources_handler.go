@@ -1,15 +1,20 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"mime/multipart"
 	"strconv"
 	"time"
 
 	"building-report-backend/internal/application/dto"
 	"building-report-backend/internal/application/usecase"
+	"building-report-backend/internal/domain/entity"
+	"building-report-backend/internal/domain/repository"
 	"building-report-backend/internal/interfaces/response"
 
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
 type WaterResourcesHandler struct {
@@ -30,11 +35,13 @@ func (h *WaterResourcesHandler) CreateReport(c *fiber.Ctx) error {
     req.InstitutionUnit = c.FormValue("institution_unit")
     req.PhoneNumber = c.FormValue("phone_number")
     req.IrrigationAreaName = c.FormValue("irrigation_area_name")
+    req.District = c.FormValue("district")
     req.IrrigationType = c.FormValue("irrigation_type")
     req.DamageType = c.FormValue("damage_type")
     req.DamageLevel = c.FormValue("damage_level")
     req.UrgencyCategory = c.FormValue("urgency_category")
     req.Notes = c.FormValue("notes")
+    req.UploadSessionID = c.FormValue("upload_session_id")
     
     
     reportDateTimeStr := c.FormValue("report_datetime")
@@ -83,21 +90,32 @@ func (h *WaterResourcesHandler) CreateReport(c *fiber.Ctx) error {
     }
 
     
-    // userID := c.Locals("userID").(string)
+    userID := c.Locals("userID").(string)
 
     
-    form, err := c.MultipartForm()
-    if err != nil {
-        return response.BadRequest(c, "Failed to parse multipart form", err)
-    }
+    // Photos uploaded through an upload session take the place of multipart
+    // files; the use case checks how many the session holds.
+    var photos []*multipart.FileHeader
+    if req.UploadSessionID == "" {
+        form, err := c.MultipartForm()
+        if err != nil {
+            return response.BadRequest(c, "Failed to parse multipart form", err)
+        }
 
-    photos := form.File["photos"]
-    if len(photos) < 2 {
-        return response.BadRequest(c, "Minimum 2 photos required", nil)
+        photos = form.File["photos"]
+        if len(photos) < 2 {
+            return response.BadRequest(c, "Minimum 2 photos required", nil)
+        }
     }
 
-    report, err := h.waterUseCase.CreateReport(c.Context(), &req, photos)
+    report, err := h.waterUseCase.CreateReport(c.UserContext(), &req, photos, userID)
     if err != nil {
+        if resp, ok := photoErrorResponse(c, err); ok {
+            return resp
+        }
+        if errors.Is(err, entity.ErrNoDataScope) {
+            return response.Forbidden(c, "No district or unit is assigned to your account", err)
+        }
         return response.InternalError(c, "Failed to create water resources report", err)
     }
 
@@ -106,7 +124,7 @@ func (h *WaterResourcesHandler) CreateReport(c *fiber.Ctx) error {
 
 func (h *WaterResourcesHandler) GetReport(c *fiber.Ctx) error {
     id := c.Params("id")
-    report, err := h.waterUseCase.GetReport(c.Context(), id)
+    report, err := h.waterUseCase.GetReport(c.UserContext(), id)
     if err != nil {
         return response.NotFound(c, "Report not found", err)
     }
@@ -130,7 +148,29 @@ func (h *WaterResourcesHandler) ListReports(c *fiber.Ctx) error {
         "end_date":         c.Query("end_date"),
     }
 
-    result, err := h.waterUseCase.ListReports(c.Context(), page, limit, filters)
+    result, err := h.waterUseCase.ListReports(c.UserContext(), page, limit, filters)
+    if err != nil {
+        return response.InternalError(c, "Failed to retrieve reports", err)
+    }
+
+    return response.Success(c, "Reports retrieved successfully", result)
+}
+
+// ListMyReports returns the reports created by the authenticated user
+func (h *WaterResourcesHandler) ListMyReports(c *fiber.Ctx) error {
+    page, _ := strconv.Atoi(c.Query("page", "1"))
+    limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+    if page < 1 {
+        page = 1
+    }
+    if limit < 1 || limit > 100 {
+        limit = 10
+    }
+
+    userID := c.Locals("userID").(string)
+
+    result, err := h.waterUseCase.ListMyReports(c.UserContext(), userID, page, limit)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve reports", err)
     }
@@ -142,7 +182,14 @@ func (h *WaterResourcesHandler) ListByPriority(c *fiber.Ctx) error {
     page, _ := strconv.Atoi(c.Query("page", "1"))
     limit, _ := strconv.Atoi(c.Query("limit", "10"))
 
-    result, err := h.waterUseCase.ListByPriority(c.Context(), page, limit)
+    if page < 1 {
+        page = 1
+    }
+    if limit < 1 || limit > 100 {
+        limit = 10
+    }
+
+    result, err := h.waterUseCase.ListByPriority(c.UserContext(), page, limit)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve priority reports", err)
     }
@@ -162,8 +209,9 @@ func (h *WaterResourcesHandler) UpdateReport(c *fiber.Ctx) error {
     }
 
     userID := c.Locals("userID").(string)
+    role := entity.UserRole(c.Locals("role").(string))
 
-    report, err := h.waterUseCase.UpdateReport(c.Context(), id, &req, userID)
+    report, err := h.waterUseCase.UpdateReport(c.UserContext(), id, &req, userID, role)
     if err != nil {
         if err == usecase.ErrUnauthorized {
             return response.Forbidden(c, "You don't have permission to update this report", err)
@@ -186,7 +234,24 @@ func (h *WaterResourcesHandler) UpdateStatus(c *fiber.Ctx) error {
         return response.ValidationError(c, err)
     }
 
-    if err := h.waterUseCase.UpdateStatus(c.Context(), id, &req); err != nil {
+    userID := c.Locals("userID").(string)
+
+    if err := h.waterUseCase.UpdateStatus(c.UserContext(), id, &req, userID); err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return response.NotFound(c, "Report not found", err)
+        }
+        if errors.Is(err, usecase.ErrInvalidStatusTransition) {
+            return response.Conflict(c, "Invalid status transition", err)
+        }
+        if errors.Is(err, repository.ErrStatusChanged) {
+            return response.Conflict(c, "Report status was changed by someone else, reload and try again", err)
+        }
+        if errors.Is(err, usecase.ErrRepairPhotosNotAllowed) {
+            return response.BadRequest(c, "Photos cannot be attached with this status", err)
+        }
+        if resp, ok := photoErrorResponse(c, err); ok {
+            return resp
+        }
         return response.InternalError(c, "Failed to update report status", err)
     }
 
@@ -197,8 +262,9 @@ func (h *WaterResourcesHandler) DeleteReport(c *fiber.Ctx) error {
     id := c.Params("id")
 
     userID := c.Locals("userID").(string)
+    role := entity.UserRole(c.Locals("role").(string))
 
-    if err := h.waterUseCase.DeleteReport(c.Context(), id, userID); err != nil {
+    if err := h.waterUseCase.DeleteReport(c.UserContext(), id, userID, role); err != nil {
         if err == usecase.ErrUnauthorized {
             return response.Forbidden(c, "You don't have permission to delete this report", err)
         }
@@ -236,7 +302,7 @@ func (h *WaterResourcesHandler) GetWaterResourcesOverview(c *fiber.Ctx) error {
         queryIrrigationType = ""
     }
 
-    overview, err := h.waterUseCase.GetWaterResourcesOverview(c.Context(), queryIrrigationType)
+    overview, err := h.waterUseCase.GetWaterResourcesOverview(c.UserContext(), queryIrrigationType)
     if err != nil {
         return response.InternalError(c, "Failed to retrieve water resources overview", err)
     }
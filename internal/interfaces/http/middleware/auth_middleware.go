@@ -2,15 +2,69 @@
 package middleware
 
 import (
+    "context"
+    "fmt"
     "strings"
     
+    "building-report-backend/internal/domain/entity"
     "building-report-backend/internal/infrastructure/auth"
     "building-report-backend/internal/interfaces/response"
     "github.com/gofiber/fiber/v2"
 )
 
-func AuthMiddleware(jwtService auth.JWTService) fiber.Handler {
+// APIKeyHeader carries an API key as an alternative to a bearer token.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator resolves a plaintext API key to the key and the user it acts as.
+type APIKeyAuthenticator interface {
+    AuthenticateAPIKey(ctx context.Context, rawKey string) (*entity.APIKey, *entity.User, error)
+}
+
+// tokenAllowance lists the restricted tokens a route accepts.
+type tokenAllowance struct {
+    passwordChange bool
+    twoFactorSetup bool
+}
+
+// AuthMiddleware authenticates either a bearer token or an X-API-Key header and
+// sets the same userID, username and role locals for both. Restricted tokens, of
+// users who must change their password or enroll in two-factor authentication,
+// are rejected; routes that are part of those flows use the middlewares below.
+func AuthMiddleware(jwtService auth.JWTService, tokenStore auth.TokenStore, apiKeys APIKeyAuthenticator) fiber.Handler {
+    return authenticate(jwtService, tokenStore, apiKeys, tokenAllowance{})
+}
+
+// TwoFactorSetupAuthMiddleware accepts bearer tokens only, including those flagged
+// with must_enroll_2fa.
+func TwoFactorSetupAuthMiddleware(jwtService auth.JWTService, tokenStore auth.TokenStore) fiber.Handler {
+    return authenticate(jwtService, tokenStore, nil, tokenAllowance{twoFactorSetup: true})
+}
+
+// SessionAuthMiddleware accepts any bearer token, restricted or not, for routes
+// every signed-in user needs such as the profile, logout and change-password.
+// A user who must both change their password and enroll can do so in that order.
+func SessionAuthMiddleware(jwtService auth.JWTService, tokenStore auth.TokenStore) fiber.Handler {
+    return authenticate(jwtService, tokenStore, nil, tokenAllowance{passwordChange: true, twoFactorSetup: true})
+}
+
+func authenticate(jwtService auth.JWTService, tokenStore auth.TokenStore, apiKeys APIKeyAuthenticator, allow tokenAllowance) fiber.Handler {
     return func(c *fiber.Ctx) error {
+        if rawKey := c.Get(APIKeyHeader); rawKey != "" && apiKeys != nil {
+            key, owner, err := apiKeys.AuthenticateAPIKey(c.UserContext(), rawKey)
+            if err != nil {
+                return response.Unauthorized(c, "Invalid API key", err)
+            }
+
+            c.Locals("userID", owner.ID)
+            c.Locals("username", owner.Username)
+            c.Locals("role", string(owner.Role))
+            c.Locals("apiKeyID", key.ID)
+            c.Locals("apiKeyScopes", key.Scopes)
+            c.Locals(entity.DataScopeContextKey, owner.DataScope())
+
+            return c.Next()
+        }
+
         authHeader := c.Get("Authorization")
         if authHeader == "" {
             return response.Unauthorized(c, "Missing authorization header", nil)
@@ -26,10 +80,37 @@ func AuthMiddleware(jwtService auth.JWTService) fiber.Handler {
             return response.Unauthorized(c, "Invalid or expired token", err)
         }
 
-        
+        // Without the revocation state a revoked token cannot be told apart,
+        // so the request is refused rather than let through.
+        revoked, err := tokenStore.IsAccessTokenRevoked(c.UserContext(), claims.ID)
+        if err != nil {
+            return response.InternalError(c, "Failed to check token revocation", err)
+        }
+        if !revoked {
+            revoked, err = tokenStore.IsSessionRevoked(c.UserContext(), claims.UserID, claims.IssuedAt.Time)
+            if err != nil {
+                return response.InternalError(c, "Failed to check token revocation", err)
+            }
+        }
+        if revoked {
+            return response.Unauthorized(c, "Token has been revoked", nil)
+        }
+
+        if claims.MustChangePassword && !allow.passwordChange {
+            return response.Forbidden(c, "Password change required", nil)
+        }
+
+        if claims.MustEnrollTwoFactor && !allow.twoFactorSetup {
+            return response.Forbidden(c, "Two-factor authentication enrollment required", nil)
+        }
+
         c.Locals("userID", claims.UserID)
         c.Locals("username", claims.Username)
         c.Locals("role", claims.Role)
+        c.Locals("tokenID", claims.ID)
+        c.Locals("tokenExpiresAt", claims.ExpiresAt.Time)
+        // Repositories read the scope back from c.UserContext() to filter sector reports.
+        c.Locals(entity.DataScopeContextKey, claims.DataScope())
 
         return c.Next()
     }
@@ -37,7 +118,10 @@ func AuthMiddleware(jwtService auth.JWTService) fiber.Handler {
 
 func RequireRole(roles ...string) fiber.Handler {
     return func(c *fiber.Ctx) error {
-        userRole := c.Locals("role").(string)
+        userRole, ok := c.Locals("role").(string)
+        if !ok {
+            return response.Unauthorized(c, "Missing authentication context", nil)
+        }
         
         for _, role := range roles {
             if userRole == role {
@@ -47,4 +131,28 @@ func RequireRole(roles ...string) fiber.Handler {
         
         return response.Forbidden(c, "Insufficient permissions", nil)
     }
+}
+
+// RequirePermission checks the authenticated role against entity.PermissionMatrix,
+// and the key's scopes for API key requests. It must run after AuthMiddleware.
+func RequirePermission(sector entity.Sector, action entity.Action) fiber.Handler {
+    return func(c *fiber.Ctx) error {
+        userRole, ok := c.Locals("role").(string)
+        if !ok {
+            return response.Unauthorized(c, "Missing authentication context", nil)
+        }
+
+        if !entity.HasPermission(entity.UserRole(userRole), sector, action) {
+            return response.Forbidden(c, "Insufficient permissions",
+                fmt.Errorf("role %s is not allowed to %s on %s", userRole, action, sector))
+        }
+
+        // API keys are further limited to their scopes.
+        if scopes, ok := c.Locals("apiKeyScopes").(entity.APIKeyScopes); ok && !scopes.Allows(sector, action) {
+            return response.Forbidden(c, "Insufficient API key scope",
+                fmt.Errorf("API key is not scoped to %s on %s", action, sector))
+        }
+
+        return c.Next()
+    }
 }
\ No newline at end of file
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"building-report-backend/pkg/metrics"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "http_request_duration_seconds",
+		Help: "Duration of HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+	httpRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "HTTP requests currently being served.",
+	})
+)
+
+func init() {
+	metrics.Default.MustRegister(httpRequestDuration, httpRequestsInFlight)
+}
+
+// Metrics records the duration of every request under its route pattern, so
+// /reports/:id is one series however many reports there are. Requests that
+// matched no route are recorded as "unmatched".
+func Metrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		httpRequestsInFlight.Inc()
+		defer httpRequestsInFlight.Dec()
+
+		handleError(c, c.Next())
+
+		status := strconv.Itoa(c.Response().StatusCode())
+		httpRequestDuration.WithLabelValues(c.Method(), routePattern(c), status).Observe(time.Since(start).Seconds())
+		return nil
+	}
+}
+
+// routePattern returns the pattern of the route that served the request.
+// Requests no route matched end in the catch-all 404 handler mounted at "/".
+func routePattern(c *fiber.Ctx) string {
+	route := c.Route().Path
+	if route == "/" && c.Path() != "/" {
+		return "unmatched"
+	}
+	return route
+}
+
+// handleError passes an error returned down the chain to the app's error
+// handler straight away, so middleware wrapping the chain sees the final status.
+func handleError(c *fiber.Ctx, err error) {
+	if err == nil {
+		return
+	}
+	if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+		_ = c.SendStatus(fiber.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"building-report-backend/internal/infrastructure/ratelimit"
+	"building-report-backend/internal/interfaces/response"
+	"building-report-backend/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RateLimitConfig struct {
+	// Name separates the buckets of different route groups.
+	Name   string
+	Limit  int
+	Window time.Duration
+	// Cost returns how many units a request consumes. Nil means one per request.
+	Cost func(c *fiber.Ctx) int
+}
+
+// MultipartCost charges uploadCost for multipart requests, which carry photo
+// uploads, and one unit for everything else.
+func MultipartCost(uploadCost int) func(c *fiber.Ctx) int {
+	return func(c *fiber.Ctx) int {
+		if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+			return uploadCost
+		}
+		return 1
+	}
+}
+
+// RateLimit limits requests per identity: the user ID when AuthMiddleware ran
+// earlier in the chain, the client IP otherwise. It sets the RateLimit-Limit,
+// RateLimit-Remaining and RateLimit-Reset headers and answers 429 with
+// Retry-After once the limit is reached. If the limiter is unavailable the
+// request is let through.
+func RateLimit(limiter ratelimit.Limiter, cfg RateLimitConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if limiter == nil || cfg.Limit <= 0 {
+			return c.Next()
+		}
+
+		cost := 1
+		if cfg.Cost != nil {
+			cost = cfg.Cost(c)
+		}
+
+		identity := "ip:" + c.IP()
+		if userID, ok := c.Locals("userID").(string); ok && userID != "" {
+			identity = "user:" + userID
+		}
+
+		result, err := limiter.Allow(c.UserContext(), cfg.Name+":"+identity, cfg.Limit, cfg.Window, cost)
+		if err != nil {
+			logger.FromContext(c.UserContext()).WarnContext(c.UserContext(), "rate limit check failed",
+				slog.String("limit", cfg.Name), logger.Err(err))
+			return c.Next()
+		}
+
+		reset := strconv.Itoa(int(math.Ceil(result.Reset.Seconds())))
+		c.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Set("RateLimit-Reset", reset)
+
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, reset)
+			return response.TooManyRequests(c, "Rate limit exceeded",
+				fmt.Errorf("limit of %d per %s reached", cfg.Limit, cfg.Window))
+		}
+
+		return c.Next()
+	}
+}
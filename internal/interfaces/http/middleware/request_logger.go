@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"building-report-backend/pkg/logger"
+	"building-report-backend/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const HeaderRequestID = "X-Request-ID"
+
+var requestIDRegex = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,128}$`)
+
+// RequestID gives every request an ID, taken from a well-formed incoming
+// X-Request-ID header or generated, and echoes it in the response. The ID and
+// the logger are stored as locals, and the user context is bound to the
+// request so c.UserContext() reaches them and every other local. It must be
+// the first middleware.
+//
+// The request context is done as soon as the server starts shutting down, not
+// when the request ends, so the user context drops its cancellation; requests
+// in flight at shutdown can then finish their queries and uploads while the
+// server drains.
+func RequestID(log *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(HeaderRequestID)
+		if !requestIDRegex.MatchString(requestID) {
+			requestID = utils.GenerateULID()
+		}
+
+		c.Locals(logger.RequestIDKey, requestID)
+		c.Locals(logger.LoggerKey, log)
+		c.Set(HeaderRequestID, requestID)
+		c.SetUserContext(context.WithoutCancel(c.Context()))
+
+		return c.Next()
+	}
+}
+
+// AccessLog writes one record per request once the rest of the chain has run.
+// Errors are handed to the app's error handler first so the logged status is
+// the one the client receives.
+func AccessLog(log *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		handleError(c, c.Next())
+
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= fiber.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		log.LogAttrs(c.UserContext(), level, "request",
+			slog.String("method", c.Method()),
+			slog.String("route", c.Route().Path),
+			slog.String("path", c.Path()),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("ip", c.IP()),
+			slog.Int("bytes", len(c.Response().Body())),
+			slog.String("user_agent", c.Get(fiber.HeaderUserAgent)),
+		)
+		return nil
+	}
+}
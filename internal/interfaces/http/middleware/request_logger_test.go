@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"building-report-backend/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequestIDContextOutlivesShutdown(t *testing.T) {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(RequestID(slog.New(slog.NewTextHandler(io.Discard, nil))))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	type result struct {
+		err       error
+		requestID string
+	}
+	results := make(chan result, 1)
+
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		close(started)
+		<-release
+		results <- result{err: ctx.Err(), requestID: logger.RequestIDFromContext(ctx)}
+		return c.SendString("done")
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go app.Listener(ln)
+
+	responses := make(chan error, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/slow")
+		if err == nil {
+			resp.Body.Close()
+		}
+		responses <- err
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler did not start")
+	}
+
+	shutdownDone := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdownDone <- app.ShutdownWithContext(ctx)
+	}()
+
+	// Give the shutdown time to close the server's done channel.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	res := <-results
+	if res.err != nil {
+		t.Fatalf("handler context cancelled during shutdown: %v", res.err)
+	}
+	if res.requestID == "" {
+		t.Fatal("handler context lost the request ID")
+	}
+	if err := <-responses; err != nil {
+		t.Fatalf("in-flight request failed: %v", err)
+	}
+	if err := <-shutdownDone; err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
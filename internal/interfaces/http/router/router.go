@@ -1,6 +1,9 @@
 package router
 
 import (
+	"time"
+
+	"building-report-backend/internal/domain/entity"
 	"building-report-backend/internal/interfaces/http/middleware"
 	"building-report-backend/pkg/container"
 
@@ -9,82 +12,204 @@ import (
 
 func SetupRoutes(app *fiber.App, cont *container.Container) {
     
+    if cont.MetricsHandler != nil {
+        app.Get("/metrics", cont.MetricsHandler.Serve)
+    }
+
     api := app.Group("/api/v1")
 
     
-    api.Get("/health", func(c *fiber.Ctx) error {
-        return c.JSON(fiber.Map{
-            "status": "ok",
-            "message": "Service is running",
-        })
-    })
+    api.Get("/health", cont.HealthHandler.Live)
+    api.Get("/health/live", cont.HealthHandler.Live)
+    api.Get("/health/ready", cont.HealthHandler.Ready)
+    api.Get("/version", cont.HealthHandler.Version)
 
     
-    authRoutes := api.Group("/auth")
+    authRequired := middleware.AuthMiddleware(cont.AuthService, cont.TokenStore, cont.APIKeyUseCase)
+    twoFactorSetupAuth := middleware.TwoFactorSetupAuthMiddleware(cont.AuthService, cont.TokenStore)
+    sessionAuth := middleware.SessionAuthMiddleware(cont.AuthService, cont.TokenStore)
+    can := middleware.RequirePermission
+
+    // Each group gets its own bucket. Anonymous requests are keyed by IP; groups
+    // behind authRequired are keyed by user, so the limiter must come after it.
+    rateCfg := cont.Config.RateLimit
+    rateWindow := time.Duration(rateCfg.WindowSeconds) * time.Second
+    rateLimit := func(name string, limit int) fiber.Handler {
+        return middleware.RateLimit(cont.RateLimiter, middleware.RateLimitConfig{
+            Name:   name,
+            Limit:  limit,
+            Window: rateWindow,
+            Cost:   middleware.MultipartCost(rateCfg.UploadCost),
+        })
+    }
+
+    authRoutes := api.Group("/auth", rateLimit("auth", rateCfg.AuthLimit))
     authRoutes.Post("/register", cont.AuthHandler.Register)
     authRoutes.Post("/login", cont.AuthHandler.Login)
-    users := api.Group("/users")
-    users.Use(middleware.AuthMiddleware(cont.AuthService))
-    
-    users.Get("/", cont.AuthHandler.GetAllUsers)
-    users.Get("/:id", cont.AuthHandler.GetUserByID)
-    users.Post("/", cont.AuthHandler.CreateUser)
-    users.Put("/:id", cont.AuthHandler.UpdateUser)
-    users.Delete("/:id", cont.AuthHandler.DeleteUser)
-
-    api.Post("/reports", cont.ReportHandler.CreateReport)
-    api.Post("/spatial-planning", cont.SpatialPlanningHandler.CreateReport)
-    api.Post("/water-resources", cont.WaterResourcesHandler.CreateReport)
-    api.Post("/bina-marga", cont.BinaMargaHandler.CreateReport)
-    api.Post("/agriculture", cont.AgricultureHandler.CreateReport)
-
-    
-    //protected := api.Use(middleware.AuthMiddleware(cont.AuthService))
-    
-    
-    api.Get("/profile", cont.AuthHandler.GetProfile)
-
-    reportRoutes := api.Group("/reports")
-    reportRoutes.Get("/", cont.ReportHandler.ListReports)
-    reportRoutes.Get("/:id", cont.ReportHandler.GetReport)
-    reportRoutes.Put("/:id", cont.ReportHandler.UpdateReport)
-    reportRoutes.Delete("/:id", cont.ReportHandler.DeleteReport)
-
-    reportRoutes.Get("/tata-bangunan/overview", cont.ReportHandler.GetTataBangunanOverview)
-    
-    spatialRoutes := api.Group("/spatial-planning")
-    spatialRoutes.Get("/", cont.SpatialPlanningHandler.ListReports)
-    spatialRoutes.Get("/statistics", cont.SpatialPlanningHandler.GetStatistics)
-    spatialRoutes.Get("/:id", cont.SpatialPlanningHandler.GetReport)
-    spatialRoutes.Put("/:id", cont.SpatialPlanningHandler.UpdateReport)
-    spatialRoutes.Delete("/:id", cont.SpatialPlanningHandler.DeleteReport)
-
-    spatialRoutes.Get("/tata-ruang/overview", cont.SpatialPlanningHandler.GetTataRuangOverview)
-
-    waterRoutes := api.Group("/water-resources")
-    waterRoutes.Get("/", cont.WaterResourcesHandler.ListReports)
-    waterRoutes.Get("/overview", cont.WaterResourcesHandler.GetWaterResourcesOverview)
-
-    binaMargaRoutes := api.Group("/bina-marga")
-    binaMargaRoutes.Get("/", cont.BinaMargaHandler.ListReports)
-    binaMargaRoutes.Get("/overview", cont.BinaMargaHandler.GetBinaMargaOverview)
-
-    agricultureRoutes := api.Group("/agriculture")
+    authRoutes.Post("/refresh", cont.AuthHandler.RefreshToken)
+    authRoutes.Post("/logout", sessionAuth, cont.AuthHandler.Logout)
+    authRoutes.Post("/change-password", sessionAuth, cont.AuthHandler.ChangePassword)
+    authRoutes.Post("/reset-password", cont.AuthHandler.ResetPassword)
+    authRoutes.Post("/2fa/verify", cont.AuthHandler.VerifyTwoFactor)
+    authRoutes.Get("/2fa", twoFactorSetupAuth, cont.AuthHandler.GetTwoFactorStatus)
+    authRoutes.Post("/2fa/setup", twoFactorSetupAuth, cont.AuthHandler.SetupTwoFactor)
+    authRoutes.Post("/2fa/enable", twoFactorSetupAuth, cont.AuthHandler.EnableTwoFactor)
+    authRoutes.Post("/2fa/disable", twoFactorSetupAuth, cont.AuthHandler.DisableTwoFactor)
+    authRoutes.Post("/2fa/recovery-codes", twoFactorSetupAuth, cont.AuthHandler.RegenerateRecoveryCodes)
+    if cont.OIDCHandler != nil {
+        authRoutes.Get("/oidc/login", cont.OIDCHandler.Login)
+        authRoutes.Get("/oidc/callback", cont.OIDCHandler.Callback)
+        authRoutes.Post("/oidc/callback", cont.OIDCHandler.Callback)
+    }
+
+    api.Get("/profile", sessionAuth, rateLimit("profile", rateCfg.APILimit), cont.AuthHandler.GetProfile)
+
+    users := api.Group("/users", authRequired, rateLimit("users", rateCfg.APILimit))
+    users.Get("/", can(entity.SectorUsers, entity.ActionRead), cont.AuthHandler.GetAllUsers)
+    users.Get("/lockouts", can(entity.SectorUsers, entity.ActionRead), cont.AuthHandler.ListLoginLockouts)
+    users.Get("/:id", can(entity.SectorUsers, entity.ActionRead), cont.AuthHandler.GetUserByID)
+    users.Post("/", can(entity.SectorUsers, entity.ActionCreate), cont.AuthHandler.CreateUser)
+    users.Post("/import", can(entity.SectorUsers, entity.ActionCreate), cont.AuthHandler.ImportUsers)
+    users.Post("/bulk-status", can(entity.SectorUsers, entity.ActionUpdate), cont.AuthHandler.BulkUpdateUserStatus)
+    users.Put("/:id", can(entity.SectorUsers, entity.ActionUpdate), cont.AuthHandler.UpdateUser)
+    users.Post("/:id/reset-password", can(entity.SectorUsers, entity.ActionUpdate), cont.AuthHandler.IssuePasswordReset)
+    users.Post("/:id/unlock", can(entity.SectorUsers, entity.ActionUpdate), cont.AuthHandler.UnlockUser)
+    users.Post("/:id/reset-2fa", can(entity.SectorUsers, entity.ActionUpdate), cont.AuthHandler.ResetTwoFactor)
+    users.Delete("/:id", can(entity.SectorUsers, entity.ActionDelete), cont.AuthHandler.DeleteUser)
+
+    reportRoutes := api.Group("/reports", authRequired, rateLimit("reports", rateCfg.APILimit))
+    reportRoutes.Get("/tata-bangunan/overview", can(entity.SectorReports, entity.ActionRead), cont.ReportHandler.GetTataBangunanOverview)
+
+    reportRoutes.Post("/", can(entity.SectorReports, entity.ActionCreate), cont.ReportHandler.CreateReport)
+    reportRoutes.Post("/uploads", can(entity.SectorReports, entity.ActionCreate), cont.UploadHandler.CreateSession(entity.SectorReports))
+    reportRoutes.Post("/uploads/:uploadId/confirm", can(entity.SectorReports, entity.ActionCreate), cont.UploadHandler.Confirm(entity.SectorReports))
+    reportRoutes.Get("/mine", can(entity.SectorReports, entity.ActionRead), cont.ReportHandler.ListMyReports)
+    reportRoutes.Get("/", can(entity.SectorReports, entity.ActionRead), cont.ReportHandler.ListReports)
+    reportRoutes.Get("/:id", can(entity.SectorReports, entity.ActionRead), cont.ReportHandler.GetReport)
+    reportRoutes.Get("/:id/history", can(entity.SectorReports, entity.ActionRead), cont.AuditHandler.GetReportHistory(entity.SectorReports))
+    reportRoutes.Get("/:id/photos", can(entity.SectorReports, entity.ActionRead), cont.PhotoHandler.ListPhotos(entity.SectorReports))
+    reportRoutes.Post("/:id/photos", can(entity.SectorReports, entity.ActionUpdate), cont.PhotoHandler.AddPhotos(entity.SectorReports))
+    reportRoutes.Put("/:id/photos/order", can(entity.SectorReports, entity.ActionUpdate), cont.PhotoHandler.ReorderPhotos(entity.SectorReports))
+    reportRoutes.Get("/:id/photos/:photoId", can(entity.SectorReports, entity.ActionRead), cont.PhotoHandler.GetPhoto(entity.SectorReports))
+    reportRoutes.Patch("/:id/photos/:photoId", can(entity.SectorReports, entity.ActionUpdate), cont.PhotoHandler.UpdatePhoto(entity.SectorReports))
+    reportRoutes.Delete("/:id/photos/:photoId", can(entity.SectorReports, entity.ActionUpdate), cont.PhotoHandler.DeletePhoto(entity.SectorReports))
+    reportRoutes.Put("/:id", can(entity.SectorReports, entity.ActionUpdate), cont.ReportHandler.UpdateReport)
+    reportRoutes.Delete("/:id", can(entity.SectorReports, entity.ActionDelete), cont.ReportHandler.DeleteReport)
+
+    spatialRoutes := api.Group("/spatial-planning", authRequired, rateLimit("spatial-planning", rateCfg.APILimit))
+    spatialRoutes.Get("/statistics", can(entity.SectorSpatialPlanning, entity.ActionRead), cont.SpatialPlanningHandler.GetStatistics)
+    spatialRoutes.Get("/tata-ruang/overview", can(entity.SectorSpatialPlanning, entity.ActionRead), cont.SpatialPlanningHandler.GetTataRuangOverview)
+    spatialRoutes.Get("/priority", can(entity.SectorSpatialPlanning, entity.ActionRead), cont.SpatialPlanningHandler.ListByPriority)
+
+    spatialRoutes.Post("/", can(entity.SectorSpatialPlanning, entity.ActionCreate), cont.SpatialPlanningHandler.CreateReport)
+    spatialRoutes.Post("/uploads", can(entity.SectorSpatialPlanning, entity.ActionCreate), cont.UploadHandler.CreateSession(entity.SectorSpatialPlanning))
+    spatialRoutes.Post("/uploads/:uploadId/confirm", can(entity.SectorSpatialPlanning, entity.ActionCreate), cont.UploadHandler.Confirm(entity.SectorSpatialPlanning))
+    spatialRoutes.Get("/mine", can(entity.SectorSpatialPlanning, entity.ActionRead), cont.SpatialPlanningHandler.ListMyReports)
+    spatialRoutes.Get("/", can(entity.SectorSpatialPlanning, entity.ActionRead), cont.SpatialPlanningHandler.ListReports)
+    spatialRoutes.Get("/:id", can(entity.SectorSpatialPlanning, entity.ActionRead), cont.SpatialPlanningHandler.GetReport)
+    spatialRoutes.Get("/:id/history", can(entity.SectorSpatialPlanning, entity.ActionRead), cont.AuditHandler.GetReportHistory(entity.SectorSpatialPlanning))
+    spatialRoutes.Get("/:id/photos", can(entity.SectorSpatialPlanning, entity.ActionRead), cont.PhotoHandler.ListPhotos(entity.SectorSpatialPlanning))
+    spatialRoutes.Post("/:id/photos", can(entity.SectorSpatialPlanning, entity.ActionUpdate), cont.PhotoHandler.AddPhotos(entity.SectorSpatialPlanning))
+    spatialRoutes.Put("/:id/photos/order", can(entity.SectorSpatialPlanning, entity.ActionUpdate), cont.PhotoHandler.ReorderPhotos(entity.SectorSpatialPlanning))
+    spatialRoutes.Get("/:id/photos/:photoId", can(entity.SectorSpatialPlanning, entity.ActionRead), cont.PhotoHandler.GetPhoto(entity.SectorSpatialPlanning))
+    spatialRoutes.Patch("/:id/photos/:photoId", can(entity.SectorSpatialPlanning, entity.ActionUpdate), cont.PhotoHandler.UpdatePhoto(entity.SectorSpatialPlanning))
+    spatialRoutes.Delete("/:id/photos/:photoId", can(entity.SectorSpatialPlanning, entity.ActionUpdate), cont.PhotoHandler.DeletePhoto(entity.SectorSpatialPlanning))
+    spatialRoutes.Put("/:id", can(entity.SectorSpatialPlanning, entity.ActionUpdate), cont.SpatialPlanningHandler.UpdateReport)
+    spatialRoutes.Patch("/:id/status", can(entity.SectorSpatialPlanning, entity.ActionUpdateStatus), cont.SpatialPlanningHandler.UpdateStatus)
+    spatialRoutes.Delete("/:id", can(entity.SectorSpatialPlanning, entity.ActionDelete), cont.SpatialPlanningHandler.DeleteReport)
+
+    waterRoutes := api.Group("/water-resources", authRequired, rateLimit("water-resources", rateCfg.APILimit))
+    waterRoutes.Get("/overview", can(entity.SectorWaterResources, entity.ActionRead), cont.WaterResourcesHandler.GetWaterResourcesOverview)
+    waterRoutes.Get("/priority", can(entity.SectorWaterResources, entity.ActionRead), cont.WaterResourcesHandler.ListByPriority)
+
+    waterRoutes.Post("/", can(entity.SectorWaterResources, entity.ActionCreate), cont.WaterResourcesHandler.CreateReport)
+    waterRoutes.Post("/uploads", can(entity.SectorWaterResources, entity.ActionCreate), cont.UploadHandler.CreateSession(entity.SectorWaterResources))
+    waterRoutes.Post("/uploads/:uploadId/confirm", can(entity.SectorWaterResources, entity.ActionCreate), cont.UploadHandler.Confirm(entity.SectorWaterResources))
+    waterRoutes.Get("/mine", can(entity.SectorWaterResources, entity.ActionRead), cont.WaterResourcesHandler.ListMyReports)
+    waterRoutes.Get("/", can(entity.SectorWaterResources, entity.ActionRead), cont.WaterResourcesHandler.ListReports)
+    waterRoutes.Get("/:id", can(entity.SectorWaterResources, entity.ActionRead), cont.WaterResourcesHandler.GetReport)
+    waterRoutes.Get("/:id/history", can(entity.SectorWaterResources, entity.ActionRead), cont.AuditHandler.GetReportHistory(entity.SectorWaterResources))
+    waterRoutes.Get("/:id/photos", can(entity.SectorWaterResources, entity.ActionRead), cont.PhotoHandler.ListPhotos(entity.SectorWaterResources))
+    waterRoutes.Post("/:id/photos", can(entity.SectorWaterResources, entity.ActionUpdate), cont.PhotoHandler.AddPhotos(entity.SectorWaterResources))
+    waterRoutes.Put("/:id/photos/order", can(entity.SectorWaterResources, entity.ActionUpdate), cont.PhotoHandler.ReorderPhotos(entity.SectorWaterResources))
+    waterRoutes.Get("/:id/photos/:photoId", can(entity.SectorWaterResources, entity.ActionRead), cont.PhotoHandler.GetPhoto(entity.SectorWaterResources))
+    waterRoutes.Patch("/:id/photos/:photoId", can(entity.SectorWaterResources, entity.ActionUpdate), cont.PhotoHandler.UpdatePhoto(entity.SectorWaterResources))
+    waterRoutes.Delete("/:id/photos/:photoId", can(entity.SectorWaterResources, entity.ActionUpdate), cont.PhotoHandler.DeletePhoto(entity.SectorWaterResources))
+    waterRoutes.Put("/:id", can(entity.SectorWaterResources, entity.ActionUpdate), cont.WaterResourcesHandler.UpdateReport)
+    waterRoutes.Patch("/:id/status", can(entity.SectorWaterResources, entity.ActionUpdateStatus), cont.WaterResourcesHandler.UpdateStatus)
+    waterRoutes.Delete("/:id", can(entity.SectorWaterResources, entity.ActionDelete), cont.WaterResourcesHandler.DeleteReport)
+
+    binaMargaRoutes := api.Group("/bina-marga", authRequired, rateLimit("bina-marga", rateCfg.APILimit))
+    binaMargaRoutes.Get("/overview", can(entity.SectorBinaMarga, entity.ActionRead), cont.BinaMargaHandler.GetBinaMargaOverview)
+    binaMargaRoutes.Get("/priority", can(entity.SectorBinaMarga, entity.ActionRead), cont.BinaMargaHandler.ListByPriority)
+
+    binaMargaRoutes.Post("/", can(entity.SectorBinaMarga, entity.ActionCreate), cont.BinaMargaHandler.CreateReport)
+    binaMargaRoutes.Post("/uploads", can(entity.SectorBinaMarga, entity.ActionCreate), cont.UploadHandler.CreateSession(entity.SectorBinaMarga))
+    binaMargaRoutes.Post("/uploads/:uploadId/confirm", can(entity.SectorBinaMarga, entity.ActionCreate), cont.UploadHandler.Confirm(entity.SectorBinaMarga))
+    binaMargaRoutes.Get("/mine", can(entity.SectorBinaMarga, entity.ActionRead), cont.BinaMargaHandler.ListMyReports)
+    binaMargaRoutes.Get("/", can(entity.SectorBinaMarga, entity.ActionRead), cont.BinaMargaHandler.ListReports)
+    binaMargaRoutes.Get("/:id", can(entity.SectorBinaMarga, entity.ActionRead), cont.BinaMargaHandler.GetReport)
+    binaMargaRoutes.Get("/:id/history", can(entity.SectorBinaMarga, entity.ActionRead), cont.AuditHandler.GetReportHistory(entity.SectorBinaMarga))
+    binaMargaRoutes.Get("/:id/photos", can(entity.SectorBinaMarga, entity.ActionRead), cont.PhotoHandler.ListPhotos(entity.SectorBinaMarga))
+    binaMargaRoutes.Post("/:id/photos", can(entity.SectorBinaMarga, entity.ActionUpdate), cont.PhotoHandler.AddPhotos(entity.SectorBinaMarga))
+    binaMargaRoutes.Put("/:id/photos/order", can(entity.SectorBinaMarga, entity.ActionUpdate), cont.PhotoHandler.ReorderPhotos(entity.SectorBinaMarga))
+    binaMargaRoutes.Get("/:id/photos/:photoId", can(entity.SectorBinaMarga, entity.ActionRead), cont.PhotoHandler.GetPhoto(entity.SectorBinaMarga))
+    binaMargaRoutes.Patch("/:id/photos/:photoId", can(entity.SectorBinaMarga, entity.ActionUpdate), cont.PhotoHandler.UpdatePhoto(entity.SectorBinaMarga))
+    binaMargaRoutes.Delete("/:id/photos/:photoId", can(entity.SectorBinaMarga, entity.ActionUpdate), cont.PhotoHandler.DeletePhoto(entity.SectorBinaMarga))
+    binaMargaRoutes.Put("/:id", can(entity.SectorBinaMarga, entity.ActionUpdate), cont.BinaMargaHandler.UpdateReport)
+    binaMargaRoutes.Patch("/:id/status", can(entity.SectorBinaMarga, entity.ActionUpdateStatus), cont.BinaMargaHandler.UpdateStatus)
+    binaMargaRoutes.Delete("/:id", can(entity.SectorBinaMarga, entity.ActionDelete), cont.BinaMargaHandler.DeleteReport)
+
+    agricultureRoutes := api.Group("/agriculture", authRequired, rateLimit("agriculture", rateCfg.APILimit))
     
-    agricultureRoutes.Get("/executive/dashboard", cont.AgricultureHandler.GetExecutiveDashboard)
-    agricultureRoutes.Get("/commodity/analysis", cont.AgricultureHandler.GetCommodityAnalysis)
-    agricultureRoutes.Get("/food-crop/stats", cont.AgricultureHandler.GetFoodCropStats)
-    agricultureRoutes.Get("/horticulture/stats", cont.AgricultureHandler.GetHorticultureStats)
-    agricultureRoutes.Get("/plantation/stats", cont.AgricultureHandler.GetPlantationStats)
-    agricultureRoutes.Get("/equipment/stats", cont.AgricultureHandler.GetAgriculturalEquipmentStats)
-    agricultureRoutes.Get("/land-irrigation/stats", cont.AgricultureHandler.GetLandAndIrrigationStats)
-
-    agricultureRoutes.Get("/", cont.AgricultureHandler.ListReports)
-    agricultureRoutes.Get("/:id", cont.AgricultureHandler.GetReport)
-    agricultureRoutes.Put("/:id", cont.AgricultureHandler.UpdateReport)
-    agricultureRoutes.Delete("/:id", cont.AgricultureHandler.DeleteReport)
-
-    executiveRoutes := api.Group("/executive")
+    agricultureRoutes.Get("/executive/dashboard", can(entity.SectorAgriculture, entity.ActionRead), cont.AgricultureHandler.GetExecutiveDashboard)
+    agricultureRoutes.Get("/commodity/analysis", can(entity.SectorAgriculture, entity.ActionRead), cont.AgricultureHandler.GetCommodityAnalysis)
+    agricultureRoutes.Get("/food-crop/stats", can(entity.SectorAgriculture, entity.ActionRead), cont.AgricultureHandler.GetFoodCropStats)
+    agricultureRoutes.Get("/horticulture/stats", can(entity.SectorAgriculture, entity.ActionRead), cont.AgricultureHandler.GetHorticultureStats)
+    agricultureRoutes.Get("/plantation/stats", can(entity.SectorAgriculture, entity.ActionRead), cont.AgricultureHandler.GetPlantationStats)
+    agricultureRoutes.Get("/equipment/stats", can(entity.SectorAgriculture, entity.ActionRead), cont.AgricultureHandler.GetAgriculturalEquipmentStats)
+    agricultureRoutes.Get("/land-irrigation/stats", can(entity.SectorAgriculture, entity.ActionRead), cont.AgricultureHandler.GetLandAndIrrigationStats)
+
+    agricultureRoutes.Post("/", can(entity.SectorAgriculture, entity.ActionCreate), cont.AgricultureHandler.CreateReport)
+    agricultureRoutes.Post("/uploads", can(entity.SectorAgriculture, entity.ActionCreate), cont.UploadHandler.CreateSession(entity.SectorAgriculture))
+    agricultureRoutes.Post("/uploads/:uploadId/confirm", can(entity.SectorAgriculture, entity.ActionCreate), cont.UploadHandler.Confirm(entity.SectorAgriculture))
+    agricultureRoutes.Get("/mine", can(entity.SectorAgriculture, entity.ActionRead), cont.AgricultureHandler.ListMyReports)
+    agricultureRoutes.Get("/", can(entity.SectorAgriculture, entity.ActionRead), cont.AgricultureHandler.ListReports)
+    agricultureRoutes.Get("/:id", can(entity.SectorAgriculture, entity.ActionRead), cont.AgricultureHandler.GetReport)
+    agricultureRoutes.Get("/:id/history", can(entity.SectorAgriculture, entity.ActionRead), cont.AuditHandler.GetReportHistory(entity.SectorAgriculture))
+    agricultureRoutes.Get("/:id/photos", can(entity.SectorAgriculture, entity.ActionRead), cont.PhotoHandler.ListPhotos(entity.SectorAgriculture))
+    agricultureRoutes.Post("/:id/photos", can(entity.SectorAgriculture, entity.ActionUpdate), cont.PhotoHandler.AddPhotos(entity.SectorAgriculture))
+    agricultureRoutes.Put("/:id/photos/order", can(entity.SectorAgriculture, entity.ActionUpdate), cont.PhotoHandler.ReorderPhotos(entity.SectorAgriculture))
+    agricultureRoutes.Get("/:id/photos/:photoId", can(entity.SectorAgriculture, entity.ActionRead), cont.PhotoHandler.GetPhoto(entity.SectorAgriculture))
+    agricultureRoutes.Patch("/:id/photos/:photoId", can(entity.SectorAgriculture, entity.ActionUpdate), cont.PhotoHandler.UpdatePhoto(entity.SectorAgriculture))
+    agricultureRoutes.Delete("/:id/photos/:photoId", can(entity.SectorAgriculture, entity.ActionUpdate), cont.PhotoHandler.DeletePhoto(entity.SectorAgriculture))
+    agricultureRoutes.Put("/:id", can(entity.SectorAgriculture, entity.ActionUpdate), cont.AgricultureHandler.UpdateReport)
+    agricultureRoutes.Delete("/:id", can(entity.SectorAgriculture, entity.ActionDelete), cont.AgricultureHandler.DeleteReport)
+
+    riceFieldRoutes := api.Group("/rice-fields", authRequired, rateLimit("rice-fields", rateCfg.APILimit))
+    riceFieldRoutes.Get("/statistics", can(entity.SectorRiceFields, entity.ActionRead), cont.RiceFieldHandler.GetStatistics)
+    riceFieldRoutes.Get("/distribution", can(entity.SectorRiceFields, entity.ActionRead), cont.RiceFieldHandler.GetDistributionByDistrict)
+    riceFieldRoutes.Get("/trends", can(entity.SectorRiceFields, entity.ActionRead), cont.RiceFieldHandler.GetTrends)
+
+    riceFieldRoutes.Get("/", can(entity.SectorRiceFields, entity.ActionRead), cont.RiceFieldHandler.ListRiceFields)
+    riceFieldRoutes.Post("/", can(entity.SectorRiceFields, entity.ActionCreate), cont.RiceFieldHandler.CreateRiceField)
+    riceFieldRoutes.Get("/:id", can(entity.SectorRiceFields, entity.ActionRead), cont.RiceFieldHandler.GetRiceField)
+    riceFieldRoutes.Put("/:id", can(entity.SectorRiceFields, entity.ActionUpdate), cont.RiceFieldHandler.UpdateRiceField)
+    riceFieldRoutes.Delete("/:id", can(entity.SectorRiceFields, entity.ActionDelete), cont.RiceFieldHandler.DeleteRiceField)
+
+    auditRoutes := api.Group("/audit-logs", authRequired, rateLimit("audit-logs", rateCfg.APILimit), can(entity.SectorAuditLogs, entity.ActionRead))
+    auditRoutes.Get("/", cont.AuditHandler.SearchAuditLogs)
+
+    apiKeyRoutes := api.Group("/api-keys", authRequired, rateLimit("api-keys", rateCfg.APILimit))
+    apiKeyRoutes.Get("/", can(entity.SectorAPIKeys, entity.ActionRead), cont.APIKeyHandler.ListAPIKeys)
+    apiKeyRoutes.Post("/", can(entity.SectorAPIKeys, entity.ActionCreate), cont.APIKeyHandler.CreateAPIKey)
+    apiKeyRoutes.Delete("/:id", can(entity.SectorAPIKeys, entity.ActionDelete), cont.APIKeyHandler.RevokeAPIKey)
+
+    trashRoutes := api.Group("/trash", authRequired, rateLimit("trash", rateCfg.APILimit))
+    trashRoutes.Get("/", can(entity.SectorTrash, entity.ActionRead), cont.TrashHandler.ListTrash)
+    trashRoutes.Post("/:sector/:id/restore", can(entity.SectorTrash, entity.ActionUpdate), cont.TrashHandler.Restore)
+
+    executiveRoutes := api.Group("/executive", authRequired, rateLimit("executive", rateCfg.APILimit), can(entity.SectorExecutive, entity.ActionRead))
     economyRoutes := executiveRoutes.Group("/economy")
     economyRoutes.Get("/overview", cont.ExecutiveHandler.GetEkonomiOverview)
 
@@ -0,0 +1,111 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"building-report-backend/internal/domain/repository"
+	"building-report-backend/pkg/metrics"
+	"building-report-backend/pkg/tracing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	reportsStored = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "reports_stored",
+		Help: "Reports outside the trash, by sector.",
+	}, []string{"sector"})
+	reportsOpenUrgent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "reports_open_urgent",
+		Help: "Reports at the highest urgency that are not completed or rejected, by sector.",
+	}, []string{"sector"})
+)
+
+func init() {
+	metrics.Default.MustRegister(reportsStored, reportsOpenUrgent)
+}
+
+// ReportMetricsJob periodically recomputes the report gauges, which are too
+// expensive to count on every scrape.
+type ReportMetricsJob struct {
+	metricsRepo repository.ReportMetricsRepository
+	interval    time.Duration
+	log         *slog.Logger
+	done        chan struct{}
+}
+
+func NewReportMetricsJob(metricsRepo repository.ReportMetricsRepository, intervalSeconds int, log *slog.Logger) *ReportMetricsJob {
+	return &ReportMetricsJob{
+		metricsRepo: metricsRepo,
+		interval:    time.Duration(intervalSeconds) * time.Second,
+		log:         log.With(slog.String("job", "report_metrics")),
+		done:        make(chan struct{}),
+	}
+}
+
+// Start refreshes the gauges immediately and then on every interval until ctx is cancelled.
+func (j *ReportMetricsJob) Start(ctx context.Context) {
+	if j.interval <= 0 {
+		j.log.InfoContext(ctx, "report metrics job disabled")
+		close(j.done)
+		return
+	}
+
+	go func() {
+		defer close(j.done)
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			j.run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (j *ReportMetricsJob) run(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, j.interval)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "job.report_metrics")
+	defer span.End()
+
+	stored, err := j.metricsRepo.CountReports(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		j.log.ErrorContext(ctx, "counting reports failed", slog.String("error", err.Error()))
+		return
+	}
+	for sector, count := range stored {
+		reportsStored.WithLabelValues(string(sector)).Set(float64(count))
+	}
+
+	urgent, err := j.metricsRepo.CountOpenUrgentReports(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		j.log.ErrorContext(ctx, "counting open urgent reports failed", slog.String("error", err.Error()))
+		return
+	}
+	for sector, count := range urgent {
+		reportsOpenUrgent.WithLabelValues(string(sector)).Set(float64(count))
+	}
+}
+
+// Wait blocks until the job has stopped after its context was cancelled,
+// letting a run in progress finish, or until ctx is done.
+func (j *ReportMetricsJob) Wait(ctx context.Context) error {
+	select {
+	case <-j.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,82 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"building-report-backend/internal/application/usecase"
+	"building-report-backend/pkg/tracing"
+)
+
+// TrashPurgeJob periodically removes reports that have outlived the trash retention period.
+type TrashPurgeJob struct {
+	trashUseCase *usecase.TrashUseCase
+	retention    time.Duration
+	interval     time.Duration
+	log          *slog.Logger
+	done         chan struct{}
+}
+
+func NewTrashPurgeJob(trashUseCase *usecase.TrashUseCase, retentionDays, intervalHours int, log *slog.Logger) *TrashPurgeJob {
+	return &TrashPurgeJob{
+		trashUseCase: trashUseCase,
+		log:          log.With(slog.String("job", "trash_purge")),
+		done:         make(chan struct{}),
+		retention:    time.Duration(retentionDays) * 24 * time.Hour,
+		interval:     time.Duration(intervalHours) * time.Hour,
+	}
+}
+
+// Start runs a purge immediately and then on every interval until ctx is cancelled.
+// A retention or interval of zero or less disables purging.
+func (j *TrashPurgeJob) Start(ctx context.Context) {
+	if j.interval <= 0 || j.retention <= 0 {
+		j.log.InfoContext(ctx, "trash purge job disabled")
+		close(j.done)
+		return
+	}
+
+	go func() {
+		defer close(j.done)
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			j.run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (j *TrashPurgeJob) run(ctx context.Context) {
+	ctx, span := tracing.Start(ctx, "job.trash_purge")
+	defer span.End()
+
+	purged, err := j.trashUseCase.PurgeExpired(ctx, j.retention)
+	if err != nil {
+		tracing.RecordError(span, err)
+		j.log.ErrorContext(ctx, "trash purge failed", slog.Int("purged", purged), slog.String("error", err.Error()))
+		return
+	}
+	if purged > 0 {
+		j.log.InfoContext(ctx, "trash purge completed", slog.Int("purged", purged))
+	}
+}
+
+// Wait blocks until the job has stopped after its context was cancelled,
+// letting a run in progress finish, or until ctx is done.
+func (j *TrashPurgeJob) Wait(ctx context.Context) error {
+	select {
+	case <-j.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,80 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"building-report-backend/internal/application/usecase"
+	"building-report-backend/pkg/tracing"
+)
+
+// UploadSweepJob periodically removes expired upload sessions and the objects
+// that were uploaded for them but never made it onto a report.
+type UploadSweepJob struct {
+	uploadUseCase *usecase.UploadUseCase
+	interval      time.Duration
+	log           *slog.Logger
+	done          chan struct{}
+}
+
+func NewUploadSweepJob(uploadUseCase *usecase.UploadUseCase, intervalMinutes int, log *slog.Logger) *UploadSweepJob {
+	return &UploadSweepJob{
+		uploadUseCase: uploadUseCase,
+		log:           log.With(slog.String("job", "upload_sweep")),
+		done:          make(chan struct{}),
+		interval:      time.Duration(intervalMinutes) * time.Minute,
+	}
+}
+
+// Start runs a sweep immediately and then on every interval until ctx is cancelled.
+func (j *UploadSweepJob) Start(ctx context.Context) {
+	if j.interval <= 0 {
+		j.log.InfoContext(ctx, "upload sweep job disabled")
+		close(j.done)
+		return
+	}
+
+	go func() {
+		defer close(j.done)
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			j.run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (j *UploadSweepJob) run(ctx context.Context) {
+	ctx, span := tracing.Start(ctx, "job.upload_sweep")
+	defer span.End()
+
+	swept, err := j.uploadUseCase.SweepExpired(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		j.log.ErrorContext(ctx, "upload sweep failed", slog.Int("swept", swept), slog.String("error", err.Error()))
+		return
+	}
+	if swept > 0 {
+		j.log.InfoContext(ctx, "upload sweep completed", slog.Int("swept", swept))
+	}
+}
+
+// Wait blocks until the job has stopped after its context was cancelled,
+// letting a run in progress finish, or until ctx is done.
+func (j *UploadSweepJob) Wait(ctx context.Context) error {
+	select {
+	case <-j.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -69,6 +69,14 @@ func Conflict(c *fiber.Ctx, message string, err error) error {
     })
 }
 
+func TooManyRequests(c *fiber.Ctx, message string, err error) error {
+    return c.Status(fiber.StatusTooManyRequests).JSON(Response{
+        Success: false,
+        Message: message,
+        Error:   getErrorMessage(err),
+    })
+}
+
 func InternalError(c *fiber.Ctx, message string, err error) error {
     return c.Status(fiber.StatusInternalServerError).JSON(Response{
         Success: false,
@@ -0,0 +1,51 @@
+-- +goose Up
+ALTER TABLE reports
+ADD COLUMN IF NOT EXISTS created_by VARCHAR(26),
+ADD COLUMN IF NOT EXISTS updated_by VARCHAR(26);
+CREATE INDEX IF NOT EXISTS idx_reports_created_by ON reports(created_by);
+
+ALTER TABLE spatial_planning_reports
+ADD COLUMN IF NOT EXISTS created_by VARCHAR(26),
+ADD COLUMN IF NOT EXISTS updated_by VARCHAR(26);
+CREATE INDEX IF NOT EXISTS idx_spatial_planning_reports_created_by ON spatial_planning_reports(created_by);
+
+ALTER TABLE water_resources_reports
+ADD COLUMN IF NOT EXISTS created_by VARCHAR(26),
+ADD COLUMN IF NOT EXISTS updated_by VARCHAR(26);
+CREATE INDEX IF NOT EXISTS idx_water_resources_reports_created_by ON water_resources_reports(created_by);
+
+ALTER TABLE bina_marga_reports
+ADD COLUMN IF NOT EXISTS created_by VARCHAR(26),
+ADD COLUMN IF NOT EXISTS updated_by VARCHAR(26);
+CREATE INDEX IF NOT EXISTS idx_bina_marga_reports_created_by ON bina_marga_reports(created_by);
+
+ALTER TABLE agriculture_reports
+ADD COLUMN IF NOT EXISTS created_by VARCHAR(26),
+ADD COLUMN IF NOT EXISTS updated_by VARCHAR(26);
+CREATE INDEX IF NOT EXISTS idx_agriculture_reports_created_by ON agriculture_reports(created_by);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_agriculture_reports_created_by;
+ALTER TABLE agriculture_reports
+DROP COLUMN IF EXISTS updated_by,
+DROP COLUMN IF EXISTS created_by;
+
+DROP INDEX IF EXISTS idx_bina_marga_reports_created_by;
+ALTER TABLE bina_marga_reports
+DROP COLUMN IF EXISTS updated_by,
+DROP COLUMN IF EXISTS created_by;
+
+DROP INDEX IF EXISTS idx_water_resources_reports_created_by;
+ALTER TABLE water_resources_reports
+DROP COLUMN IF EXISTS updated_by,
+DROP COLUMN IF EXISTS created_by;
+
+DROP INDEX IF EXISTS idx_spatial_planning_reports_created_by;
+ALTER TABLE spatial_planning_reports
+DROP COLUMN IF EXISTS updated_by,
+DROP COLUMN IF EXISTS created_by;
+
+DROP INDEX IF EXISTS idx_reports_created_by;
+ALTER TABLE reports
+DROP COLUMN IF EXISTS updated_by,
+DROP COLUMN IF EXISTS created_by;
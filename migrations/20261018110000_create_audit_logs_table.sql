@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id VARCHAR(26) PRIMARY KEY,
+    sector VARCHAR(50) NOT NULL,
+    report_id VARCHAR(26) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor_id VARCHAR(26),
+    changes JSONB NOT NULL DEFAULT '{}'::jsonb,
+    notes TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_logs_report ON audit_logs(sector, report_id);
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs(actor_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS audit_logs;
@@ -0,0 +1,31 @@
+-- +goose Up
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+CREATE INDEX IF NOT EXISTS idx_reports_deleted_at ON reports(deleted_at);
+
+ALTER TABLE spatial_planning_reports ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+CREATE INDEX IF NOT EXISTS idx_spatial_planning_reports_deleted_at ON spatial_planning_reports(deleted_at);
+
+ALTER TABLE water_resources_reports ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+CREATE INDEX IF NOT EXISTS idx_water_resources_reports_deleted_at ON water_resources_reports(deleted_at);
+
+ALTER TABLE bina_marga_reports ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+CREATE INDEX IF NOT EXISTS idx_bina_marga_reports_deleted_at ON bina_marga_reports(deleted_at);
+
+ALTER TABLE agriculture_reports ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+CREATE INDEX IF NOT EXISTS idx_agriculture_reports_deleted_at ON agriculture_reports(deleted_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_agriculture_reports_deleted_at;
+ALTER TABLE agriculture_reports DROP COLUMN IF EXISTS deleted_at;
+
+DROP INDEX IF EXISTS idx_bina_marga_reports_deleted_at;
+ALTER TABLE bina_marga_reports DROP COLUMN IF EXISTS deleted_at;
+
+DROP INDEX IF EXISTS idx_water_resources_reports_deleted_at;
+ALTER TABLE water_resources_reports DROP COLUMN IF EXISTS deleted_at;
+
+DROP INDEX IF EXISTS idx_spatial_planning_reports_deleted_at;
+ALTER TABLE spatial_planning_reports DROP COLUMN IF EXISTS deleted_at;
+
+DROP INDEX IF EXISTS idx_reports_deleted_at;
+ALTER TABLE reports DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS login_lockouts (
+    id VARCHAR(26) PRIMARY KEY,
+    scope VARCHAR(20) NOT NULL,
+    user_id VARCHAR(26),
+    identifier VARCHAR(255),
+    ip_address VARCHAR(64),
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NOT NULL,
+    unlocked_at TIMESTAMP,
+    unlocked_by VARCHAR(26),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_login_lockouts_user_id ON login_lockouts(user_id);
+CREATE INDEX idx_login_lockouts_ip_address ON login_lockouts(ip_address);
+CREATE INDEX idx_login_lockouts_created_at ON login_lockouts(created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS login_lockouts;
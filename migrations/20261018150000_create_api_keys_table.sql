@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS api_keys (
+    id VARCHAR(26) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    owner_id VARCHAR(26) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes JSONB NOT NULL DEFAULT '[]'::jsonb,
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    revoked_by VARCHAR(26),
+    created_by VARCHAR(26),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_api_keys_key_hash ON api_keys(key_hash);
+CREATE INDEX idx_api_keys_owner_id ON api_keys(owner_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS api_keys;
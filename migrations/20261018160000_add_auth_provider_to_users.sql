@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN IF NOT EXISTS auth_provider VARCHAR(20) NOT NULL DEFAULT 'local';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS external_subject VARCHAR(255) NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_external_subject ON users(auth_provider, external_subject) WHERE external_subject IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_users_external_subject;
+ALTER TABLE users DROP COLUMN IF EXISTS external_subject;
+ALTER TABLE users DROP COLUMN IF EXISTS auth_provider;
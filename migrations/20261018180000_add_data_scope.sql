@@ -0,0 +1,37 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN IF NOT EXISTS district VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS unit VARCHAR(100) NOT NULL DEFAULT '';
+
+ALTER TABLE spatial_planning_reports ADD COLUMN IF NOT EXISTS district VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE water_resources_reports ADD COLUMN IF NOT EXISTS district VARCHAR(100) NOT NULL DEFAULT '';
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS unit VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE spatial_planning_reports ADD COLUMN IF NOT EXISTS unit VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE water_resources_reports ADD COLUMN IF NOT EXISTS unit VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE bina_marga_reports ADD COLUMN IF NOT EXISTS unit VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE agriculture_reports ADD COLUMN IF NOT EXISTS unit VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_reports_scope ON reports(LOWER(district), unit);
+CREATE INDEX IF NOT EXISTS idx_spatial_planning_reports_scope ON spatial_planning_reports(LOWER(district), unit);
+CREATE INDEX IF NOT EXISTS idx_water_resources_reports_scope ON water_resources_reports(LOWER(district), unit);
+CREATE INDEX IF NOT EXISTS idx_bina_marga_reports_scope ON bina_marga_reports(LOWER(district), unit);
+CREATE INDEX IF NOT EXISTS idx_agriculture_reports_scope ON agriculture_reports(LOWER(district), unit);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_agriculture_reports_scope;
+DROP INDEX IF EXISTS idx_bina_marga_reports_scope;
+DROP INDEX IF EXISTS idx_water_resources_reports_scope;
+DROP INDEX IF EXISTS idx_spatial_planning_reports_scope;
+DROP INDEX IF EXISTS idx_reports_scope;
+
+ALTER TABLE agriculture_reports DROP COLUMN IF EXISTS unit;
+ALTER TABLE bina_marga_reports DROP COLUMN IF EXISTS unit;
+ALTER TABLE water_resources_reports DROP COLUMN IF EXISTS unit;
+ALTER TABLE spatial_planning_reports DROP COLUMN IF EXISTS unit;
+ALTER TABLE reports DROP COLUMN IF EXISTS unit;
+
+ALTER TABLE water_resources_reports DROP COLUMN IF EXISTS district;
+ALTER TABLE spatial_planning_reports DROP COLUMN IF EXISTS district;
+
+ALTER TABLE users DROP COLUMN IF EXISTS unit;
+ALTER TABLE users DROP COLUMN IF EXISTS district;
@@ -0,0 +1,81 @@
+-- +goose Up
+ALTER TABLE report_photos ADD COLUMN IF NOT EXISTS thumbnail_url VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE report_photos ADD COLUMN IF NOT EXISTS medium_url VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE report_photos ADD COLUMN IF NOT EXISTS width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE report_photos ADD COLUMN IF NOT EXISTS height INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE report_photos ADD COLUMN IF NOT EXISTS taken_at TIMESTAMP;
+ALTER TABLE report_photos ADD COLUMN IF NOT EXISTS gps_latitude DECIMAL(10, 8);
+ALTER TABLE report_photos ADD COLUMN IF NOT EXISTS gps_longitude DECIMAL(11, 8);
+
+ALTER TABLE spatial_planning_photos ADD COLUMN IF NOT EXISTS thumbnail_url VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE spatial_planning_photos ADD COLUMN IF NOT EXISTS medium_url VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE spatial_planning_photos ADD COLUMN IF NOT EXISTS width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE spatial_planning_photos ADD COLUMN IF NOT EXISTS height INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE spatial_planning_photos ADD COLUMN IF NOT EXISTS taken_at TIMESTAMP;
+ALTER TABLE spatial_planning_photos ADD COLUMN IF NOT EXISTS gps_latitude DECIMAL(10, 8);
+ALTER TABLE spatial_planning_photos ADD COLUMN IF NOT EXISTS gps_longitude DECIMAL(11, 8);
+
+ALTER TABLE water_resources_photos ADD COLUMN IF NOT EXISTS thumbnail_url VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE water_resources_photos ADD COLUMN IF NOT EXISTS medium_url VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE water_resources_photos ADD COLUMN IF NOT EXISTS width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE water_resources_photos ADD COLUMN IF NOT EXISTS height INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE water_resources_photos ADD COLUMN IF NOT EXISTS taken_at TIMESTAMP;
+ALTER TABLE water_resources_photos ADD COLUMN IF NOT EXISTS gps_latitude DECIMAL(10, 8);
+ALTER TABLE water_resources_photos ADD COLUMN IF NOT EXISTS gps_longitude DECIMAL(11, 8);
+
+ALTER TABLE bina_marga_photos ADD COLUMN IF NOT EXISTS thumbnail_url VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE bina_marga_photos ADD COLUMN IF NOT EXISTS medium_url VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE bina_marga_photos ADD COLUMN IF NOT EXISTS width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE bina_marga_photos ADD COLUMN IF NOT EXISTS height INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE bina_marga_photos ADD COLUMN IF NOT EXISTS taken_at TIMESTAMP;
+ALTER TABLE bina_marga_photos ADD COLUMN IF NOT EXISTS gps_latitude DECIMAL(10, 8);
+ALTER TABLE bina_marga_photos ADD COLUMN IF NOT EXISTS gps_longitude DECIMAL(11, 8);
+
+ALTER TABLE agriculture_photos ADD COLUMN IF NOT EXISTS thumbnail_url VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE agriculture_photos ADD COLUMN IF NOT EXISTS medium_url VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE agriculture_photos ADD COLUMN IF NOT EXISTS width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE agriculture_photos ADD COLUMN IF NOT EXISTS height INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE agriculture_photos ADD COLUMN IF NOT EXISTS taken_at TIMESTAMP;
+ALTER TABLE agriculture_photos ADD COLUMN IF NOT EXISTS gps_latitude DECIMAL(10, 8);
+ALTER TABLE agriculture_photos ADD COLUMN IF NOT EXISTS gps_longitude DECIMAL(11, 8);
+
+-- +goose Down
+ALTER TABLE agriculture_photos DROP COLUMN IF EXISTS gps_longitude;
+ALTER TABLE agriculture_photos DROP COLUMN IF EXISTS gps_latitude;
+ALTER TABLE agriculture_photos DROP COLUMN IF EXISTS taken_at;
+ALTER TABLE agriculture_photos DROP COLUMN IF EXISTS height;
+ALTER TABLE agriculture_photos DROP COLUMN IF EXISTS width;
+ALTER TABLE agriculture_photos DROP COLUMN IF EXISTS medium_url;
+ALTER TABLE agriculture_photos DROP COLUMN IF EXISTS thumbnail_url;
+
+ALTER TABLE bina_marga_photos DROP COLUMN IF EXISTS gps_longitude;
+ALTER TABLE bina_marga_photos DROP COLUMN IF EXISTS gps_latitude;
+ALTER TABLE bina_marga_photos DROP COLUMN IF EXISTS taken_at;
+ALTER TABLE bina_marga_photos DROP COLUMN IF EXISTS height;
+ALTER TABLE bina_marga_photos DROP COLUMN IF EXISTS width;
+ALTER TABLE bina_marga_photos DROP COLUMN IF EXISTS medium_url;
+ALTER TABLE bina_marga_photos DROP COLUMN IF EXISTS thumbnail_url;
+
+ALTER TABLE water_resources_photos DROP COLUMN IF EXISTS gps_longitude;
+ALTER TABLE water_resources_photos DROP COLUMN IF EXISTS gps_latitude;
+ALTER TABLE water_resources_photos DROP COLUMN IF EXISTS taken_at;
+ALTER TABLE water_resources_photos DROP COLUMN IF EXISTS height;
+ALTER TABLE water_resources_photos DROP COLUMN IF EXISTS width;
+ALTER TABLE water_resources_photos DROP COLUMN IF EXISTS medium_url;
+ALTER TABLE water_resources_photos DROP COLUMN IF EXISTS thumbnail_url;
+
+ALTER TABLE spatial_planning_photos DROP COLUMN IF EXISTS gps_longitude;
+ALTER TABLE spatial_planning_photos DROP COLUMN IF EXISTS gps_latitude;
+ALTER TABLE spatial_planning_photos DROP COLUMN IF EXISTS taken_at;
+ALTER TABLE spatial_planning_photos DROP COLUMN IF EXISTS height;
+ALTER TABLE spatial_planning_photos DROP COLUMN IF EXISTS width;
+ALTER TABLE spatial_planning_photos DROP COLUMN IF EXISTS medium_url;
+ALTER TABLE spatial_planning_photos DROP COLUMN IF EXISTS thumbnail_url;
+
+ALTER TABLE report_photos DROP COLUMN IF EXISTS gps_longitude;
+ALTER TABLE report_photos DROP COLUMN IF EXISTS gps_latitude;
+ALTER TABLE report_photos DROP COLUMN IF EXISTS taken_at;
+ALTER TABLE report_photos DROP COLUMN IF EXISTS height;
+ALTER TABLE report_photos DROP COLUMN IF EXISTS width;
+ALTER TABLE report_photos DROP COLUMN IF EXISTS medium_url;
+ALTER TABLE report_photos DROP COLUMN IF EXISTS thumbnail_url;
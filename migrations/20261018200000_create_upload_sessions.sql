@@ -0,0 +1,38 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS upload_sessions (
+    id VARCHAR(26) PRIMARY KEY,
+    user_id VARCHAR(26) NOT NULL,
+    sector VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    report_id VARCHAR(26),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_upload_sessions_user_id ON upload_sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_upload_sessions_expires_at ON upload_sessions(expires_at);
+
+CREATE TABLE IF NOT EXISTS upload_session_files (
+    id VARCHAR(26) PRIMARY KEY,
+    session_id VARCHAR(26) NOT NULL REFERENCES upload_sessions(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    object_name VARCHAR(500) NOT NULL,
+    photo_url VARCHAR(500) NOT NULL DEFAULT '',
+    thumbnail_url VARCHAR(500) NOT NULL DEFAULT '',
+    medium_url VARCHAR(500) NOT NULL DEFAULT '',
+    width INTEGER NOT NULL DEFAULT 0,
+    height INTEGER NOT NULL DEFAULT 0,
+    taken_at TIMESTAMP,
+    gps_latitude DECIMAL(10, 8),
+    gps_longitude DECIMAL(11, 8)
+);
+
+CREATE INDEX IF NOT EXISTS idx_upload_session_files_session_id ON upload_session_files(session_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS upload_session_files;
+DROP TABLE IF EXISTS upload_sessions;
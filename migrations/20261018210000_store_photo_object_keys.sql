@@ -0,0 +1,34 @@
+-- +goose Up
+-- Photos used to be stored as permanent public URLs of the form
+-- <MINIO_PUBLIC_URL>/<bucket>/<object>. The bucket is private now and the
+-- columns keep the object name only; URLs are signed when a response is built.
+
+UPDATE report_photos SET photo_url = regexp_replace(photo_url, '^https?://[^/]+/[^/]+/', '') WHERE photo_url ~ '^https?://';
+UPDATE report_photos SET thumbnail_url = regexp_replace(thumbnail_url, '^https?://[^/]+/[^/]+/', '') WHERE thumbnail_url ~ '^https?://';
+UPDATE report_photos SET medium_url = regexp_replace(medium_url, '^https?://[^/]+/[^/]+/', '') WHERE medium_url ~ '^https?://';
+
+UPDATE spatial_planning_photos SET photo_url = regexp_replace(photo_url, '^https?://[^/]+/[^/]+/', '') WHERE photo_url ~ '^https?://';
+UPDATE spatial_planning_photos SET thumbnail_url = regexp_replace(thumbnail_url, '^https?://[^/]+/[^/]+/', '') WHERE thumbnail_url ~ '^https?://';
+UPDATE spatial_planning_photos SET medium_url = regexp_replace(medium_url, '^https?://[^/]+/[^/]+/', '') WHERE medium_url ~ '^https?://';
+
+UPDATE water_resources_photos SET photo_url = regexp_replace(photo_url, '^https?://[^/]+/[^/]+/', '') WHERE photo_url ~ '^https?://';
+UPDATE water_resources_photos SET thumbnail_url = regexp_replace(thumbnail_url, '^https?://[^/]+/[^/]+/', '') WHERE thumbnail_url ~ '^https?://';
+UPDATE water_resources_photos SET medium_url = regexp_replace(medium_url, '^https?://[^/]+/[^/]+/', '') WHERE medium_url ~ '^https?://';
+
+UPDATE bina_marga_photos SET photo_url = regexp_replace(photo_url, '^https?://[^/]+/[^/]+/', '') WHERE photo_url ~ '^https?://';
+UPDATE bina_marga_photos SET thumbnail_url = regexp_replace(thumbnail_url, '^https?://[^/]+/[^/]+/', '') WHERE thumbnail_url ~ '^https?://';
+UPDATE bina_marga_photos SET medium_url = regexp_replace(medium_url, '^https?://[^/]+/[^/]+/', '') WHERE medium_url ~ '^https?://';
+
+UPDATE agriculture_photos SET photo_url = regexp_replace(photo_url, '^https?://[^/]+/[^/]+/', '') WHERE photo_url ~ '^https?://';
+UPDATE agriculture_photos SET thumbnail_url = regexp_replace(thumbnail_url, '^https?://[^/]+/[^/]+/', '') WHERE thumbnail_url ~ '^https?://';
+UPDATE agriculture_photos SET medium_url = regexp_replace(medium_url, '^https?://[^/]+/[^/]+/', '') WHERE medium_url ~ '^https?://';
+
+UPDATE upload_session_files SET photo_url = regexp_replace(photo_url, '^https?://[^/]+/[^/]+/', '') WHERE photo_url ~ '^https?://';
+UPDATE upload_session_files SET thumbnail_url = regexp_replace(thumbnail_url, '^https?://[^/]+/[^/]+/', '') WHERE thumbnail_url ~ '^https?://';
+UPDATE upload_session_files SET medium_url = regexp_replace(medium_url, '^https?://[^/]+/[^/]+/', '') WHERE medium_url ~ '^https?://';
+
+-- +goose Down
+-- The public URL the old rows were built from is deployment configuration the
+-- database does not know, and the bucket no longer allows public reads, so the
+-- object names are kept.
+SELECT 1;
@@ -0,0 +1,72 @@
+-- +goose Up
+ALTER TABLE report_photos ADD COLUMN IF NOT EXISTS caption VARCHAR(255);
+ALTER TABLE spatial_planning_photos ADD COLUMN IF NOT EXISTS photo_angle VARCHAR(50);
+
+ALTER TABLE report_photos ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE report_photos ADD COLUMN IF NOT EXISTS repair_phase VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE report_photos ADD COLUMN IF NOT EXISTS linked_status VARCHAR(50) NOT NULL DEFAULT '';
+
+ALTER TABLE spatial_planning_photos ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE spatial_planning_photos ADD COLUMN IF NOT EXISTS repair_phase VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE spatial_planning_photos ADD COLUMN IF NOT EXISTS linked_status VARCHAR(50) NOT NULL DEFAULT '';
+
+ALTER TABLE water_resources_photos ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE water_resources_photos ADD COLUMN IF NOT EXISTS repair_phase VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE water_resources_photos ADD COLUMN IF NOT EXISTS linked_status VARCHAR(50) NOT NULL DEFAULT '';
+
+ALTER TABLE bina_marga_photos ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE bina_marga_photos ADD COLUMN IF NOT EXISTS repair_phase VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE bina_marga_photos ADD COLUMN IF NOT EXISTS linked_status VARCHAR(50) NOT NULL DEFAULT '';
+
+ALTER TABLE agriculture_photos ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE agriculture_photos ADD COLUMN IF NOT EXISTS repair_phase VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE agriculture_photos ADD COLUMN IF NOT EXISTS linked_status VARCHAR(50) NOT NULL DEFAULT '';
+
+-- Existing photos keep the order they were uploaded in.
+UPDATE report_photos p SET position = o.position
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY report_id ORDER BY created_at, id) - 1 AS position FROM report_photos) o
+WHERE p.id = o.id;
+UPDATE spatial_planning_photos p SET position = o.position
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY report_id ORDER BY created_at, id) - 1 AS position FROM spatial_planning_photos) o
+WHERE p.id = o.id;
+UPDATE water_resources_photos p SET position = o.position
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY report_id ORDER BY created_at, id) - 1 AS position FROM water_resources_photos) o
+WHERE p.id = o.id;
+UPDATE bina_marga_photos p SET position = o.position
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY report_id ORDER BY created_at, id) - 1 AS position FROM bina_marga_photos) o
+WHERE p.id = o.id;
+UPDATE agriculture_photos p SET position = o.position
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY report_id ORDER BY created_at, id) - 1 AS position FROM agriculture_photos) o
+WHERE p.id = o.id;
+
+CREATE INDEX IF NOT EXISTS idx_report_photos_report_position ON report_photos(report_id, position);
+CREATE INDEX IF NOT EXISTS idx_spatial_planning_photos_report_position ON spatial_planning_photos(report_id, position);
+CREATE INDEX IF NOT EXISTS idx_water_resources_photos_report_position ON water_resources_photos(report_id, position);
+CREATE INDEX IF NOT EXISTS idx_bina_marga_photos_report_position ON bina_marga_photos(report_id, position);
+CREATE INDEX IF NOT EXISTS idx_agriculture_photos_report_position ON agriculture_photos(report_id, position);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_report_photos_report_position;
+DROP INDEX IF EXISTS idx_spatial_planning_photos_report_position;
+DROP INDEX IF EXISTS idx_water_resources_photos_report_position;
+DROP INDEX IF EXISTS idx_bina_marga_photos_report_position;
+DROP INDEX IF EXISTS idx_agriculture_photos_report_position;
+
+ALTER TABLE report_photos DROP COLUMN IF EXISTS linked_status;
+ALTER TABLE report_photos DROP COLUMN IF EXISTS repair_phase;
+ALTER TABLE report_photos DROP COLUMN IF EXISTS position;
+ALTER TABLE spatial_planning_photos DROP COLUMN IF EXISTS linked_status;
+ALTER TABLE spatial_planning_photos DROP COLUMN IF EXISTS repair_phase;
+ALTER TABLE spatial_planning_photos DROP COLUMN IF EXISTS position;
+ALTER TABLE water_resources_photos DROP COLUMN IF EXISTS linked_status;
+ALTER TABLE water_resources_photos DROP COLUMN IF EXISTS repair_phase;
+ALTER TABLE water_resources_photos DROP COLUMN IF EXISTS position;
+ALTER TABLE bina_marga_photos DROP COLUMN IF EXISTS linked_status;
+ALTER TABLE bina_marga_photos DROP COLUMN IF EXISTS repair_phase;
+ALTER TABLE bina_marga_photos DROP COLUMN IF EXISTS position;
+ALTER TABLE agriculture_photos DROP COLUMN IF EXISTS linked_status;
+ALTER TABLE agriculture_photos DROP COLUMN IF EXISTS repair_phase;
+ALTER TABLE agriculture_photos DROP COLUMN IF EXISTS position;
+
+ALTER TABLE spatial_planning_photos DROP COLUMN IF EXISTS photo_angle;
+ALTER TABLE report_photos DROP COLUMN IF EXISTS caption;
@@ -0,0 +1,52 @@
+-- +goose Up
+ALTER TABLE spatial_planning_reports ADD COLUMN IF NOT EXISTS priority_score INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE water_resources_reports ADD COLUMN IF NOT EXISTS priority_score INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE bina_marga_reports ADD COLUMN IF NOT EXISTS priority_score INTEGER NOT NULL DEFAULT 0;
+
+-- Existing reports get the score CalculatePriority would give them; new
+-- writes keep it up to date from then on.
+UPDATE spatial_planning_reports SET priority_score =
+    (CASE WHEN urgency_level = 'MENDESAK' THEN 100 ELSE 0 END)
+  + (CASE violation_level WHEN 'BERAT' THEN 50 WHEN 'SEDANG' THEN 25 WHEN 'RINGAN' THEN 10 ELSE 0 END)
+  + (CASE environmental_impact
+        WHEN 'POTENSI_BANJIR_LONGSOR' THEN 30
+        WHEN 'GANGGU_AKTIVITAS_WARGA' THEN 20
+        WHEN 'MENURUN_KUALITAS_RUANG' THEN 10
+        ELSE 0 END);
+
+UPDATE water_resources_reports SET priority_score =
+    (CASE WHEN urgency_category = 'MENDESAK' THEN 100 ELSE 0 END)
+  + (CASE damage_level WHEN 'BERAT' THEN 50 WHEN 'SEDANG' THEN 25 WHEN 'RINGAN' THEN 10 ELSE 0 END)
+  + (CASE WHEN COALESCE(affected_rice_field_area, 0) > 10 THEN 30 ELSE 0 END)
+  + (CASE WHEN COALESCE(affected_farmers_count, 0) > 50 THEN 20 ELSE 0 END);
+
+UPDATE bina_marga_reports SET priority_score =
+    (CASE urgency_level WHEN 'DARURAT' THEN 100 WHEN 'CEPAT' THEN 75 WHEN 'RUTIN' THEN 50 WHEN 'RENDAH' THEN 25 ELSE 0 END)
+  + (CASE damage_level WHEN 'BERAT' THEN 50 WHEN 'SEDANG' THEN 30 WHEN 'RINGAN' THEN 15 ELSE 0 END)
+  + (CASE traffic_impact
+        WHEN 'TERPUTUS' THEN 60
+        WHEN 'SANGAT_TERGANGGU' THEN 40
+        WHEN 'TERGANGGU' THEN 20
+        WHEN 'MINIMAL' THEN 5
+        ELSE 0 END)
+  + (CASE
+        WHEN COALESCE(NULLIF(total_damaged_area, 0), damaged_area, 0) > 100 THEN 25
+        WHEN COALESCE(NULLIF(total_damaged_area, 0), damaged_area, 0) > 50 THEN 15
+        ELSE 0 END)
+  + (CASE WHEN COALESCE(bridge_name, '') <> '' AND bridge_damage_level = 'BERAT_TIDAK_LAYAK' THEN 30 ELSE 0 END);
+
+CREATE INDEX IF NOT EXISTS idx_spatial_planning_priority_queue ON spatial_planning_reports(priority_score DESC, created_at DESC)
+  WHERE status NOT IN ('RESOLVED', 'REJECTED');
+CREATE INDEX IF NOT EXISTS idx_water_resources_priority_queue ON water_resources_reports(priority_score DESC, created_at DESC)
+  WHERE status NOT IN ('COMPLETED', 'REJECTED');
+CREATE INDEX IF NOT EXISTS idx_bina_marga_priority_queue ON bina_marga_reports(priority_score DESC, created_at DESC)
+  WHERE status NOT IN ('COMPLETED', 'REJECTED');
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_bina_marga_priority_queue;
+DROP INDEX IF EXISTS idx_water_resources_priority_queue;
+DROP INDEX IF EXISTS idx_spatial_planning_priority_queue;
+
+ALTER TABLE bina_marga_reports DROP COLUMN IF EXISTS priority_score;
+ALTER TABLE water_resources_reports DROP COLUMN IF EXISTS priority_score;
+ALTER TABLE spatial_planning_reports DROP COLUMN IF EXISTS priority_score;
@@ -9,17 +9,43 @@
     )
 
     type Config struct {
-        App      AppConfig
-        Database DatabaseConfig
-        Redis    RedisConfig
-        Minio    MinioConfig
-        JWT      JWTConfig
+        App           AppConfig
+        Database      DatabaseConfig
+        Redis         RedisConfig
+        Minio         MinioConfig
+        JWT           JWTConfig
+        Trash         TrashConfig
+        Upload        UploadConfig
+        Photo         PhotoConfig
+        PasswordReset PasswordResetConfig
+        LoginGuard    LoginGuardConfig
+        RateLimit     RateLimitConfig
+        OIDC          OIDCConfig
+        TwoFactor     TwoFactorConfig
+        Log           LogConfig
+        Metrics       MetricsConfig
+        Health        HealthConfig
+        Tracing       TracingConfig
     }
 
     type AppConfig struct {
         Env            string
         Port           string
         AllowedOrigins string
+        // ProxyHeader names the header carrying the client IP when running behind
+        // a reverse proxy. Leave empty when the app is reached directly.
+        ProxyHeader    string
+        // TrustedProxies is a comma separated list of proxy IPs or CIDR ranges
+        // whose ProxyHeader is believed; from any other peer it is ignored.
+        TrustedProxies string
+        // Timeouts for reading a whole request, writing a response and keeping
+        // an idle keep-alive connection open.
+        ReadTimeoutSeconds  int
+        WriteTimeoutSeconds int
+        IdleTimeoutSeconds  int
+        // ShutdownTimeoutSeconds bounds draining requests and closing resources
+        // after SIGINT or SIGTERM.
+        ShutdownTimeoutSeconds int
     }
 
     type DatabaseConfig struct {
@@ -45,11 +71,125 @@
         UseSSL     bool
         BucketName string
         PublicURL  string
+        // Region signs presigned URLs; it must match the server's region.
+        Region     string
     }
 
     type JWTConfig struct {
-        Secret      string
-        ExpiryHours int
+        Secret             string
+        ExpiryHours        int
+        RefreshExpiryHours int
+    }
+
+    type PasswordResetConfig struct {
+        Sender     string
+        FilePath   string
+        TTLMinutes int
+    }
+
+    type LoginGuardConfig struct {
+        MaxAttempts        int
+        IPMaxAttempts      int
+        WindowMinutes      int
+        BaseLockoutSeconds int
+        MaxLockoutSeconds  int
+    }
+
+    // RateLimitConfig limits are per identity and per route group within the window.
+    type RateLimitConfig struct {
+        Enabled       bool
+        WindowSeconds int
+        AuthLimit     int
+        APILimit      int
+        UploadCost    int
+    }
+
+    // OIDCConfig enables SSO through an OpenID Connect provider next to local logins.
+    type OIDCConfig struct {
+        Enabled          bool
+        IssuerURL        string
+        ClientID         string
+        ClientSecret     string
+        RedirectURL      string
+        Scopes           string
+        // RoleClaim and RoleMapping ("group=ROLE,group=ROLE") derive the user's role.
+        RoleClaim        string
+        RoleMapping      string
+        DefaultRole      string
+        UsernameClaim    string
+        LinkByEmail      bool
+        StateTTLMinutes  int
+    }
+
+    type TwoFactorConfig struct {
+        Issuer               string
+        // RequiredRoles is a comma separated list of roles that must use 2FA.
+        RequiredRoles        string
+        // ExemptSSO leaves SSO users to their identity provider's MFA instead
+        // of making them enroll. Off by default.
+        ExemptSSO            bool
+        // EncryptionKey protects stored TOTP secrets; defaults to the JWT secret.
+        EncryptionKey        string
+        ChallengeTTLMinutes  int
+        MaxChallengeAttempts int
+        RecoveryCodeCount    int
+    }
+
+    type LogConfig struct {
+        Level  string
+        Format string
+        // SlowQueryMs is the duration above which SQL statements are logged as slow.
+        SlowQueryMs int
+    }
+
+    type MetricsConfig struct {
+        Enabled bool
+        // Token, when set, must be sent as a bearer token to read /metrics.
+        Token string
+        // RefreshSeconds is how often the report gauges are recomputed.
+        RefreshSeconds int
+    }
+
+    type HealthConfig struct {
+        // CheckTimeoutMs bounds each dependency check of the readiness endpoint.
+        CheckTimeoutMs int
+    }
+
+    type TracingConfig struct {
+        Enabled bool
+        // Exporter is otlp (OTLP over HTTP to Endpoint) or stdout for local debugging.
+        Exporter string
+        // Endpoint is the OTLP/HTTP base URL; /v1/traces is appended.
+        Endpoint    string
+        ServiceName string
+        // SampleRatio is the fraction of new traces that are recorded, 0 to 1.
+        SampleRatio float64
+    }
+
+    // UploadConfig controls presigned direct-to-storage photo uploads.
+    type UploadConfig struct {
+        // SessionTTLMinutes is how long a client has to upload, confirm and use
+        // a session before the sweeper removes it and its objects.
+        SessionTTLMinutes    int
+        // URLExpiryMinutes is the lifetime of each presigned PUT URL. It is
+        // capped at the session TTL.
+        URLExpiryMinutes     int
+        SweepIntervalMinutes int
+    }
+
+    // PhotoConfig controls how photos in the private bucket are handed to
+    // clients.
+    type PhotoConfig struct {
+        // URLMode is "signed" for presigned MinIO URLs or "proxy" for URLs of
+        // the API's own photo endpoints, for deployments where MinIO is not
+        // reachable by clients.
+        URLMode          string
+        URLExpiryMinutes int
+    }
+
+    type TrashConfig struct {
+        RetentionDays      int
+        PurgeIntervalHours int
     }
 
     func Load() *Config {
@@ -60,9 +200,15 @@
 
         return &Config{
             App: AppConfig{
-                Env:            getEnv("APP_ENV", "development"),
-                Port:           getEnv("APP_PORT", "8081"),
-                AllowedOrigins: getEnv("APP_ALLOWED_ORIGINS", "http://localhost:3000"),
+                Env:                    getEnv("APP_ENV", "development"),
+                Port:                   getEnv("APP_PORT", "8081"),
+                AllowedOrigins:         getEnv("APP_ALLOWED_ORIGINS", "http://localhost:3000"),
+                ProxyHeader:            getEnv("APP_PROXY_HEADER", ""),
+                TrustedProxies:         getEnv("APP_TRUSTED_PROXIES", ""),
+                ReadTimeoutSeconds:     getEnvAsInt("APP_READ_TIMEOUT_SECONDS", 60),
+                WriteTimeoutSeconds:    getEnvAsInt("APP_WRITE_TIMEOUT_SECONDS", 60),
+                IdleTimeoutSeconds:     getEnvAsInt("APP_IDLE_TIMEOUT_SECONDS", 120),
+                ShutdownTimeoutSeconds: getEnvAsInt("APP_SHUTDOWN_TIMEOUT_SECONDS", 30),
             },
             Database: DatabaseConfig{
                 Host:     getEnv("DB_HOST", "localhost"),
@@ -85,10 +231,87 @@
                 UseSSL:     getEnvAsBool("MINIO_USE_SSL", false),
                 BucketName: getEnv("MINIO_BUCKET_NAME", "reports"),
                 PublicURL:  getEnv("MINIO_PUBLIC_URL", "http://localhost:9000"),
+                Region:     getEnv("MINIO_REGION", "us-east-1"),
             },
             JWT: JWTConfig{
-                Secret:      getEnv("JWT_SECRET", "your-secret-key-here"),
-                ExpiryHours: getEnvAsInt("JWT_EXPIRY_HOURS", 24),
+                Secret:             getEnv("JWT_SECRET", "your-secret-key-here"),
+                ExpiryHours:        getEnvAsInt("JWT_EXPIRY_HOURS", 24),
+                RefreshExpiryHours: getEnvAsInt("JWT_REFRESH_EXPIRY_HOURS", 24*7),
+            },
+            PasswordReset: PasswordResetConfig{
+                Sender:     getEnv("PASSWORD_RESET_SENDER", "log"),
+                FilePath:   getEnv("PASSWORD_RESET_FILE", "password_resets.log"),
+                TTLMinutes: getEnvAsInt("PASSWORD_RESET_TTL_MINUTES", 60),
+            },
+            LoginGuard: LoginGuardConfig{
+                MaxAttempts:        getEnvAsInt("LOGIN_MAX_ATTEMPTS", 5),
+                IPMaxAttempts:      getEnvAsInt("LOGIN_IP_MAX_ATTEMPTS", 20),
+                WindowMinutes:      getEnvAsInt("LOGIN_ATTEMPT_WINDOW_MINUTES", 15),
+                BaseLockoutSeconds: getEnvAsInt("LOGIN_LOCKOUT_BASE_SECONDS", 60),
+                MaxLockoutSeconds:  getEnvAsInt("LOGIN_LOCKOUT_MAX_SECONDS", 3600),
+            },
+            RateLimit: RateLimitConfig{
+                Enabled:       getEnvAsBool("RATE_LIMIT_ENABLED", true),
+                WindowSeconds: getEnvAsInt("RATE_LIMIT_WINDOW_SECONDS", 60),
+                AuthLimit:     getEnvAsInt("RATE_LIMIT_AUTH_LIMIT", 20),
+                APILimit:      getEnvAsInt("RATE_LIMIT_API_LIMIT", 300),
+                UploadCost:    getEnvAsInt("RATE_LIMIT_UPLOAD_COST", 10),
+            },
+            OIDC: OIDCConfig{
+                Enabled:         getEnvAsBool("OIDC_ENABLED", false),
+                IssuerURL:       getEnv("OIDC_ISSUER_URL", ""),
+                ClientID:        getEnv("OIDC_CLIENT_ID", ""),
+                ClientSecret:    getEnv("OIDC_CLIENT_SECRET", ""),
+                RedirectURL:     getEnv("OIDC_REDIRECT_URL", "http://localhost:8081/api/v1/auth/oidc/callback"),
+                Scopes:          getEnv("OIDC_SCOPES", "openid profile email"),
+                RoleClaim:       getEnv("OIDC_ROLE_CLAIM", "groups"),
+                RoleMapping:     getEnv("OIDC_ROLE_MAPPING", ""),
+                DefaultRole:     getEnv("OIDC_DEFAULT_ROLE", "VIEWER"),
+                UsernameClaim:   getEnv("OIDC_USERNAME_CLAIM", "preferred_username"),
+                LinkByEmail:     getEnvAsBool("OIDC_LINK_BY_EMAIL", false),
+                StateTTLMinutes: getEnvAsInt("OIDC_STATE_TTL_MINUTES", 10),
+            },
+            TwoFactor: TwoFactorConfig{
+                Issuer:               getEnv("TWO_FACTOR_ISSUER", "Building Report"),
+                RequiredRoles:        getEnv("TWO_FACTOR_REQUIRED_ROLES", ""),
+                ExemptSSO:            getEnvAsBool("TWO_FACTOR_EXEMPT_SSO", false),
+                EncryptionKey:        getEnv("TWO_FACTOR_ENCRYPTION_KEY", ""),
+                ChallengeTTLMinutes:  getEnvAsInt("TWO_FACTOR_CHALLENGE_TTL_MINUTES", 5),
+                MaxChallengeAttempts: getEnvAsInt("TWO_FACTOR_MAX_CHALLENGE_ATTEMPTS", 5),
+                RecoveryCodeCount:    getEnvAsInt("TWO_FACTOR_RECOVERY_CODES", 10),
+            },
+            Trash: TrashConfig{
+                RetentionDays:      getEnvAsInt("TRASH_RETENTION_DAYS", 30),
+                PurgeIntervalHours: getEnvAsInt("TRASH_PURGE_INTERVAL_HOURS", 24),
+            },
+            Upload: UploadConfig{
+                SessionTTLMinutes:    getEnvAsInt("UPLOAD_SESSION_TTL_MINUTES", 60),
+                URLExpiryMinutes:     getEnvAsInt("UPLOAD_URL_EXPIRY_MINUTES", 15),
+                SweepIntervalMinutes: getEnvAsInt("UPLOAD_SWEEP_INTERVAL_MINUTES", 10),
+            },
+            Photo: PhotoConfig{
+                URLMode:          getEnv("PHOTO_URL_MODE", "signed"),
+                URLExpiryMinutes: getEnvAsInt("PHOTO_URL_EXPIRY_MINUTES", 15),
+            },
+            Log: LogConfig{
+                Level:       getEnv("LOG_LEVEL", "info"),
+                Format:      getEnv("LOG_FORMAT", "json"),
+                SlowQueryMs: getEnvAsInt("LOG_SLOW_QUERY_MS", 200),
+            },
+            Metrics: MetricsConfig{
+                Enabled:        getEnvAsBool("METRICS_ENABLED", true),
+                Token:          getEnv("METRICS_TOKEN", ""),
+                RefreshSeconds: getEnvAsInt("METRICS_REFRESH_SECONDS", 60),
+            },
+            Tracing: TracingConfig{
+                Enabled:     getEnvAsBool("TRACING_ENABLED", false),
+                Exporter:    getEnv("TRACING_EXPORTER", "otlp"),
+                Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318"),
+                ServiceName: getEnv("OTEL_SERVICE_NAME", "building-report-backend"),
+                SampleRatio: getEnvAsFloat("TRACING_SAMPLE_RATIO", 1),
+            },
+            Health: HealthConfig{
+                CheckTimeoutMs: getEnvAsInt("HEALTH_CHECK_TIMEOUT_MS", 2000),
             },
         }
     }
@@ -100,6 +323,14 @@
         return defaultValue
     }
 
+    func getEnvAsFloat(key string, defaultValue float64) float64 {
+        valueStr := getEnv(key, "")
+        if value, err := strconv.ParseFloat(valueStr, 64); err == nil {
+            return value
+        }
+        return defaultValue
+    }
+
     func getEnvAsInt(key string, defaultValue int) int {
         valueStr := getEnv(key, "")
         if value, err := strconv.Atoi(valueStr); err == nil {
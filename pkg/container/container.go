@@ -2,17 +2,33 @@
 package container
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
 	"building-report-backend/internal/application/usecase"
+	"building-report-backend/internal/domain/entity"
 	"building-report-backend/internal/domain/repository"
 	"building-report-backend/internal/infrastructure/auth"
+	"building-report-backend/internal/infrastructure/health"
+	"building-report-backend/internal/infrastructure/notification"
+	"building-report-backend/internal/infrastructure/oidc"
 	"building-report-backend/internal/infrastructure/persistence/postgres"
+	"building-report-backend/internal/infrastructure/ratelimit"
 	"building-report-backend/internal/infrastructure/storage"
 	"building-report-backend/internal/interfaces/http/handler"
 	"building-report-backend/pkg/config"
+	"building-report-backend/pkg/database"
+	"building-report-backend/pkg/metrics"
+	minioPkg "building-report-backend/pkg/storage"
     "github.com/redis/go-redis/v9"
     redisPkg "building-report-backend/internal/infrastructure/persistence/redis"
 	
 	"github.com/minio/minio-go/v7"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	
 	"gorm.io/gorm"
 )
@@ -22,6 +38,7 @@ type Container struct {
     DB             *gorm.DB
     Redis          *redis.Client
     MinioClient    *minio.Client
+    Logger         *slog.Logger
      
     UserRepo               repository.UserRepository
     ReportRepo             repository.ReportRepository
@@ -31,10 +48,27 @@ type Container struct {
     BinaMargaRepo          repository.BinaMargaRepository
     AgricultureRepo        repository.AgricultureRepository
     ExecutiveRepo          repository.ExecutiveRepository
+    RiceFieldRepo          repository.RiceFieldRepository
+    AuditLogRepo           repository.AuditLogRepository
+    LoginLockoutRepo       repository.LoginLockoutRepository
+    APIKeyRepo             repository.APIKeyRepository
+    RecoveryCodeRepo       repository.RecoveryCodeRepository
+    ReportMetricsRepo      repository.ReportMetricsRepository
+    ReportLookupRepo       repository.ReportLookupRepository
+    MigrationRepo          repository.MigrationRepository
+    UploadSessionRepo      repository.UploadSessionRepository
 
     StorageService         storage.StorageService
+    PhotoPipeline          storage.PhotoPipeline
+    PhotoLinks             *usecase.PhotoLinks
+    UnitOfWork             *usecase.UnitOfWork
     AuthService            auth.JWTService
+    TokenStore             auth.TokenStore
+    PasswordResetSender    notification.PasswordResetSender
+    RateLimiter            ratelimit.Limiter
      
+    LoginGuard             *usecase.LoginGuard
+    TwoFactorManager       *usecase.TwoFactorManager
     AuthUseCase            *usecase.AuthUseCase
     ReportUseCase          *usecase.ReportUseCase
     SpatialPlanningUseCase *usecase.SpatialPlanningUseCase
@@ -42,6 +76,14 @@ type Container struct {
     BinaMargaUseCase       *usecase.BinaMargaUseCase
     AgricultureUseCase       *usecase.AgricultureUseCase
     ExecutiveUseCase      *usecase.ExecutiveUseCase
+    RiceFieldUseCase       *usecase.RiceFieldUseCase
+    AuditUseCase           *usecase.AuditUseCase
+    TrashUseCase           *usecase.TrashUseCase
+    APIKeyUseCase          *usecase.APIKeyUseCase
+    OIDCUseCase            *usecase.OIDCUseCase
+    HealthUseCase          *usecase.HealthUseCase
+    UploadUseCase          *usecase.UploadUseCase
+    PhotoUseCase           *usecase.PhotoUseCase
      
     AuthHandler            *handler.AuthHandler
     ReportHandler          *handler.ReportHandler
@@ -50,65 +92,234 @@ type Container struct {
     BinaMargaHandler       *handler.BinaMargaHandler
     AgricultureHandler       *handler.AgricultureHandler
     ExecutiveHandler       *handler.ExecutiveHandler
+    RiceFieldHandler       *handler.RiceFieldHandler
+    AuditHandler           *handler.AuditHandler
+    TrashHandler           *handler.TrashHandler
+    APIKeyHandler          *handler.APIKeyHandler
+    OIDCHandler            *handler.OIDCHandler
+    MetricsHandler         *handler.MetricsHandler
+    HealthHandler          *handler.HealthHandler
+    UploadHandler          *handler.UploadHandler
+    PhotoHandler           *handler.PhotoHandler
 }
 
-func NewContainer(cfg *config.Config, db *gorm.DB, redisClient *redis.Client, minioClient *minio.Client) *Container {
+func NewContainer(cfg *config.Config, db *gorm.DB, redisClient *redis.Client, minioClient *minio.Client, logger *slog.Logger) *Container {
     container := &Container{
         Config:      cfg,
         DB:          db,
         Redis:       redisClient,
         MinioClient: minioClient,
+        Logger:      logger,
+    }
+
+    if err := postgres.RegisterDataScope(db); err != nil {
+        fatal(logger, "Failed to register data scope", slog.Any("error", err))
+    }
+    if cfg.Metrics.Enabled {
+        if err := db.Use(database.MetricsPlugin{}); err != nil {
+            fatal(logger, "Failed to register database metrics", slog.Any("error", err))
+        }
+    }
+    if cfg.Tracing.Enabled {
+        if err := db.Use(database.TracingPlugin{}); err != nil {
+            fatal(logger, "Failed to register database tracing", slog.Any("error", err))
+        }
+        if err := redisotel.InstrumentTracing(redisClient); err != nil {
+            fatal(logger, "Failed to register redis tracing", slog.Any("error", err))
+        }
     }
  
     container.UserRepo = postgres.NewUserRepository(db)
     container.ReportRepo = postgres.NewReportRepository(db)
-    container.CacheRepo = redisPkg.NewCacheRepository(redisClient)
+    container.CacheRepo = redisPkg.NewCacheRepository(redisClient, logger)
     container.SpatialPlanningRepo = postgres.NewSpatialPlanningRepository(db)
     container.WaterResourcesRepo = postgres.NewWaterResourcesRepository(db)
     container.BinaMargaRepo = postgres.NewBinaMargaRepository(db)
     container.AgricultureRepo = postgres.NewAgricultureRepository(db)
     container.ExecutiveRepo = postgres.NewExecutiveRepository(db)
+    container.RiceFieldRepo = postgres.NewRiceFieldRepository(db)
+    container.AuditLogRepo = postgres.NewAuditLogRepository(db)
+    container.LoginLockoutRepo = postgres.NewLoginLockoutRepository(db)
+    container.APIKeyRepo = postgres.NewAPIKeyRepository(db)
+    container.RecoveryCodeRepo = postgres.NewRecoveryCodeRepository(db)
+    container.ReportMetricsRepo = postgres.NewReportMetricsRepository(db)
+    container.ReportLookupRepo = postgres.NewReportLookupRepository(db)
+    container.MigrationRepo = postgres.NewMigrationRepository(db)
+    container.UploadSessionRepo = postgres.NewUploadSessionRepository(db)
  
+    presignClient, err := minioPkg.NewMinioPresignClient(cfg.Minio)
+    if err != nil {
+        fatal(logger, "Invalid MinIO configuration", slog.Any("error", err))
+    }
     container.StorageService = storage.NewMinioStorage(
         minioClient,
+        presignClient,
         cfg.Minio.BucketName,
-        cfg.Minio.PublicURL,
+    )
+    container.PhotoPipeline = storage.NewPhotoPipeline(container.StorageService)
+    switch cfg.Photo.URLMode {
+    case usecase.PhotoURLModeSigned, usecase.PhotoURLModeProxy:
+    default:
+        fatal(logger, "Invalid photo URL mode", slog.String("mode", cfg.Photo.URLMode))
+    }
+    container.PhotoLinks = usecase.NewPhotoLinks(
+        container.StorageService,
+        cfg.Photo.URLMode,
+        time.Duration(cfg.Photo.URLExpiryMinutes)*time.Minute,
+        logger,
+    )
+    container.UnitOfWork = usecase.NewUnitOfWork(
+        postgres.NewTransactor(db),
+        container.StorageService,
+        logger,
+    )
+    container.UploadUseCase = usecase.NewUploadUseCase(
+        container.UploadSessionRepo,
+        container.StorageService,
+        container.PhotoPipeline,
+        container.PhotoLinks,
+        time.Duration(cfg.Upload.SessionTTLMinutes)*time.Minute,
+        time.Duration(cfg.Upload.URLExpiryMinutes)*time.Minute,
+        logger,
     )
     container.AuthService = auth.NewJWTService(cfg.JWT.Secret, cfg.JWT.ExpiryHours)
+    container.TokenStore = auth.NewTokenStore(container.CacheRepo)
+    if cfg.RateLimit.Enabled {
+        if err := validateRateLimit(cfg.RateLimit); err != nil {
+            fatal(logger, "Invalid rate limit configuration", slog.Any("error", err))
+        }
+        container.RateLimiter = ratelimit.NewRedisLimiter(redisClient)
+    }
+
+    resetSender, err := notification.NewPasswordResetSender(cfg.PasswordReset.Sender, cfg.PasswordReset.FilePath, logger)
+    if err != nil {
+        fatal(logger, "Invalid password reset sender", slog.Any("error", err))
+    }
+    container.PasswordResetSender = resetSender
  
+    container.LoginGuard = usecase.NewLoginGuard(
+        container.CacheRepo,
+        container.LoginLockoutRepo,
+        usecase.LoginGuardConfig{
+            MaxAttempts:   cfg.LoginGuard.MaxAttempts,
+            IPMaxAttempts: cfg.LoginGuard.IPMaxAttempts,
+            Window:        time.Duration(cfg.LoginGuard.WindowMinutes) * time.Minute,
+            BaseLockout:   time.Duration(cfg.LoginGuard.BaseLockoutSeconds) * time.Second,
+            MaxLockout:    time.Duration(cfg.LoginGuard.MaxLockoutSeconds) * time.Second,
+        },
+        logger,
+    )
+    container.TwoFactorManager = newTwoFactorManager(cfg, container)
     container.AuthUseCase = usecase.NewAuthUseCase(
         container.UserRepo,
         container.AuthService,
         container.CacheRepo,
+        container.TokenStore,
+        time.Duration(cfg.JWT.RefreshExpiryHours)*time.Hour,
+        container.PasswordResetSender,
+        time.Duration(cfg.PasswordReset.TTLMinutes)*time.Minute,
+        container.LoginGuard,
+        container.LoginLockoutRepo,
+        container.TwoFactorManager,
     )
-    container.ReportUseCase = usecase.NewReportUseCase(
+    container.PhotoUseCase = usecase.NewPhotoUseCase(
         container.ReportRepo,
+        container.SpatialPlanningRepo,
+        container.WaterResourcesRepo,
+        container.BinaMargaRepo,
+        container.AgricultureRepo,
+        container.UploadUseCase,
+        container.UnitOfWork,
         container.StorageService,
+        container.PhotoLinks,
+        container.CacheRepo,
+        container.AuditLogRepo,
+        logger,
+    )
+    container.ReportUseCase = usecase.NewReportUseCase(
+        container.ReportRepo,
+        container.UploadUseCase,
+        container.UnitOfWork,
+        container.PhotoLinks,
         container.CacheRepo,
+        container.AuditLogRepo,
     )
     container.SpatialPlanningUseCase = usecase.NewSpatialPlanningUseCase(
         container.SpatialPlanningRepo,
-        container.StorageService,
+        container.UploadUseCase,
+        container.UnitOfWork,
+        container.PhotoLinks,
+        container.PhotoUseCase,
         container.CacheRepo,
+        container.AuditLogRepo,
     )
     container.WaterResourcesUseCase = usecase.NewWaterResourcesUseCase(
         container.WaterResourcesRepo,
-        container.StorageService,
+        container.UploadUseCase,
+        container.UnitOfWork,
+        container.PhotoLinks,
+        container.PhotoUseCase,
         container.CacheRepo,
+        container.AuditLogRepo,
     )
     container.BinaMargaUseCase = usecase.NewBinaMargaUseCase(
         container.BinaMargaRepo,
-        container.StorageService,
+        container.UploadUseCase,
+        container.UnitOfWork,
+        container.PhotoLinks,
+        container.PhotoUseCase,
         container.CacheRepo,
+        container.AuditLogRepo,
     )
      container.AgricultureUseCase = usecase.NewAgricultureUseCase(
         container.AgricultureRepo,
-        container.StorageService,
+        container.UploadUseCase,
+        container.UnitOfWork,
+        container.PhotoLinks,
         container.CacheRepo,
+        container.AuditLogRepo,
     )
     container.ExecutiveUseCase = usecase.NewExecutiveUseCase(
         container.ExecutiveRepo,
     )
+    container.RiceFieldUseCase = usecase.NewRiceFieldUseCase(
+        container.RiceFieldRepo,
+        container.CacheRepo,
+    )
+    container.AuditUseCase = usecase.NewAuditUseCase(
+        container.AuditLogRepo,
+        container.ReportLookupRepo,
+    )
+    container.APIKeyUseCase = usecase.NewAPIKeyUseCase(
+        container.APIKeyRepo,
+        container.UserRepo,
+        logger,
+    )
+    if cfg.OIDC.Enabled {
+        container.OIDCUseCase = newOIDCUseCase(cfg.OIDC, container)
+    }
+    container.TrashUseCase = usecase.NewTrashUseCase(
+        container.ReportRepo,
+        container.SpatialPlanningRepo,
+        container.WaterResourcesRepo,
+        container.BinaMargaRepo,
+        container.AgricultureRepo,
+        container.UnitOfWork,
+        container.PhotoLinks,
+        container.CacheRepo,
+        container.AuditLogRepo,
+        logger,
+    )
+    container.HealthUseCase = usecase.NewHealthUseCase(
+        []health.Checker{
+            health.Postgres(db),
+            health.Redis(redisClient),
+            health.Minio(minioClient, cfg.Minio.BucketName),
+        },
+        container.MigrationRepo,
+        time.Duration(cfg.Health.CheckTimeoutMs)*time.Millisecond,
+        logger,
+    )
     
     container.AuthHandler = handler.NewAuthHandler(
         container.AuthUseCase,
@@ -131,8 +342,136 @@ func NewContainer(cfg *config.Config, db *gorm.DB, redisClient *redis.Client, mi
     container.ExecutiveHandler = handler.NewExecutiveHandler(
         container.ExecutiveUseCase,
     )
-
+    container.RiceFieldHandler = handler.NewRiceFieldHandler(
+        container.RiceFieldUseCase,
+    )
+    container.AuditHandler = handler.NewAuditHandler(
+        container.AuditUseCase,
+    )
+    container.TrashHandler = handler.NewTrashHandler(
+        container.TrashUseCase,
+    )
+    container.APIKeyHandler = handler.NewAPIKeyHandler(
+        container.APIKeyUseCase,
+    )
+    container.HealthHandler = handler.NewHealthHandler(
+        container.HealthUseCase,
+    )
+    container.UploadHandler = handler.NewUploadHandler(
+        container.UploadUseCase,
+    )
+    container.PhotoHandler = handler.NewPhotoHandler(
+        container.PhotoUseCase,
+    )
+    if cfg.Metrics.Enabled {
+        container.MetricsHandler = handler.NewMetricsHandler(metrics.Default, cfg.Metrics.Token)
+    }
+    if container.OIDCUseCase != nil {
+        container.OIDCHandler = handler.NewOIDCHandler(
+            container.OIDCUseCase,
+        )
+    }
 
     return container
 
-}
\ No newline at end of file
+}
+
+func newOIDCUseCase(cfg config.OIDCConfig, container *Container) *usecase.OIDCUseCase {
+    rules, err := usecase.ParseOIDCRoleMapping(cfg.RoleMapping)
+    if err != nil {
+        fatal(container.Logger, "Invalid OIDC_ROLE_MAPPING", slog.Any("error", err))
+    }
+    defaultRole := entity.UserRole(strings.ToUpper(cfg.DefaultRole))
+    if !defaultRole.IsValid() {
+        fatal(container.Logger, "Invalid OIDC_DEFAULT_ROLE", slog.String("value", cfg.DefaultRole))
+    }
+    if cfg.IssuerURL == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+        fatal(container.Logger, "OIDC_ISSUER_URL, OIDC_CLIENT_ID and OIDC_REDIRECT_URL are required when OIDC is enabled")
+    }
+
+    provider := oidc.NewProvider(oidc.Config{
+        IssuerURL:    cfg.IssuerURL,
+        ClientID:     cfg.ClientID,
+        ClientSecret: cfg.ClientSecret,
+        RedirectURL:  cfg.RedirectURL,
+        Scopes:       strings.Fields(cfg.Scopes),
+    })
+
+    return usecase.NewOIDCUseCase(
+        provider,
+        container.UserRepo,
+        container.CacheRepo,
+        container.AuthUseCase,
+        usecase.OIDCConfig{
+            RoleClaim:     cfg.RoleClaim,
+            RoleRules:     rules,
+            DefaultRole:   defaultRole,
+            UsernameClaim: cfg.UsernameClaim,
+            LinkByEmail:   cfg.LinkByEmail,
+            StateTTL:      time.Duration(cfg.StateTTLMinutes) * time.Minute,
+        },
+        container.Logger,
+    )
+}
+
+func newTwoFactorManager(cfg *config.Config, container *Container) *usecase.TwoFactorManager {
+    var requiredRoles []entity.UserRole
+    for _, value := range strings.Split(cfg.TwoFactor.RequiredRoles, ",") {
+        role := entity.UserRole(strings.ToUpper(strings.TrimSpace(value)))
+        if role == "" {
+            continue
+        }
+        if !role.IsValid() {
+            fatal(container.Logger, "Invalid TWO_FACTOR_REQUIRED_ROLES", slog.String("value", value))
+        }
+        requiredRoles = append(requiredRoles, role)
+    }
+
+    encryptionKey := cfg.TwoFactor.EncryptionKey
+    if encryptionKey == "" {
+        encryptionKey = cfg.JWT.Secret
+    }
+    secretBox, err := auth.NewSecretBox(encryptionKey)
+    if err != nil {
+        fatal(container.Logger, "Failed to initialise two-factor secret encryption", slog.Any("error", err))
+    }
+
+    return usecase.NewTwoFactorManager(
+        container.CacheRepo,
+        container.RecoveryCodeRepo,
+        secretBox,
+        usecase.TwoFactorConfig{
+            Issuer:               cfg.TwoFactor.Issuer,
+            RequiredRoles:        requiredRoles,
+            ExemptSSO:            cfg.TwoFactor.ExemptSSO,
+            ChallengeTTL:         time.Duration(cfg.TwoFactor.ChallengeTTLMinutes) * time.Minute,
+            MaxChallengeAttempts: cfg.TwoFactor.MaxChallengeAttempts,
+            RecoveryCodeCount:    cfg.TwoFactor.RecoveryCodeCount,
+            SetupTTL:             15 * time.Minute,
+        },
+    )
+}
+
+// fatal logs a configuration error the server cannot start with and exits.
+// validateRateLimit rejects settings the limiter cannot enforce: a window of
+// zero, or an upload cost above a limit, which would refuse every upload.
+func validateRateLimit(cfg config.RateLimitConfig) error {
+    if cfg.WindowSeconds <= 0 {
+        return fmt.Errorf("RATE_LIMIT_WINDOW_SECONDS must be positive, got %d", cfg.WindowSeconds)
+    }
+    if cfg.UploadCost < 1 {
+        return fmt.Errorf("RATE_LIMIT_UPLOAD_COST must be at least 1, got %d", cfg.UploadCost)
+    }
+    if cfg.AuthLimit > 0 && cfg.UploadCost > cfg.AuthLimit {
+        return fmt.Errorf("RATE_LIMIT_UPLOAD_COST (%d) exceeds RATE_LIMIT_AUTH_LIMIT (%d)", cfg.UploadCost, cfg.AuthLimit)
+    }
+    if cfg.APILimit > 0 && cfg.UploadCost > cfg.APILimit {
+        return fmt.Errorf("RATE_LIMIT_UPLOAD_COST (%d) exceeds RATE_LIMIT_API_LIMIT (%d)", cfg.UploadCost, cfg.APILimit)
+    }
+    return nil
+}
+
+func fatal(log *slog.Logger, msg string, attrs ...slog.Attr) {
+    log.LogAttrs(context.Background(), slog.LevelError, msg, attrs...)
+    os.Exit(1)
+}
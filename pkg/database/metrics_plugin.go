@@ -0,0 +1,87 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"building-report-backend/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+var (
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "db_query_duration_seconds",
+		Help: "Duration of database statements by operation and table.",
+	}, []string{"operation", "table"})
+	queryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_errors_total",
+		Help: "Database statements that failed, not counting record not found.",
+	}, []string{"operation", "table"})
+)
+
+func init() {
+	metrics.Default.MustRegister(queryDuration, queryErrors)
+}
+
+const queryStartKey = "metrics:query_start"
+
+// MetricsPlugin is a GORM plugin that times every statement.
+type MetricsPlugin struct{}
+
+func (MetricsPlugin) Name() string {
+	return "metrics"
+}
+
+func (MetricsPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	processors := []struct {
+		operation string
+		before    func(name string, fn func(*gorm.DB)) error
+		after     func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+
+	for _, p := range processors {
+		if err := p.before("metrics:before_"+p.operation, startQueryTimer); err != nil {
+			return err
+		}
+		if err := p.after("metrics:after_"+p.operation, observeQuery(p.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startQueryTimer(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+func observeQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "raw"
+		}
+		queryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			queryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
@@ -2,22 +2,23 @@ package database
 
 import (
 	"fmt"
+	"log/slog"
+	"time"
 
 	"building-report-backend/pkg/config"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
-func NewPostgresDB(cfg config.DatabaseConfig) (*gorm.DB, error) {
+func NewPostgresDB(cfg config.DatabaseConfig, log *slog.Logger, slowQuery time.Duration) (*gorm.DB, error) {
 	dsn := fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
 		cfg.Host, cfg.User, cfg.Password, cfg.DBName, cfg.Port, cfg.SSLMode,
 	)
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: newGormLogger(log, slowQuery),
 	})
 	if err != nil {
 		return nil, err
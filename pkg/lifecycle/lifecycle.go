@@ -0,0 +1,102 @@
+// Package lifecycle runs the server until SIGINT or SIGTERM and then shuts the
+// application down in order within a deadline.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+type Manager struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	timeout time.Duration
+	hooks   []hook
+	log     *slog.Logger
+}
+
+// New returns a manager whose shutdown hooks share a deadline of timeout.
+func New(timeout time.Duration, log *slog.Logger) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		ctx:     ctx,
+		cancel:  cancel,
+		timeout: timeout,
+		log:     log,
+	}
+}
+
+// Context is cancelled as soon as shutdown begins. Background workers run
+// under it and stop when it is done.
+func (m *Manager) Context() context.Context {
+	return m.ctx
+}
+
+// OnShutdown registers fn to run during shutdown. Hooks run one at a time in
+// reverse order of registration, like deferred calls, so a resource registered
+// right after it is opened is closed after everything that uses it.
+func (m *Manager) OnShutdown(name string, fn func(ctx context.Context) error) {
+	m.hooks = append(m.hooks, hook{name: name, fn: fn})
+}
+
+// Run calls serve in the background and blocks until it fails or the process
+// receives SIGINT or SIGTERM, then shuts down. It returns serve's error, if
+// any, joined with the errors of the hooks.
+func (m *Manager) Run(serve func() error) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- serve()
+	}()
+
+	var err error
+	select {
+	case sig := <-signals:
+		m.log.Info("shutdown signal received", slog.String("signal", sig.String()))
+	case err = <-serveErr:
+		if err != nil {
+			m.log.Error("server stopped", slog.String("error", err.Error()))
+		}
+	}
+
+	return errors.Join(err, m.Shutdown())
+}
+
+// Shutdown cancels Context and runs the hooks. Hooks still run after the
+// deadline has passed, with an expired context, so resources are released
+// even when draining took too long.
+func (m *Manager) Shutdown() error {
+	m.cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	start := time.Now()
+	var errs []error
+	for i := len(m.hooks) - 1; i >= 0; i-- {
+		h := m.hooks[i]
+		if err := h.fn(ctx); err != nil {
+			m.log.Error("shutdown step failed", slog.String("step", h.name), slog.String("error", err.Error()))
+			errs = append(errs, err)
+			continue
+		}
+		m.log.Info("shutdown step completed", slog.String("step", h.name))
+	}
+	m.hooks = nil
+
+	m.log.Info("shutdown completed", slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000))
+	return errors.Join(errs...)
+}
@@ -1,97 +1,133 @@
+// Package logger builds the application's structured logger on log/slog. Every
+// record carries the request ID, user ID and trace ID found in its context, and
+// values of sensitive attributes such as passwords and phone numbers are
+// redacted.
 package logger
 
 import (
-	"log"
-	"os"
-)
+	"context"
+	"io"
+	"log/slog"
+	"strings"
 
-// Logger levels
-const (
-	DEBUG = "DEBUG"
-	INFO  = "INFO"
-	WARN  = "WARN"
-	ERROR = "ERROR"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// Logger interface
-type Logger interface {
-	Debug(msg string, args ...interface{})
-	Info(msg string, args ...interface{})
-	Warn(msg string, args ...interface{})
-	Error(msg string, args ...interface{})
-}
-
-// SimpleLogger implements Logger interface using standard log package
-type SimpleLogger struct {
-	debugLogger *log.Logger
-	infoLogger  *log.Logger
-	warnLogger  *log.Logger
-	errorLogger *log.Logger
+type Config struct {
+	// Level is debug, info, warn or error.
+	Level string
+	// Format is json or text.
+	Format string
 }
 
-// NewSimpleLogger creates a new simple logger
-func NewSimpleLogger() *SimpleLogger {
-	return &SimpleLogger{
-		debugLogger: log.New(os.Stdout, "[DEBUG] ", log.LstdFlags|log.Lshortfile),
-		infoLogger:  log.New(os.Stdout, "[INFO] ", log.LstdFlags|log.Lshortfile),
-		warnLogger:  log.New(os.Stdout, "[WARN] ", log.LstdFlags|log.Lshortfile),
-		errorLogger: log.New(os.Stderr, "[ERROR] ", log.LstdFlags|log.Lshortfile),
+// New returns a logger writing to w. Log through the *Context methods, e.g.
+// InfoContext, so request-scoped attributes are attached.
+func New(cfg Config, w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level:       ParseLevel(cfg.Level),
+		ReplaceAttr: redactAttr,
 	}
-}
 
-func (l *SimpleLogger) Debug(msg string, args ...interface{}) {
-	if len(args) > 0 {
-		l.debugLogger.Printf(msg, args...)
+	var handler slog.Handler
+	if strings.EqualFold(cfg.Format, "text") {
+		handler = slog.NewTextHandler(w, opts)
 	} else {
-		l.debugLogger.Println(msg)
+		handler = slog.NewJSONHandler(w, opts)
 	}
+
+	return slog.New(contextHandler{handler})
 }
 
-func (l *SimpleLogger) Info(msg string, args ...interface{}) {
-	if len(args) > 0 {
-		l.infoLogger.Printf(msg, args...)
-	} else {
-		l.infoLogger.Println(msg)
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
 	}
+	return slog.LevelInfo
 }
 
-func (l *SimpleLogger) Warn(msg string, args ...interface{}) {
-	if len(args) > 0 {
-		l.warnLogger.Printf(msg, args...)
-	} else {
-		l.warnLogger.Println(msg)
+// Err is the attribute used for errors throughout the application.
+func Err(err error) slog.Attr {
+	if err == nil {
+		return slog.Attr{}
 	}
+	return slog.String("error", err.Error())
 }
 
-func (l *SimpleLogger) Error(msg string, args ...interface{}) {
-	if len(args) > 0 {
-		l.errorLogger.Printf(msg, args...)
-	} else {
-		l.errorLogger.Println(msg)
+// Discard returns a logger that drops everything, for tools that need one.
+func Discard() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+type contextKey string
+
+// RequestIDKey holds the request ID in a context. The HTTP layer sets it as a
+// fiber local, which fasthttp exposes through Context().Value.
+const RequestIDKey contextKey = "requestID"
+
+// LoggerKey holds a logger in a context, for code without a logger of its own.
+const LoggerKey contextKey = "logger"
+
+// userIDLocal is the fiber local the auth middleware stores the user ID under.
+const userIDLocal = "userID"
+
+func WithLogger(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, LoggerKey, log)
+}
+
+// FromContext returns the logger stored in ctx, or slog.Default if there is none.
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if log, ok := ctx.Value(LoggerKey).(*slog.Logger); ok {
+			return log
+		}
 	}
+	return slog.Default()
 }
 
-// Global logger instance
-var GlobalLogger Logger = NewSimpleLogger()
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, requestID)
+}
 
-// Package-level functions for convenience
-func Debug(msg string, args ...interface{}) {
-	GlobalLogger.Debug(msg, args...)
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+	return requestID
 }
 
-func Info(msg string, args ...interface{}) {
-	GlobalLogger.Info(msg, args...)
+// contextHandler adds the request, user, trace and span ID of the record's context.
+type contextHandler struct {
+	slog.Handler
 }
 
-func Warn(msg string, args ...interface{}) {
-	GlobalLogger.Warn(msg, args...)
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if requestID := RequestIDFromContext(ctx); requestID != "" {
+			r.AddAttrs(slog.String("request_id", requestID))
+		}
+		if userID, ok := ctx.Value(userIDLocal).(string); ok && userID != "" {
+			r.AddAttrs(slog.String("user_id", userID))
+		}
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			r.AddAttrs(
+				slog.String("trace_id", span.TraceID().String()),
+				slog.String("span_id", span.SpanID().String()),
+			)
+		}
+	}
+	return h.Handler.Handle(ctx, r)
 }
 
-func Error(msg string, args ...interface{}) {
-	GlobalLogger.Error(msg, args...)
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
 }
 
-// SetLogger sets the global logger instance
-func SetLogger(logger Logger) {
-	GlobalLogger = logger
-}
\ No newline at end of file
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"log/slog"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute and map keys whose values never reach the logs.
+var sensitiveKeys = map[string]bool{
+	"password":          true,
+	"current_password":  true,
+	"new_password":      true,
+	"confirm_password":  true,
+	"phone":             true,
+	"phone_number":      true,
+	"token":             true,
+	"access_token":      true,
+	"refresh_token":     true,
+	"authorization":     true,
+	"api_key":           true,
+	"x-api-key":         true,
+	"secret":            true,
+	"client_secret":     true,
+	"two_factor_secret": true,
+	"code":              true,
+	"recovery_code":     true,
+}
+
+func isSensitive(key string) bool {
+	return sensitiveKeys[strings.ToLower(key)]
+}
+
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if isSensitive(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+	if a.Value.Kind() == slog.KindAny {
+		switch v := a.Value.Any().(type) {
+		case map[string]interface{}:
+			return slog.Any(a.Key, RedactMap(v))
+		case map[string]string:
+			return slog.Any(a.Key, redactStringMap(v))
+		}
+	}
+	return a
+}
+
+// RedactMap returns a copy of m, such as a decoded request body, with the
+// values of sensitive keys replaced at any depth.
+func RedactMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		switch {
+		case isSensitive(k):
+			out[k] = redacted
+		default:
+			if nested, ok := v.(map[string]interface{}); ok {
+				v = RedactMap(nested)
+			}
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func redactStringMap(m map[string]string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if isSensitive(k) {
+			v = redacted
+		}
+		out[k] = v
+	}
+	return out
+}
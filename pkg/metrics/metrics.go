@@ -0,0 +1,20 @@
+// Package metrics holds the Prometheus registry the application exposes on
+// /metrics. Metrics are declared with the Prometheus client library as package
+// variables next to the code they measure and registered on Default.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// Default is the registry served on /metrics. It also carries the Go runtime
+// and process metrics.
+var Default = prometheus.NewRegistry()
+
+func init() {
+	Default.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
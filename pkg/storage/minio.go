@@ -3,7 +3,9 @@ package storage
 
 import (
     "context"
+    "fmt"
     "log"
+    "net/url"
     "building-report-backend/pkg/config"
     
     "github.com/minio/minio-go/v7"
@@ -32,25 +34,38 @@ func NewMinioClient(cfg config.MinioConfig) (*minio.Client, error) {
             return nil, err
         }
         log.Printf("Bucket %s created successfully", cfg.BucketName)
-        
-        
-        policy := `{
-            "Version": "2012-10-17",
-            "Statement": [
-                {
-                    "Effect": "Allow",
-                    "Principal": "*",
-                    "Action": ["s3:GetObject"],
-                    "Resource": ["arn:aws:s3:::` + cfg.BucketName + `/*"]
-                }
-            ]
-        }`
-        
-        err = client.SetBucketPolicy(ctx, cfg.BucketName, policy)
-        if err != nil {
-            log.Printf("Failed to set bucket policy: %v", err)
+    }
+
+    // Photos show people's homes and land, so the bucket is private and clients
+    // only get signed or proxied URLs. Earlier versions made it public-read;
+    // that policy is removed.
+    policy, err := client.GetBucketPolicy(ctx, cfg.BucketName)
+    if err != nil {
+        return nil, err
+    }
+    if policy != "" {
+        if err := client.SetBucketPolicy(ctx, cfg.BucketName, ""); err != nil {
+            return nil, fmt.Errorf("failed to remove policy of bucket %s: %w", cfg.BucketName, err)
         }
+        log.Printf("Removed the access policy of bucket %s", cfg.BucketName)
     }
 
     return client, nil
-}
\ No newline at end of file
+}
+
+// NewMinioPresignClient returns a client for signing URLs that browsers and
+// field devices use directly, so it points at cfg.PublicURL rather than the
+// internal endpoint. The region is fixed, which lets it sign without a request
+// to the server.
+func NewMinioPresignClient(cfg config.MinioConfig) (*minio.Client, error) {
+    publicURL, err := url.Parse(cfg.PublicURL)
+    if err != nil || publicURL.Host == "" {
+        return nil, fmt.Errorf("invalid MINIO_PUBLIC_URL %q", cfg.PublicURL)
+    }
+
+    return minio.New(publicURL.Host, &minio.Options{
+        Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+        Secure: publicURL.Scheme == "https",
+        Region: cfg.Region,
+    })
+}
@@ -0,0 +1,65 @@
+// Package version describes the running build. GitSHA and BuildTime are set at
+// link time:
+//
+//	go build -ldflags "-X building-report-backend/pkg/version.GitSHA=$(git rev-parse HEAD)"
+//
+// When they are not, GitSHA falls back to the VCS revision Go stamps into
+// binaries built from a checkout.
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+var (
+	Version   = "dev"
+	GitSHA    = ""
+	BuildTime = ""
+)
+
+type Info struct {
+	Version   string `json:"version"`
+	GitSHA    string `json:"git_sha"`
+	BuildTime string `json:"build_time,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+var (
+	once sync.Once
+	info Info
+)
+
+// Get returns the build information, read once.
+func Get() Info {
+	once.Do(func() {
+		info = Info{
+			Version:   Version,
+			GitSHA:    GitSHA,
+			BuildTime: BuildTime,
+			GoVersion: runtime.Version(),
+		}
+
+		build, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.GitSHA == "" {
+					info.GitSHA = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	})
+	return info
+}
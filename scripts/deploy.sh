@@ -36,7 +36,7 @@ git pull origin main
 # Build and deploy
 echo "🔨 Building and starting services..."
 docker-compose down
-docker-compose up -d --build
+GIT_SHA=$(git rev-parse HEAD 2>/dev/null || echo unknown) docker-compose up -d --build
 
 # Wait for services to be ready
 echo "⏳ Waiting for services to start..."
@@ -54,7 +54,7 @@ fi
 
 # Health check
 echo "🏥 Running health check..."
-if curl -f -s http://localhost:8080/health > /dev/null; then
+if curl -f -s http://localhost:8080/api/v1/health/ready > /dev/null; then
     echo "✅ Application is healthy!"
 else
     echo "⚠️ Health check failed. Check application logs:"